@@ -0,0 +1,362 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gokatarajesh/quiz-platform/internal/question"
+)
+
+// Supported file formats.
+const (
+	FormatJSONL   = "jsonl"
+	FormatCSV     = "csv"
+	FormatOpenTDB = "opentdb" // Open Trivia DB API dump (import only)
+)
+
+// listSeparator joins options and tags inside a single CSV cell.
+const listSeparator = "|"
+
+var csvColumns = []string{"prompt", "options", "correct_answer", "category", "difficulty", "tags", "source", "verified"}
+
+var validSources = map[string]bool{"curated": true, "quizapi": true, "kaggle": true, "ai": true}
+
+var validDifficulties = map[string]bool{
+	question.DifficultyEasy:   true,
+	question.DifficultyMedium: true,
+	question.DifficultyHard:   true,
+}
+
+// Record is one question in the import/export formats.
+type Record struct {
+	Prompt        string   `json:"prompt"`
+	Options       []string `json:"options"`
+	CorrectAnswer string   `json:"correct_answer"`
+	Category      string   `json:"category,omitempty"`
+	Difficulty    string   `json:"difficulty,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	Source        string   `json:"source,omitempty"`
+	Verified      *bool    `json:"verified,omitempty"`
+
+	line int // position in the input file, for reject reports
+}
+
+// Reject is an input record that was not imported.
+type Reject struct {
+	Line   int    `json:"line"`
+	Prompt string `json:"prompt,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// RecordDefaults fill fields the input file leaves empty.
+type RecordDefaults struct {
+	Source   string
+	Verified bool
+}
+
+// readRecords parses every record in r. Malformed entries are returned as rejects;
+// only unreadable input fails the whole read.
+func readRecords(r io.Reader, format string) ([]Record, []Reject, error) {
+	switch format {
+	case FormatJSONL:
+		return readJSONL(r)
+	case FormatCSV:
+		return readCSV(r)
+	case FormatOpenTDB:
+		return readOpenTDB(r)
+	default:
+		return nil, nil, fmt.Errorf("unsupported import format %q", format)
+	}
+}
+
+func readJSONL(r io.Reader) ([]Record, []Reject, error) {
+	var records []Record
+	var rejects []Reject
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var rec Record
+		if err := json.Unmarshal([]byte(text), &rec); err != nil {
+			rejects = append(rejects, Reject{Line: line, Reason: fmt.Sprintf("invalid json: %v", err)})
+			continue
+		}
+		rec.line = line
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("read jsonl: %w", err)
+	}
+	return records, rejects, nil
+}
+
+func readCSV(r io.Reader) ([]Record, []Reject, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read csv header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"prompt", "options", "correct_answer"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, fmt.Errorf("csv header is missing column %q", required)
+		}
+	}
+
+	var records []Record
+	var rejects []Reject
+	line := 1
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+		if err != nil {
+			rejects = append(rejects, Reject{Line: line, Reason: fmt.Sprintf("invalid csv: %v", err)})
+			continue
+		}
+
+		cell := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		rec := Record{
+			Prompt:        cell("prompt"),
+			Options:       splitList(cell("options")),
+			CorrectAnswer: cell("correct_answer"),
+			Category:      cell("category"),
+			Difficulty:    cell("difficulty"),
+			Tags:          splitList(cell("tags")),
+			Source:        cell("source"),
+			line:          line,
+		}
+		if v := cell("verified"); v != "" {
+			verified, err := strconv.ParseBool(v)
+			if err != nil {
+				rejects = append(rejects, Reject{Line: line, Prompt: rec.Prompt, Reason: fmt.Sprintf("invalid verified value %q", v)})
+				continue
+			}
+			rec.Verified = &verified
+		}
+		records = append(records, rec)
+	}
+	return records, rejects, nil
+}
+
+// openTDBDump is the response body of https://opentdb.com/api.php (default HTML encoding).
+type openTDBDump struct {
+	Results []struct {
+		Category         string   `json:"category"`
+		Type             string   `json:"type"`
+		Difficulty       string   `json:"difficulty"`
+		Question         string   `json:"question"`
+		CorrectAnswer    string   `json:"correct_answer"`
+		IncorrectAnswers []string `json:"incorrect_answers"`
+	} `json:"results"`
+}
+
+func readOpenTDB(r io.Reader) ([]Record, []Reject, error) {
+	var dump openTDBDump
+	if err := json.NewDecoder(r).Decode(&dump); err != nil {
+		return nil, nil, fmt.Errorf("decode opentdb dump: %w", err)
+	}
+
+	var records []Record
+	var rejects []Reject
+	for i, item := range dump.Results {
+		prompt := html.UnescapeString(item.Question)
+		if item.Type != "multiple" {
+			rejects = append(rejects, Reject{Line: i + 1, Prompt: prompt, Reason: fmt.Sprintf("unsupported question type %q", item.Type)})
+			continue
+		}
+
+		answer := html.UnescapeString(item.CorrectAnswer)
+		options := []string{answer}
+		for _, wrong := range item.IncorrectAnswers {
+			options = append(options, html.UnescapeString(wrong))
+		}
+		// The dump always lists the correct answer first; don't carry that into the bank.
+		sort.Strings(options)
+
+		records = append(records, Record{
+			Prompt:        prompt,
+			Options:       options,
+			CorrectAnswer: answer,
+			Category:      html.UnescapeString(item.Category),
+			Difficulty:    item.Difficulty,
+			line:          i + 1,
+		})
+	}
+	return records, rejects, nil
+}
+
+// normalizeRecord validates a record and fills defaults in place.
+func normalizeRecord(rec *Record, defaults RecordDefaults) error {
+	rec.Prompt = strings.TrimSpace(rec.Prompt)
+	if rec.Prompt == "" {
+		return errors.New("prompt is empty")
+	}
+
+	options := make([]string, 0, len(rec.Options))
+	seen := make(map[string]bool, len(rec.Options))
+	for _, opt := range rec.Options {
+		opt = strings.TrimSpace(opt)
+		if opt == "" {
+			continue
+		}
+		key := strings.ToLower(opt)
+		if seen[key] {
+			return fmt.Errorf("duplicate option %q", opt)
+		}
+		seen[key] = true
+		options = append(options, opt)
+	}
+	if len(options) < 2 {
+		return errors.New("at least two options are required")
+	}
+	rec.Options = options
+
+	answer := strings.TrimSpace(rec.CorrectAnswer)
+	rec.CorrectAnswer = ""
+	for _, opt := range options {
+		if strings.EqualFold(opt, answer) {
+			rec.CorrectAnswer = opt
+			break
+		}
+	}
+	if rec.CorrectAnswer == "" {
+		return fmt.Errorf("correct_answer %q is not one of the options", answer)
+	}
+
+	rec.Category = normalizeCategory(rec.Category)
+
+	rec.Difficulty = strings.ToLower(strings.TrimSpace(rec.Difficulty))
+	if rec.Difficulty == "" {
+		rec.Difficulty = question.DifficultyMedium
+	}
+	if !validDifficulties[rec.Difficulty] {
+		return fmt.Errorf("unknown difficulty %q", rec.Difficulty)
+	}
+
+	tags := make([]string, 0, len(rec.Tags))
+	for _, tag := range rec.Tags {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	rec.Tags = tags
+
+	if rec.Source == "" {
+		rec.Source = defaults.Source
+	}
+	if !validSources[rec.Source] {
+		return fmt.Errorf("unknown source %q", rec.Source)
+	}
+	if rec.Verified == nil {
+		verified := defaults.Verified
+		rec.Verified = &verified
+	}
+	return nil
+}
+
+// normalizeCategory turns labels like "Entertainment: Video Games" into "entertainment_video_games".
+func normalizeCategory(category string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(strings.TrimSpace(category)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+		if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+	normalized := strings.TrimSuffix(b.String(), "_")
+	if normalized == "" {
+		return "general"
+	}
+	return normalized
+}
+
+// promptKey is the identity used to dedupe prompts (case and whitespace insensitive).
+func promptKey(prompt string) string {
+	return strings.ToLower(strings.Join(strings.Fields(prompt), " "))
+}
+
+// writeRecords encodes records in the given export format.
+func writeRecords(w io.Writer, format string, records []Record) error {
+	switch format {
+	case FormatJSONL:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		for _, rec := range records {
+			if err := enc.Encode(rec); err != nil {
+				return fmt.Errorf("write jsonl: %w", err)
+			}
+		}
+		return nil
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(csvColumns); err != nil {
+			return fmt.Errorf("write csv header: %w", err)
+		}
+		for _, rec := range records {
+			verified := ""
+			if rec.Verified != nil {
+				verified = strconv.FormatBool(*rec.Verified)
+			}
+			row := []string{
+				rec.Prompt,
+				strings.Join(rec.Options, listSeparator),
+				rec.CorrectAnswer,
+				rec.Category,
+				rec.Difficulty,
+				strings.Join(rec.Tags, listSeparator),
+				rec.Source,
+				verified,
+			}
+			if err := writer.Write(row); err != nil {
+				return fmt.Errorf("write csv: %w", err)
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+func splitList(cell string) []string {
+	if cell == "" {
+		return nil
+	}
+	return strings.Split(cell, listSeparator)
+}
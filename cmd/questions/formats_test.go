@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOpenTDB(t *testing.T) {
+	dump := `{"response_code":0,"results":[
+		{"category":"Entertainment: Video Games","type":"multiple","difficulty":"hard","question":"Who is &quot;Mario&quot;&#039;s brother?","correct_answer":"Luigi","incorrect_answers":["Wario","Toad","Yoshi"]},
+		{"category":"Science","type":"boolean","difficulty":"easy","question":"Water is wet.","correct_answer":"True","incorrect_answers":["False"]}
+	]}`
+
+	records, rejects, err := readRecords(strings.NewReader(dump), FormatOpenTDB)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Len(t, rejects, 1)
+	assert.Equal(t, 2, rejects[0].Line)
+
+	rec := records[0]
+	require.NoError(t, normalizeRecord(&rec, RecordDefaults{Source: "curated", Verified: true}))
+	assert.Equal(t, `Who is "Mario"'s brother?`, rec.Prompt)
+	assert.Equal(t, []string{"Luigi", "Toad", "Wario", "Yoshi"}, rec.Options)
+	assert.Equal(t, "entertainment_video_games", rec.Category)
+	assert.Equal(t, "hard", rec.Difficulty)
+}
+
+func TestNormalizeRecordValidation(t *testing.T) {
+	defaults := RecordDefaults{Source: "curated", Verified: true}
+
+	rec := Record{Prompt: "2+2?", Options: []string{"3", "4"}, CorrectAnswer: "5"}
+	assert.ErrorContains(t, normalizeRecord(&rec, defaults), "not one of the options")
+
+	rec = Record{Prompt: "2+2?", Options: []string{"4", " 4 "}, CorrectAnswer: "4"}
+	assert.ErrorContains(t, normalizeRecord(&rec, defaults), "duplicate option")
+
+	rec = Record{Prompt: "2+2?", Options: []string{"3", "4"}, CorrectAnswer: "4", Difficulty: "extreme"}
+	assert.ErrorContains(t, normalizeRecord(&rec, defaults), "unknown difficulty")
+
+	rec = Record{Prompt: "Capital of France?", Options: []string{"Paris", "Rome"}, CorrectAnswer: "paris"}
+	require.NoError(t, normalizeRecord(&rec, defaults))
+	assert.Equal(t, "Paris", rec.CorrectAnswer)
+	assert.Equal(t, "general", rec.Category)
+	assert.Equal(t, "medium", rec.Difficulty)
+	assert.Equal(t, "curated", rec.Source)
+	assert.True(t, *rec.Verified)
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	verified := false
+	records := []Record{{
+		Prompt:        "Largest planet?",
+		Options:       []string{"Jupiter", "Mars"},
+		CorrectAnswer: "Jupiter",
+		Category:      "science",
+		Difficulty:    "easy",
+		Tags:          []string{"space", "planets"},
+		Source:        "curated",
+		Verified:      &verified,
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeRecords(&buf, FormatCSV, records))
+
+	read, rejects, err := readRecords(&buf, FormatCSV)
+	require.NoError(t, err)
+	assert.Empty(t, rejects)
+	require.Len(t, read, 1)
+	read[0].line = 0
+	assert.Equal(t, records[0], read[0])
+}
+
+func TestPromptKey(t *testing.T) {
+	assert.Equal(t, promptKey("What is  the\tcapital of France?"), promptKey(" what is the capital of france? "))
+}
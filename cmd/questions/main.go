@@ -0,0 +1,273 @@
+// Command questions bulk-imports questions into the bank from JSON Lines, CSV or
+// Open Trivia DB dumps, and exports the bank back to JSON Lines or CSV.
+//
+//	questions -command=import -format=jsonl -file=bank.jsonl -dry-run
+//	questions -command=import -format=opentdb -file=opentdb.json -rejects=rejects.jsonl
+//	questions -command=export -format=csv -file=bank.csv
+//
+// Database settings are read from the same PG_* environment variables as the migrator.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
+	"github.com/gokatarajesh/quiz-platform/internal/question"
+)
+
+func main() {
+	var (
+		command           = flag.String("command", "import", "Command: import or export")
+		format            = flag.String("format", FormatJSONL, "File format: jsonl, csv or opentdb (import only)")
+		file              = flag.String("file", "", "Input file for import, output file for export (default stdin/stdout)")
+		source            = flag.String("source", "curated", "Source for imported rows that don't set one")
+		verified          = flag.Bool("verified", true, "Verified flag for imported rows that don't set one")
+		dryRun            = flag.Bool("dry-run", false, "Validate and dedupe without writing to the database")
+		rejectsFile       = flag.String("rejects", "", "Write rejected records as JSON Lines to this file")
+		category          = flag.String("category", "", "Only export this category")
+		includeUnverified = flag.Bool("include-unverified", false, "Also export unverified questions")
+	)
+	flag.Parse()
+
+	// Setup logging
+	log.Logger = zerolog.New(os.Stderr).With().Timestamp().Logger()
+
+	// Read database configuration from environment
+	pgHost := getEnv("PG_HOST", "localhost")
+	pgPort := getEnv("PG_PORT", "5432")
+	pgUser := getEnv("PG_USER", "")
+	pgPassword := getEnv("PG_PASSWORD", "")
+	pgDatabase := getEnv("PG_DATABASE", "")
+	pgSSLMode := getEnv("PG_SSL_MODE", "disable")
+
+	if pgUser == "" {
+		log.Fatal().Msg("PG_USER environment variable is required")
+	}
+	if pgPassword == "" {
+		log.Fatal().Msg("PG_PASSWORD environment variable is required")
+	}
+	if pgDatabase == "" {
+		log.Fatal().Msg("PG_DATABASE environment variable is required")
+	}
+
+	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		pgHost, pgPort, pgUser, pgPassword, pgDatabase, pgSSLMode)
+
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		log.Fatal().Err(err).Str("host", pgHost).Str("port", pgPort).Msg("failed to connect to database")
+	}
+	defer conn.Close(ctx)
+
+	switch *command {
+	case "import":
+		in := io.Reader(os.Stdin)
+		if *file != "" {
+			f, err := os.Open(*file)
+			if err != nil {
+				log.Fatal().Err(err).Str("file", *file).Msg("failed to open input file")
+			}
+			defer f.Close()
+			in = f
+		}
+
+		opts := importOptions{
+			format:   *format,
+			defaults: RecordDefaults{Source: *source, Verified: *verified},
+			dryRun:   *dryRun,
+		}
+		result, err := importQuestions(ctx, conn, in, opts)
+		if err != nil {
+			log.Fatal().Err(err).Msg("import failed")
+		}
+
+		for _, reject := range result.rejects {
+			log.Warn().Int("line", reject.Line).Str("prompt", reject.Prompt).Str("reason", reject.Reason).Msg("rejected question")
+		}
+		if *rejectsFile != "" {
+			if err := writeRejects(*rejectsFile, result.rejects); err != nil {
+				log.Fatal().Err(err).Str("file", *rejectsFile).Msg("failed to write rejects")
+			}
+		}
+
+		log.Info().
+			Bool("dry_run", *dryRun).
+			Int("read", result.read).
+			Int("imported", result.imported).
+			Int("duplicates", result.duplicates).
+			Int("rejected", len(result.rejects)).
+			Msg("question import finished")
+
+	case "export":
+		out := io.Writer(os.Stdout)
+		if *file != "" {
+			f, err := os.Create(*file)
+			if err != nil {
+				log.Fatal().Err(err).Str("file", *file).Msg("failed to create output file")
+			}
+			defer f.Close()
+			out = f
+		}
+
+		count, err := exportQuestions(ctx, sqlcgen.New(conn), out, *format, sqlcgen.ListQuestionsForExportParams{
+			Category:          *category,
+			IncludeUnverified: *includeUnverified,
+		})
+		if err != nil {
+			log.Fatal().Err(err).Msg("export failed")
+		}
+		log.Info().Int("exported", count).Str("format", *format).Msg("question export finished")
+
+	default:
+		log.Fatal().Str("command", *command).Msg("unknown command. Use: import or export")
+	}
+}
+
+type importOptions struct {
+	format   string
+	defaults RecordDefaults
+	dryRun   bool
+}
+
+type importResult struct {
+	read       int
+	imported   int
+	duplicates int
+	rejects    []Reject
+}
+
+// importQuestions loads every valid, previously unseen question from in inside a
+// single transaction. Duplicates of existing prompts (or of earlier rows in the
+// same file) are skipped and reported as rejects.
+func importQuestions(ctx context.Context, conn *pgx.Conn, in io.Reader, opts importOptions) (*importResult, error) {
+	records, rejects, err := readRecords(in, opts.format)
+	if err != nil {
+		return nil, err
+	}
+	result := &importResult{read: len(records) + len(rejects), rejects: rejects}
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	queries := sqlcgen.New(tx)
+
+	existing, err := queries.ListQuestionPrompts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load existing prompts: %w", err)
+	}
+	seen := make(map[string]bool, len(existing)+len(records))
+	for _, prompt := range existing {
+		seen[promptKey(prompt)] = true
+	}
+
+	for i := range records {
+		rec := &records[i]
+		if err := normalizeRecord(rec, opts.defaults); err != nil {
+			result.rejects = append(result.rejects, Reject{Line: rec.line, Prompt: rec.Prompt, Reason: err.Error()})
+			continue
+		}
+
+		key := promptKey(rec.Prompt)
+		if seen[key] {
+			result.duplicates++
+			result.rejects = append(result.rejects, Reject{Line: rec.line, Prompt: rec.Prompt, Reason: "duplicate prompt"})
+			continue
+		}
+		seen[key] = true
+
+		if !opts.dryRun {
+			_, err := queries.InsertQuestion(ctx, sqlcgen.InsertQuestionParams{
+				Source:        rec.Source,
+				Prompt:        rec.Prompt,
+				Options:       rec.Options,
+				CorrectAnswer: rec.CorrectAnswer,
+				Metadata:      []byte(fmt.Sprintf(`{"imported_from":%q}`, opts.format)),
+				Verified:      *rec.Verified,
+				Category:      rec.Category,
+				Difficulty:    rec.Difficulty,
+				Tags:          rec.Tags,
+				// Same hash as AI-generated copies, so neither is stored twice
+				ContentHash: pgtype.Text{String: question.ContentHash(rec.Prompt, rec.CorrectAnswer), Valid: true},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("insert question from line %d: %w", rec.line, err)
+			}
+		}
+		result.imported++
+	}
+
+	if opts.dryRun {
+		return result, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit import: %w", err)
+	}
+	return result, nil
+}
+
+// exportQuestions writes the question bank in an importable format.
+func exportQuestions(ctx context.Context, queries *sqlcgen.Queries, out io.Writer, format string, params sqlcgen.ListQuestionsForExportParams) (int, error) {
+	if format == FormatOpenTDB {
+		return 0, fmt.Errorf("%s is an import-only format", FormatOpenTDB)
+	}
+
+	rows, err := queries.ListQuestionsForExport(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("list questions: %w", err)
+	}
+
+	records := make([]Record, 0, len(rows))
+	for _, row := range rows {
+		verified := row.Verified
+		records = append(records, Record{
+			Prompt:        row.Prompt,
+			Options:       row.Options,
+			CorrectAnswer: row.CorrectAnswer,
+			Category:      row.Category,
+			Difficulty:    row.Difficulty,
+			Tags:          row.Tags,
+			Source:        row.Source,
+			Verified:      &verified,
+		})
+	}
+	if err := writeRecords(out, format, records); err != nil {
+		return 0, err
+	}
+	return len(records), nil
+}
+
+func writeRejects(path string, rejects []Reject) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	for _, reject := range rejects {
+		if err := enc.Encode(reject); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
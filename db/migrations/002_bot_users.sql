@@ -0,0 +1,16 @@
+-- +goose Up
+-- Seeded bot accounts used by the accept_bot_fill flow (one per difficulty level).
+ALTER TABLE users DROP CONSTRAINT users_user_type_check;
+ALTER TABLE users ADD CONSTRAINT users_user_type_check CHECK (user_type IN ('registered', 'guest', 'bot'));
+
+INSERT INTO users (user_id, username, user_type, metadata) VALUES
+    ('00000000-0000-0000-0000-00000000b001', 'bot_easy',   'bot', '{"difficulty": "easy"}'::JSONB),
+    ('00000000-0000-0000-0000-00000000b002', 'bot_medium', 'bot', '{"difficulty": "medium"}'::JSONB),
+    ('00000000-0000-0000-0000-00000000b003', 'bot_hard',   'bot', '{"difficulty": "hard"}'::JSONB)
+ON CONFLICT (user_id) DO NOTHING;
+
+-- +goose Down
+DELETE FROM player_match_state WHERE user_id IN (SELECT user_id FROM users WHERE user_type = 'bot');
+DELETE FROM users WHERE user_type = 'bot';
+ALTER TABLE users DROP CONSTRAINT users_user_type_check;
+ALTER TABLE users ADD CONSTRAINT users_user_type_check CHECK (user_type IN ('registered', 'guest'));
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Why a player left a match early ('quit' via leave_match, 'disconnected' after the resume grace window).
+ALTER TABLE player_match_state ADD COLUMN leave_reason TEXT;
+
+-- UpdatePlayerMatchResult stamps updated_at, which player_match_state never had;
+-- without it every final state write, left_at and leave_reason included, fails.
+ALTER TABLE player_match_state ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+-- +goose Down
+ALTER TABLE player_match_state DROP COLUMN updated_at;
+ALTER TABLE player_match_state DROP COLUMN leave_reason;
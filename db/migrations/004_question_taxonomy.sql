@@ -0,0 +1,21 @@
+-- +goose Up
+-- Category, difficulty and tags for curated question selection.
+ALTER TABLE questions ADD COLUMN category TEXT NOT NULL DEFAULT 'general';
+ALTER TABLE questions ADD COLUMN difficulty TEXT NOT NULL DEFAULT 'medium' CHECK (difficulty IN ('easy', 'medium', 'hard'));
+ALTER TABLE questions ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- Backfill from metadata written before the columns existed.
+UPDATE questions SET category = metadata->>'category'
+WHERE COALESCE(metadata->>'category', '') <> '';
+UPDATE questions SET difficulty = metadata->>'difficulty'
+WHERE metadata->>'difficulty' IN ('easy', 'medium', 'hard');
+
+CREATE INDEX idx_questions_pool ON questions(category, difficulty) WHERE verified;
+CREATE INDEX idx_questions_tags ON questions USING GIN(tags);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_questions_tags;
+DROP INDEX IF EXISTS idx_questions_pool;
+ALTER TABLE questions DROP COLUMN tags;
+ALTER TABLE questions DROP COLUMN difficulty;
+ALTER TABLE questions DROP COLUMN category;
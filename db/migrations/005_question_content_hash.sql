@@ -0,0 +1,9 @@
+-- +goose Up
+-- Identity of AI-generated questions: sha256 of the normalized prompt and answer,
+-- so a re-generated duplicate resolves to the row that already exists.
+ALTER TABLE questions ADD COLUMN content_hash TEXT;
+CREATE UNIQUE INDEX idx_questions_content_hash ON questions(content_hash) WHERE content_hash IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_questions_content_hash;
+ALTER TABLE questions DROP COLUMN content_hash;
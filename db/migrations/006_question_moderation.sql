@@ -0,0 +1,12 @@
+-- +goose Up
+-- Roles for admin-only endpoints, and the review state of each question.
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'player' CHECK (role IN ('player', 'admin'));
+
+ALTER TABLE questions ADD COLUMN review_status TEXT NOT NULL DEFAULT 'pending' CHECK (review_status IN ('pending', 'approved', 'rejected'));
+UPDATE questions SET review_status = 'approved' WHERE verified;
+CREATE INDEX idx_questions_review ON questions(review_status, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_questions_review;
+ALTER TABLE questions DROP COLUMN review_status;
+ALTER TABLE users DROP COLUMN role;
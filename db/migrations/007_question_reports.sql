@@ -0,0 +1,32 @@
+-- +goose Up
+-- Questions issued in each match, so tokens can be resolved after the Redis state
+-- expires and matches affected by a wrong answer key can be found.
+CREATE TABLE match_questions (
+    match_id        UUID NOT NULL REFERENCES matches(match_id) ON DELETE CASCADE,
+    question_order  SMALLINT NOT NULL,
+    question_id     UUID NOT NULL REFERENCES questions(question_id),
+    token           TEXT NOT NULL,
+    PRIMARY KEY (match_id, question_order)
+);
+CREATE INDEX idx_match_questions_token ON match_questions(token);
+CREATE INDEX idx_match_questions_question ON match_questions(question_id);
+
+-- Player reports against a question. One report per player per question.
+CREATE TABLE question_reports (
+    report_id     UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    question_id   UUID NOT NULL REFERENCES questions(question_id) ON DELETE CASCADE,
+    match_id      UUID REFERENCES matches(match_id) ON DELETE SET NULL,
+    reporter_id   UUID NOT NULL REFERENCES users(user_id),
+    reason        TEXT NOT NULL CHECK (reason IN ('wrong_answer', 'ambiguous', 'typo', 'offensive', 'other')),
+    details       TEXT NOT NULL DEFAULT '',
+    status        TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'upheld', 'dismissed')),
+    resolved_by   UUID REFERENCES users(user_id),
+    resolved_at   TIMESTAMPTZ,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (question_id, reporter_id)
+);
+CREATE INDEX idx_question_reports_status ON question_reports(status, question_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS question_reports;
+DROP TABLE IF EXISTS match_questions;
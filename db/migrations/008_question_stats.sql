@@ -0,0 +1,17 @@
+-- +goose Up
+-- Per-question answer statistics rolled up from finished matches. Bots are not
+-- counted. empirical_difficulty stays NULL until a question has enough samples.
+CREATE TABLE question_stats (
+    question_id           UUID PRIMARY KEY REFERENCES questions(question_id) ON DELETE CASCADE,
+    exposures             INTEGER NOT NULL DEFAULT 0,
+    answered              INTEGER NOT NULL DEFAULT 0,
+    correct               INTEGER NOT NULL DEFAULT 0,
+    avg_response_ms       INTEGER NOT NULL DEFAULT 0,
+    option_picks          JSONB NOT NULL DEFAULT '{}'::jsonb,
+    empirical_difficulty  TEXT CHECK (empirical_difficulty IN ('easy', 'medium', 'hard')),
+    updated_at            TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_question_stats_difficulty ON question_stats(empirical_difficulty);
+
+-- +goose Down
+DROP TABLE IF EXISTS question_stats;
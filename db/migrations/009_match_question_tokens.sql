@@ -0,0 +1,9 @@
+-- +goose Up
+-- Question tokens are signed per player and carry the question order, so match
+-- questions are resolved by order and the shared token is no longer stored.
+DROP INDEX IF EXISTS idx_match_questions_token;
+ALTER TABLE match_questions DROP COLUMN token;
+
+-- +goose Down
+ALTER TABLE match_questions ADD COLUMN token TEXT NOT NULL DEFAULT '';
+CREATE INDEX idx_match_questions_token ON match_questions(token);
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Anti-cheat flags raised against a player's result in a finished match. A kind is
+-- flagged at most once per player per match.
+CREATE TABLE cheat_flags (
+    flag_id      UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    match_id     UUID NOT NULL REFERENCES matches(match_id) ON DELETE CASCADE,
+    user_id      UUID NOT NULL REFERENCES users(user_id),
+    kind         TEXT NOT NULL CHECK (kind IN ('impossible_latency', 'uniform_timing', 'accuracy_jump', 'win_trading')),
+    details      JSONB NOT NULL DEFAULT '{}'::jsonb,
+    status       TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'confirmed', 'dismissed')),
+    reviewed_by  UUID REFERENCES users(user_id),
+    reviewed_at  TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (match_id, user_id, kind)
+);
+CREATE INDEX idx_cheat_flags_status ON cheat_flags(status, created_at);
+CREATE INDEX idx_cheat_flags_user ON cheat_flags(user_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS cheat_flags;
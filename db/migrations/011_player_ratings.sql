@@ -0,0 +1,42 @@
+-- +goose Up
+-- Glicko-2 skill ratings for registered players, updated after every
+-- leaderboard-eligible random_1v1 match.
+CREATE TABLE player_ratings (
+    user_id      UUID PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    rating       DOUBLE PRECISION NOT NULL DEFAULT 1500,
+    deviation    DOUBLE PRECISION NOT NULL DEFAULT 350,
+    volatility   DOUBLE PRECISION NOT NULL DEFAULT 0.06,
+    games        INTEGER NOT NULL DEFAULT 0,
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_player_ratings_rating ON player_ratings(rating DESC);
+
+-- One row per rated player per match; the unique key keeps a match from being rated twice.
+CREATE TABLE rating_history (
+    history_id        BIGSERIAL PRIMARY KEY,
+    user_id           UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    match_id          UUID NOT NULL REFERENCES matches(match_id) ON DELETE CASCADE,
+    opponent_id       UUID NOT NULL REFERENCES users(user_id),
+    outcome           DOUBLE PRECISION NOT NULL CHECK (outcome IN (0, 0.5, 1)),
+    rating_before     DOUBLE PRECISION NOT NULL,
+    rating_after      DOUBLE PRECISION NOT NULL,
+    deviation_before  DOUBLE PRECISION NOT NULL,
+    deviation_after   DOUBLE PRECISION NOT NULL,
+    volatility_after  DOUBLE PRECISION NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (match_id, user_id)
+);
+CREATE INDEX idx_rating_history_user ON rating_history(user_id, created_at DESC);
+
+-- The rating board is snapshotted alongside the score windows.
+ALTER TABLE leaderboard_snapshots DROP CONSTRAINT leaderboard_snapshots_time_window_check;
+ALTER TABLE leaderboard_snapshots ADD CONSTRAINT leaderboard_snapshots_time_window_check
+    CHECK (time_window IN ('daily', 'weekly', 'monthly', 'all_time', 'rating'));
+
+-- +goose Down
+DELETE FROM leaderboard_snapshots WHERE time_window = 'rating';
+ALTER TABLE leaderboard_snapshots DROP CONSTRAINT leaderboard_snapshots_time_window_check;
+ALTER TABLE leaderboard_snapshots ADD CONSTRAINT leaderboard_snapshots_time_window_check
+    CHECK (time_window IN ('daily', 'weekly', 'monthly', 'all_time'));
+DROP TABLE IF EXISTS rating_history;
+DROP TABLE IF EXISTS player_ratings;
@@ -0,0 +1,32 @@
+-- +goose Up
+-- Named competitive seasons. A season is scheduled until it starts, active while it
+-- runs and archived once its final standings are stored and ratings soft-reset.
+CREATE TABLE seasons (
+    season_id    TEXT PRIMARY KEY,
+    name         TEXT NOT NULL,
+    starts_at    TIMESTAMPTZ NOT NULL,
+    ends_at      TIMESTAMPTZ NOT NULL,
+    status       TEXT NOT NULL DEFAULT 'scheduled' CHECK (status IN ('scheduled', 'active', 'archived')),
+    archived_at  TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (ends_at > starts_at)
+);
+CREATE INDEX idx_seasons_status ON seasons(status, starts_at);
+
+-- Final standings of a season are snapshots tagged with it, one per board.
+ALTER TABLE leaderboard_snapshots ADD COLUMN season_id TEXT REFERENCES seasons(season_id) ON DELETE CASCADE;
+CREATE UNIQUE INDEX idx_leaderboard_snapshots_season
+    ON leaderboard_snapshots(season_id, time_window) WHERE season_id IS NOT NULL;
+
+ALTER TABLE leaderboard_snapshots DROP CONSTRAINT leaderboard_snapshots_time_window_check;
+ALTER TABLE leaderboard_snapshots ADD CONSTRAINT leaderboard_snapshots_time_window_check
+    CHECK (time_window IN ('daily', 'weekly', 'monthly', 'all_time', 'rating', 'season'));
+
+-- +goose Down
+DELETE FROM leaderboard_snapshots WHERE time_window = 'season' OR season_id IS NOT NULL;
+ALTER TABLE leaderboard_snapshots DROP CONSTRAINT leaderboard_snapshots_time_window_check;
+ALTER TABLE leaderboard_snapshots ADD CONSTRAINT leaderboard_snapshots_time_window_check
+    CHECK (time_window IN ('daily', 'weekly', 'monthly', 'all_time', 'rating'));
+DROP INDEX IF EXISTS idx_leaderboard_snapshots_season;
+ALTER TABLE leaderboard_snapshots DROP COLUMN IF EXISTS season_id;
+DROP TABLE IF EXISTS seasons;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- Daily, weekly and monthly boards are kept per calendar period. When a period
+-- closes its final standings are stored once, tagged with the period
+-- (2026-10-16, 2026-W42, 2026-10); rolling snapshots leave it NULL.
+ALTER TABLE leaderboard_snapshots ADD COLUMN period TEXT;
+CREATE UNIQUE INDEX idx_leaderboard_snapshots_period
+    ON leaderboard_snapshots(time_window, period) WHERE period IS NOT NULL;
+
+-- +goose Down
+DELETE FROM leaderboard_snapshots WHERE period IS NOT NULL;
+DROP INDEX IF EXISTS idx_leaderboard_snapshots_period;
+ALTER TABLE leaderboard_snapshots DROP COLUMN IF EXISTS period;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Archiving a season soft-resets the stored ratings in the same statement; the
+-- rating and season boards in Redis are reset afterwards. reset_at records that
+-- this second step finished, so an archived season without it is retried.
+ALTER TABLE seasons ADD COLUMN reset_at TIMESTAMPTZ;
+UPDATE seasons SET reset_at = archived_at WHERE status = 'archived';
+
+-- +goose Down
+ALTER TABLE seasons DROP COLUMN IF EXISTS reset_at;
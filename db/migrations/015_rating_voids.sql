@@ -0,0 +1,7 @@
+-- +goose Up
+-- Invalidating a rated match takes its rating changes back off the players and
+-- marks its history rows void, so they are taken back once.
+ALTER TABLE rating_history ADD COLUMN voided_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE rating_history DROP COLUMN IF EXISTS voided_at;
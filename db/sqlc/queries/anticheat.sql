@@ -0,0 +1,63 @@
+-- name: InsertCheatFlag :execrows
+-- Affects no row when the player was already flagged for this kind in the match.
+INSERT INTO cheat_flags (
+    match_id,
+    user_id,
+    kind,
+    details
+) VALUES (
+    sqlc.arg(match_id),
+    sqlc.arg(user_id),
+    sqlc.arg(kind),
+    sqlc.arg(details)
+)
+ON CONFLICT (match_id, user_id, kind) DO NOTHING;
+
+-- name: GetCheatFlag :one
+SELECT flag_id, match_id, user_id, kind, details, status, reviewed_by, reviewed_at, created_at
+FROM cheat_flags
+WHERE flag_id = $1;
+
+-- name: ListCheatFlags :many
+-- Flags in the given status, newest first.
+SELECT flag_id, match_id, user_id, kind, details, status, reviewed_by, reviewed_at, created_at
+FROM cheat_flags
+WHERE status = sqlc.arg(status)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(max_count) OFFSET sqlc.arg(skip);
+
+-- name: ReviewCheatFlag :one
+UPDATE cheat_flags
+SET status = sqlc.arg(status),
+    reviewed_by = sqlc.arg(reviewed_by),
+    reviewed_at = NOW()
+WHERE flag_id = sqlc.arg(flag_id)
+  AND status = 'open'
+RETURNING flag_id, match_id, user_id, kind, details, status, reviewed_by, reviewed_at, created_at;
+
+-- name: GetPlayerAccuracyHistory :one
+-- Average accuracy over the player's most recent finished matches, excluding one match.
+SELECT COUNT(*) AS matches,
+       COALESCE(AVG(h.accuracy), 0)::float8 AS avg_accuracy
+FROM (
+    SELECT pms.accuracy
+    FROM player_match_state pms
+    JOIN matches m ON m.match_id = pms.match_id
+    WHERE pms.user_id = sqlc.arg(user_id)
+      AND pms.match_id <> sqlc.arg(exclude_match_id)
+      AND pms.accuracy IS NOT NULL
+      AND m.status IN ('completed', 'timeout')
+    ORDER BY m.completed_at DESC
+    LIMIT sqlc.arg(max_matches)
+) h;
+
+-- name: CountRecentPairings :one
+-- Leaderboard-eligible random matches both players finished since the given time.
+SELECT COUNT(*)
+FROM matches m
+JOIN player_match_state a ON a.match_id = m.match_id AND a.user_id = sqlc.arg(player_a)
+JOIN player_match_state b ON b.match_id = m.match_id AND b.user_id = sqlc.arg(player_b)
+WHERE m.mode = 'random_1v1'
+  AND m.leaderboard_eligible
+  AND m.status IN ('completed', 'timeout')
+  AND m.completed_at >= sqlc.arg(since);
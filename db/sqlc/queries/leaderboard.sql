@@ -16,6 +16,31 @@ RETURNING *;
 SELECT *
 FROM leaderboard_snapshots
 WHERE time_window = $1
+  AND season_id IS NULL
+  AND period IS NULL
 ORDER BY generated_at DESC
 LIMIT $2;
 
+
+-- name: InsertPeriodSnapshot :execrows
+-- Affects no row when the period's final standings are already stored.
+INSERT INTO leaderboard_snapshots (
+    time_window,
+    generated_at,
+    entries,
+    source_hash,
+    period
+) VALUES (
+    sqlc.arg(time_window),
+    sqlc.arg(generated_at),
+    sqlc.arg(entries),
+    sqlc.arg(source_hash),
+    sqlc.arg(period)
+)
+ON CONFLICT (time_window, period) WHERE period IS NOT NULL DO NOTHING;
+
+-- name: GetPeriodSnapshot :one
+SELECT *
+FROM leaderboard_snapshots
+WHERE time_window = $1
+  AND period = $2;
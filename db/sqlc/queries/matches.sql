@@ -58,6 +58,7 @@ SET final_score = sqlc.arg(final_score),
     accuracy = sqlc.arg(accuracy),
     streak_bonus_pct = sqlc.arg(streak_bonus_pct),
     left_at = COALESCE(sqlc.arg(left_at), left_at),
+    leave_reason = COALESCE(sqlc.arg(leave_reason), leave_reason),
     answers = COALESCE(sqlc.arg(answers), answers),
     updated_at = NOW()
 WHERE match_id = sqlc.arg(match_id)
@@ -68,3 +69,36 @@ SELECT *
 FROM player_match_state
 WHERE match_id = $1;
 
+
+-- name: InsertMatchQuestion :exec
+INSERT INTO match_questions (
+    match_id,
+    question_order,
+    question_id
+) VALUES (
+    sqlc.arg(match_id),
+    sqlc.arg(question_order),
+    sqlc.arg(question_id)
+)
+ON CONFLICT (match_id, question_order) DO NOTHING;
+
+-- name: GetMatchQuestionForPlayer :one
+-- Resolves a question by order within a match the user played in.
+SELECT mq.match_id, mq.question_order, mq.question_id
+FROM match_questions mq
+JOIN player_match_state pms ON pms.match_id = mq.match_id AND pms.user_id = sqlc.arg(user_id)
+WHERE mq.match_id = sqlc.arg(match_id)
+  AND mq.question_order = sqlc.arg(question_order);
+
+-- name: ListMatchQuestionsByQuestion :many
+SELECT match_id, question_order, question_id
+FROM match_questions
+WHERE question_id = $1;
+
+-- name: RevokeLeaderboardEligibility :execrows
+-- Affects no row when the match was already ineligible, so results are reversed once.
+UPDATE matches
+SET leaderboard_eligible = FALSE,
+    updated_at = NOW()
+WHERE match_id = $1
+  AND leaderboard_eligible;
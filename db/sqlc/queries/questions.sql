@@ -5,29 +5,110 @@ INSERT INTO questions (
     options,
     correct_answer,
     metadata,
-    verified
+    verified,
+    review_status,
+    category,
+    difficulty,
+    tags,
+    content_hash
 ) VALUES (
     sqlc.arg(source),
     sqlc.arg(prompt),
     sqlc.arg(options),
     sqlc.arg(correct_answer),
     COALESCE(sqlc.arg(metadata), '{}'::jsonb),
-    sqlc.arg(verified)
+    sqlc.arg(verified),
+    CASE WHEN sqlc.arg(verified)::bool THEN 'approved' ELSE 'pending' END,
+    sqlc.arg(category),
+    sqlc.arg(difficulty),
+    COALESCE(sqlc.arg(tags)::text[], '{}'),
+    sqlc.arg(content_hash)
 )
 RETURNING *;
 
+-- name: UpsertAIQuestion :one
+-- Stores an AI-generated question as unverified, or touches the existing row with
+-- the same content hash. Either way the stored question is returned, so a
+-- re-generated duplicate is served as moderation left it.
+INSERT INTO questions (
+    source,
+    prompt,
+    options,
+    correct_answer,
+    verified,
+    category,
+    difficulty,
+    content_hash
+) VALUES (
+    'ai',
+    sqlc.arg(prompt),
+    sqlc.arg(options),
+    sqlc.arg(correct_answer),
+    false,
+    sqlc.arg(category),
+    sqlc.arg(difficulty),
+    sqlc.arg(content_hash)
+)
+ON CONFLICT (content_hash) WHERE content_hash IS NOT NULL
+DO UPDATE SET updated_at = NOW()
+RETURNING question_id, prompt, options, correct_answer, review_status;
+
 -- name: GetQuestionPool :many
-SELECT question_id, source, prompt, options, correct_answer, metadata, verified, created_at, updated_at
-FROM questions
-WHERE verified = true
+-- An empty category matches every category. Difficulty matches the empirical
+-- difficulty once one has been calibrated, the labelled difficulty until then.
+SELECT q.question_id, q.source, q.prompt, q.options, q.correct_answer, q.metadata, q.verified, q.created_at, q.updated_at, q.category, q.difficulty, q.tags, q.content_hash, q.review_status
+FROM questions q
+LEFT JOIN question_stats s ON s.question_id = q.question_id
+WHERE q.verified = true
+  AND (sqlc.arg(category)::text = '' OR q.category = sqlc.arg(category)::text)
+  AND COALESCE(s.empirical_difficulty, q.difficulty) = sqlc.arg(difficulty)::text
 ORDER BY RANDOM()
-LIMIT $1;
+LIMIT sqlc.arg(max_count);
 
 -- name: UpsertQuestionVerification :one
 UPDATE questions
 SET verified = sqlc.arg(verified),
+    review_status = sqlc.arg(review_status),
     metadata = COALESCE(sqlc.arg(metadata), metadata),
     updated_at = NOW()
 WHERE question_id = sqlc.arg(question_id)
-RETURNING question_id, source, prompt, options, correct_answer, metadata, verified, created_at, updated_at;
+RETURNING question_id, source, prompt, options, correct_answer, metadata, verified, created_at, updated_at, category, difficulty, tags, content_hash, review_status;
+
+-- name: ListQuestionPrompts :many
+SELECT prompt
+FROM questions;
+
+-- name: ListQuestionsForExport :many
+SELECT question_id, source, prompt, options, correct_answer, metadata, verified, created_at, updated_at, category, difficulty, tags, content_hash, review_status
+FROM questions
+WHERE (sqlc.arg(category)::text = '' OR category = sqlc.arg(category)::text)
+  AND (verified OR sqlc.arg(include_unverified)::bool)
+ORDER BY created_at, question_id;
+
+-- name: GetQuestionByID :one
+SELECT question_id, source, prompt, options, correct_answer, metadata, verified, created_at, updated_at, category, difficulty, tags, content_hash, review_status
+FROM questions
+WHERE question_id = $1;
 
+-- name: ListQuestionsForReview :many
+-- Empty filters match everything.
+SELECT question_id, source, prompt, options, correct_answer, metadata, verified, created_at, updated_at, category, difficulty, tags, content_hash, review_status
+FROM questions
+WHERE review_status = sqlc.arg(review_status)
+  AND (sqlc.arg(source)::text = '' OR source = sqlc.arg(source)::text)
+  AND (sqlc.arg(category)::text = '' OR category = sqlc.arg(category)::text)
+  AND (sqlc.arg(difficulty)::text = '' OR difficulty = sqlc.arg(difficulty)::text)
+ORDER BY created_at, question_id
+LIMIT sqlc.arg(max_count) OFFSET sqlc.arg(skip);
+
+-- name: UpdateQuestionContent :one
+UPDATE questions
+SET prompt = sqlc.arg(prompt),
+    options = sqlc.arg(options),
+    correct_answer = sqlc.arg(correct_answer),
+    category = sqlc.arg(category),
+    difficulty = sqlc.arg(difficulty),
+    tags = COALESCE(sqlc.arg(tags)::text[], '{}'),
+    updated_at = NOW()
+WHERE question_id = sqlc.arg(question_id)
+RETURNING question_id, source, prompt, options, correct_answer, metadata, verified, created_at, updated_at, category, difficulty, tags, content_hash, review_status;
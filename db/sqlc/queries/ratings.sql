@@ -0,0 +1,103 @@
+-- name: GetPlayerRating :one
+SELECT user_id, rating, deviation, volatility, games, updated_at
+FROM player_ratings
+WHERE user_id = $1;
+
+-- name: LockPlayerRating :one
+-- Holds the player's rating row until the transaction ends, so concurrent
+-- matches rate them one after the other.
+SELECT user_id, rating, deviation, volatility, games, updated_at
+FROM player_ratings
+WHERE user_id = $1
+FOR UPDATE;
+
+-- name: EnsurePlayerRating :exec
+-- Gives an unrated player a row at the starting rating, so it can be locked.
+INSERT INTO player_ratings (
+    user_id,
+    rating,
+    deviation,
+    volatility,
+    games
+) VALUES (
+    sqlc.arg(user_id),
+    sqlc.arg(rating),
+    sqlc.arg(deviation),
+    sqlc.arg(volatility),
+    0
+)
+ON CONFLICT (user_id) DO NOTHING;
+
+-- name: ListPlayerRatings :many
+SELECT user_id, rating
+FROM player_ratings;
+
+-- name: UpsertPlayerRating :exec
+INSERT INTO player_ratings (
+    user_id,
+    rating,
+    deviation,
+    volatility,
+    games
+) VALUES (
+    sqlc.arg(user_id),
+    sqlc.arg(rating),
+    sqlc.arg(deviation),
+    sqlc.arg(volatility),
+    1
+)
+ON CONFLICT (user_id) DO UPDATE
+SET rating = EXCLUDED.rating,
+    deviation = EXCLUDED.deviation,
+    volatility = EXCLUDED.volatility,
+    games = player_ratings.games + 1,
+    updated_at = NOW();
+
+-- name: InsertRatingHistory :execrows
+-- Affects no row when the player was already rated for this match.
+INSERT INTO rating_history (
+    user_id,
+    match_id,
+    opponent_id,
+    outcome,
+    rating_before,
+    rating_after,
+    deviation_before,
+    deviation_after,
+    volatility_after
+) VALUES (
+    sqlc.arg(user_id),
+    sqlc.arg(match_id),
+    sqlc.arg(opponent_id),
+    sqlc.arg(outcome),
+    sqlc.arg(rating_before),
+    sqlc.arg(rating_after),
+    sqlc.arg(deviation_before),
+    sqlc.arg(deviation_after),
+    sqlc.arg(volatility_after)
+)
+ON CONFLICT (match_id, user_id) DO NOTHING;
+
+-- name: VoidMatchRatings :many
+-- Voids a match's rating changes and takes them back off the players: the rating
+-- won or lost is reversed and the deviation widened back to what it was before the
+-- match, keeping any games played since. A match rated before the last season
+-- rollover is only marked void, since the soft reset already shrank what it moved.
+-- Returns no rows when the match was not rated, was already voided or is from an
+-- earlier season.
+WITH voided AS (
+    UPDATE rating_history
+    SET voided_at = NOW()
+    WHERE match_id = $1
+      AND voided_at IS NULL
+    RETURNING user_id, rating_after - rating_before AS delta, deviation_before, created_at
+)
+UPDATE player_ratings p
+SET rating = p.rating - v.delta,
+    deviation = GREATEST(p.deviation, v.deviation_before),
+    games = GREATEST(p.games - 1, 0),
+    updated_at = NOW()
+FROM voided v
+WHERE p.user_id = v.user_id
+  AND v.created_at > COALESCE((SELECT MAX(archived_at) FROM seasons), '-infinity')
+RETURNING p.user_id, p.rating, p.deviation, p.volatility;
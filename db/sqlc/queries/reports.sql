@@ -0,0 +1,49 @@
+-- name: InsertQuestionReport :one
+-- Returns no row when the reporter already reported this question.
+INSERT INTO question_reports (
+    question_id,
+    match_id,
+    reporter_id,
+    reason,
+    details
+) VALUES (
+    sqlc.arg(question_id),
+    sqlc.arg(match_id),
+    sqlc.arg(reporter_id),
+    sqlc.arg(reason),
+    sqlc.arg(details)
+)
+ON CONFLICT (question_id, reporter_id) DO NOTHING
+RETURNING report_id, question_id, match_id, reporter_id, reason, details, status, resolved_by, resolved_at, created_at;
+
+-- name: CountOpenReportsForQuestion :one
+SELECT COUNT(*)
+FROM question_reports
+WHERE question_id = $1
+  AND status = 'open';
+
+-- name: ListReportedQuestions :many
+-- Questions with reports in the given status, most reported first.
+SELECT q.question_id, q.prompt, q.options, q.correct_answer, q.source, q.verified, q.review_status,
+       COUNT(r.report_id) AS report_count,
+       MAX(r.created_at)::timestamptz AS last_reported_at
+FROM question_reports r
+JOIN questions q ON q.question_id = r.question_id
+WHERE r.status = sqlc.arg(status)
+GROUP BY q.question_id
+ORDER BY report_count DESC, last_reported_at DESC
+LIMIT sqlc.arg(max_count) OFFSET sqlc.arg(skip);
+
+-- name: ListReportsForQuestion :many
+SELECT report_id, question_id, match_id, reporter_id, reason, details, status, resolved_by, resolved_at, created_at
+FROM question_reports
+WHERE question_id = $1
+ORDER BY created_at DESC;
+
+-- name: ResolveQuestionReports :execrows
+UPDATE question_reports
+SET status = sqlc.arg(status),
+    resolved_by = sqlc.arg(resolved_by),
+    resolved_at = NOW()
+WHERE question_id = sqlc.arg(question_id)
+  AND status = 'open';
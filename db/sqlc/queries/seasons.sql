@@ -0,0 +1,100 @@
+-- name: CreateSeason :one
+-- Returns no row when a season with the same ID already exists.
+INSERT INTO seasons (
+    season_id,
+    name,
+    starts_at,
+    ends_at
+) VALUES (
+    sqlc.arg(season_id),
+    sqlc.arg(name),
+    sqlc.arg(starts_at),
+    sqlc.arg(ends_at)
+)
+ON CONFLICT (season_id) DO NOTHING
+RETURNING *;
+
+-- name: CountOverlappingSeasons :one
+SELECT COUNT(*)
+FROM seasons
+WHERE starts_at < sqlc.arg(ends_at)
+  AND ends_at > sqlc.arg(starts_at);
+
+-- name: GetSeason :one
+SELECT *
+FROM seasons
+WHERE season_id = $1;
+
+-- name: ListSeasons :many
+SELECT *
+FROM seasons
+ORDER BY starts_at DESC;
+
+-- name: ActivateDueSeasons :many
+-- Moves scheduled seasons that have started to active and returns them.
+UPDATE seasons
+SET status = 'active'
+WHERE status = 'scheduled'
+  AND starts_at <= sqlc.arg(now)
+RETURNING *;
+
+-- name: ListEndedSeasons :many
+-- Active seasons whose end has passed, and archived seasons whose boards were not
+-- reset yet, oldest first.
+SELECT *
+FROM seasons
+WHERE (status = 'active' AND ends_at <= sqlc.arg(now))
+   OR (status = 'archived' AND reset_at IS NULL)
+ORDER BY ends_at;
+
+-- name: ArchiveSeason :one
+-- Archives an active season and soft-resets every rating in the same statement, so
+-- ratings are reset exactly once: with the claim, or not at all. Ratings are pulled
+-- toward the starting 1500, keeping carry_over of the distance, and deviations are
+-- widened to at least min_deviation. claimed is 0 when the season was already archived.
+WITH claimed AS (
+    UPDATE seasons
+    SET status = 'archived',
+        archived_at = NOW()
+    WHERE season_id = sqlc.arg(season_id)
+      AND status = 'active'
+    RETURNING season_id
+), reset AS (
+    UPDATE player_ratings
+    SET rating = 1500 + (rating - 1500) * sqlc.arg(carry_over)::float8,
+        deviation = GREATEST(deviation, sqlc.arg(min_deviation)::float8),
+        updated_at = NOW()
+    WHERE EXISTS (SELECT 1 FROM claimed)
+    RETURNING user_id
+)
+SELECT (SELECT COUNT(*) FROM claimed) AS claimed,
+       (SELECT COUNT(*) FROM reset) AS ratings_reset;
+
+-- name: MarkSeasonReset :exec
+-- Records that an archived season's Redis boards have been reset.
+UPDATE seasons
+SET reset_at = NOW()
+WHERE season_id = $1;
+
+-- name: InsertSeasonStandings :execrows
+-- Affects no row when the season's standings for this board are already stored.
+INSERT INTO leaderboard_snapshots (
+    time_window,
+    generated_at,
+    entries,
+    source_hash,
+    season_id
+) VALUES (
+    sqlc.arg(time_window),
+    sqlc.arg(generated_at),
+    sqlc.arg(entries),
+    sqlc.arg(source_hash),
+    sqlc.arg(season_id)
+)
+ON CONFLICT (season_id, time_window) WHERE season_id IS NOT NULL DO NOTHING;
+
+-- name: ListSeasonStandings :many
+SELECT *
+FROM leaderboard_snapshots
+WHERE season_id = $1
+ORDER BY time_window;
@@ -0,0 +1,84 @@
+-- name: RefreshQuestionStats :execrows
+-- Recomputes every question's stats from the answers of finished matches, bots
+-- excluded. Response time is the recorded latency, or for older answers the time
+-- since the question's window opened.
+WITH answers AS (
+    SELECT mq.question_id,
+           COALESCE(a->>'answer', '') AS answer,
+           COALESCE((a->>'is_correct')::boolean, false) AS is_correct,
+           COALESCE((a->>'latency_ms')::numeric, GREATEST(0, EXTRACT(EPOCH FROM (
+               (a->>'submitted_at')::timestamptz
+               - m.started_at
+               - (mq.question_order - 1) * m.per_question_seconds * INTERVAL '1 second'
+           )) * 1000)) AS response_ms
+    FROM match_questions mq
+    JOIN matches m ON m.match_id = mq.match_id
+    JOIN player_match_state pms ON pms.match_id = mq.match_id
+    JOIN users u ON u.user_id = pms.user_id
+    CROSS JOIN LATERAL jsonb_array_elements(pms.answers) AS a
+    WHERE m.status IN ('completed', 'timeout')
+      AND m.started_at IS NOT NULL
+      AND u.user_type <> 'bot'
+      AND (a->>'question_order')::int = mq.question_order
+),
+totals AS (
+    SELECT question_id,
+           COUNT(*)::int AS exposures,
+           COUNT(*) FILTER (WHERE answer <> '')::int AS answered,
+           COUNT(*) FILTER (WHERE is_correct)::int AS correct,
+           COALESCE(AVG(response_ms) FILTER (WHERE answer <> ''), 0)::int AS avg_response_ms
+    FROM answers
+    GROUP BY question_id
+),
+picks AS (
+    SELECT question_id, jsonb_object_agg(answer, picked) AS option_picks
+    FROM (
+        SELECT question_id, answer, COUNT(*) AS picked
+        FROM answers
+        WHERE answer <> ''
+        GROUP BY question_id, answer
+    ) counted
+    GROUP BY question_id
+)
+INSERT INTO question_stats (
+    question_id,
+    exposures,
+    answered,
+    correct,
+    avg_response_ms,
+    option_picks,
+    empirical_difficulty,
+    updated_at
+)
+SELECT t.question_id,
+       t.exposures,
+       t.answered,
+       t.correct,
+       t.avg_response_ms,
+       COALESCE(p.option_picks, '{}'::jsonb),
+       CASE
+           WHEN t.exposures < sqlc.arg(min_samples)::int THEN NULL
+           WHEN t.correct::float8 / t.exposures >= sqlc.arg(easy_rate)::float8 THEN 'easy'
+           WHEN t.correct::float8 / t.exposures < sqlc.arg(hard_rate)::float8 THEN 'hard'
+           ELSE 'medium'
+       END,
+       NOW()
+FROM totals t
+LEFT JOIN picks p ON p.question_id = t.question_id
+ON CONFLICT (question_id) DO UPDATE
+SET exposures = EXCLUDED.exposures,
+    answered = EXCLUDED.answered,
+    correct = EXCLUDED.correct,
+    avg_response_ms = EXCLUDED.avg_response_ms,
+    option_picks = EXCLUDED.option_picks,
+    empirical_difficulty = EXCLUDED.empirical_difficulty,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: ListQuestionStats :many
+-- Stats joined with question content for questions with at least min_exposures.
+SELECT q.question_id, q.prompt, q.options, q.correct_answer, q.difficulty, q.verified,
+       s.exposures, s.answered, s.correct, s.avg_response_ms, s.option_picks, s.empirical_difficulty, s.updated_at
+FROM question_stats s
+JOIN questions q ON q.question_id = s.question_id
+WHERE s.exposures >= sqlc.arg(min_exposures)
+ORDER BY s.exposures DESC;
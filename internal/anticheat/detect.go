@@ -0,0 +1,145 @@
+package anticheat
+
+import (
+	"math"
+
+	"github.com/gokatarajesh/quiz-platform/internal/match/scoring"
+)
+
+// Flag kinds (cheat_flags.kind).
+const (
+	KindImpossibleLatency = "impossible_latency"
+	KindUniformTiming     = "uniform_timing"
+	KindAccuracyJump      = "accuracy_jump"
+	KindWinTrading        = "win_trading"
+)
+
+const (
+	// minFastAnswers is how many impossibly fast correct answers a match needs
+	// before it is flagged, so one lucky tap is not enough.
+	minFastAnswers = 3
+	// fastAnswerShare is the share of correct answers that must be impossibly fast.
+	fastAnswerShare = 0.5
+	// minTimedAnswers is how many answers uniform timing is judged on.
+	minTimedAnswers = 5
+	// uniformTimingCV flags answer latencies whose coefficient of variation
+	// (stddev / mean) is below it. People vary far more than this across questions.
+	uniformTimingCV = 0.1
+	// minHistoryMatches is how many earlier matches an accuracy jump is judged against.
+	minHistoryMatches = 10
+	// jumpAccuracy and jumpMargin: a match at or above jumpAccuracy that beats the
+	// player's average by at least jumpMargin is flagged.
+	jumpAccuracy = 0.9
+	jumpMargin   = 0.4
+)
+
+// Finding is one suspicious pattern in a player's match, with the numbers behind it.
+type Finding struct {
+	Kind    string
+	Details map[string]interface{}
+}
+
+// answered reports whether the player submitted an answer, as opposed to the
+// empty record filled in for a question left unanswered.
+func answered(ans scoring.AnswerRecord) bool {
+	return ans.Answer != ""
+}
+
+// accuracy is the share of the match's questions the player got right.
+func accuracy(answers []scoring.AnswerRecord) float64 {
+	if len(answers) == 0 {
+		return 0
+	}
+	correct := 0
+	for _, ans := range answers {
+		if ans.IsCorrect {
+			correct++
+		}
+	}
+	return float64(correct) / float64(len(answers))
+}
+
+// detectImpossibleLatency flags a player who keeps answering correctly faster than
+// a person can read the question.
+func detectImpossibleLatency(answers []scoring.AnswerRecord, minLatencyMs int) *Finding {
+	correct, fast := 0, 0
+	for _, ans := range answers {
+		if !answered(ans) || !ans.IsCorrect {
+			continue
+		}
+		correct++
+		if ans.LatencyMs < minLatencyMs {
+			fast++
+		}
+	}
+	if fast < minFastAnswers || float64(fast) < fastAnswerShare*float64(correct) {
+		return nil
+	}
+	return &Finding{
+		Kind: KindImpossibleLatency,
+		Details: map[string]interface{}{
+			"fast_correct":   fast,
+			"correct":        correct,
+			"min_latency_ms": minLatencyMs,
+		},
+	}
+}
+
+// detectUniformTiming flags answer latencies too regular to come from a person.
+func detectUniformTiming(answers []scoring.AnswerRecord) *Finding {
+	var latencies []float64
+	for _, ans := range answers {
+		if answered(ans) {
+			latencies = append(latencies, float64(ans.LatencyMs))
+		}
+	}
+	if len(latencies) < minTimedAnswers {
+		return nil
+	}
+
+	mean := 0.0
+	for _, l := range latencies {
+		mean += l
+	}
+	mean /= float64(len(latencies))
+	if mean <= 0 {
+		return nil
+	}
+	variance := 0.0
+	for _, l := range latencies {
+		variance += (l - mean) * (l - mean)
+	}
+	stddev := math.Sqrt(variance / float64(len(latencies)))
+
+	cv := stddev / mean
+	if cv >= uniformTimingCV {
+		return nil
+	}
+	return &Finding{
+		Kind: KindUniformTiming,
+		Details: map[string]interface{}{
+			"answers":   len(latencies),
+			"mean_ms":   math.Round(mean),
+			"stddev_ms": math.Round(stddev),
+			"cv":        math.Round(cv*1000) / 1000,
+		},
+	}
+}
+
+// detectAccuracyJump flags a match far more accurate than the player's history.
+func detectAccuracyJump(matchAccuracy float64, historyMatches int64, historyAccuracy float64) *Finding {
+	if historyMatches < minHistoryMatches {
+		return nil
+	}
+	if matchAccuracy < jumpAccuracy || matchAccuracy-historyAccuracy < jumpMargin {
+		return nil
+	}
+	return &Finding{
+		Kind: KindAccuracyJump,
+		Details: map[string]interface{}{
+			"accuracy":         matchAccuracy,
+			"history_accuracy": math.Round(historyAccuracy*100) / 100,
+			"history_matches":  historyMatches,
+		},
+	}
+}
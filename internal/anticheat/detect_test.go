@@ -0,0 +1,65 @@
+package anticheat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gokatarajesh/quiz-platform/internal/match/scoring"
+)
+
+// timed builds answered records with the given latencies, all correct.
+func timed(latencies ...int) []scoring.AnswerRecord {
+	answers := make([]scoring.AnswerRecord, len(latencies))
+	for i, l := range latencies {
+		answers[i] = scoring.AnswerRecord{QuestionOrder: i + 1, Answer: "Jupiter", IsCorrect: true, LatencyMs: l}
+	}
+	return answers
+}
+
+func TestDetectImpossibleLatency(t *testing.T) {
+	f := detectImpossibleLatency(timed(150, 200, 180, 3200, 4100), 400)
+	require.NotNil(t, f)
+	assert.Equal(t, KindImpossibleLatency, f.Kind)
+	assert.Equal(t, 3, f.Details["fast_correct"])
+
+	// Fast but wrong answers are guesses, not lookups
+	guesses := timed(150, 200, 180, 3200, 4100)
+	for i := range guesses[:3] {
+		guesses[i].IsCorrect = false
+	}
+	assert.Nil(t, detectImpossibleLatency(guesses, 400))
+
+	// A couple of quick taps among slow answers are fine
+	assert.Nil(t, detectImpossibleLatency(timed(150, 200, 3000, 5200, 4100), 400))
+	assert.Nil(t, detectImpossibleLatency(timed(150, 200, 300, 3000, 5200, 4100, 6000, 3900), 400))
+}
+
+func TestDetectUniformTiming(t *testing.T) {
+	f := detectUniformTiming(timed(2000, 2050, 1980, 2010, 2030))
+	require.NotNil(t, f)
+	assert.Equal(t, KindUniformTiming, f.Kind)
+	assert.Equal(t, 5, f.Details["answers"])
+
+	assert.Nil(t, detectUniformTiming(timed(2000, 5400, 1200, 8800, 3100)))
+	// Too few answers to judge
+	assert.Nil(t, detectUniformTiming(timed(2000, 2000, 2000, 2000)))
+
+	// Unanswered questions are not timed
+	answers := append(timed(2000, 5400, 1200, 8800), scoring.AnswerRecord{QuestionOrder: 5})
+	assert.Nil(t, detectUniformTiming(answers))
+}
+
+func TestDetectAccuracyJump(t *testing.T) {
+	f := detectAccuracyJump(1.0, 25, 0.45)
+	require.NotNil(t, f)
+	assert.Equal(t, KindAccuracyJump, f.Kind)
+
+	// Consistently strong players are not flagged
+	assert.Nil(t, detectAccuracyJump(1.0, 25, 0.85))
+	// Too little history
+	assert.Nil(t, detectAccuracyJump(1.0, 4, 0.3))
+	// A big improvement that is not near perfect
+	assert.Nil(t, detectAccuracyJump(0.8, 25, 0.3))
+}
@@ -0,0 +1,113 @@
+package anticheat
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/gokatarajesh/quiz-platform/internal/auth/jwt"
+	httperrors "github.com/gokatarajesh/quiz-platform/pkg/http/errors"
+)
+
+// HTTPHandlers provides the admin endpoints for reviewing anti-cheat flags. They
+// expect the auth and admin middleware to have run.
+type HTTPHandlers struct {
+	service *Service
+	logger  zerolog.Logger
+}
+
+// NewHTTPHandlers creates HTTP handlers for anti-cheat review.
+func NewHTTPHandlers(service *Service, logger zerolog.Logger) *HTTPHandlers {
+	return &HTTPHandlers{
+		service: service,
+		logger:  logger.With().Str("component", "anticheat_http").Logger(),
+	}
+}
+
+// Register mounts the anti-cheat endpoints on mux.
+func (h *HTTPHandlers) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/v1/admin/cheat-flags", h.List)
+	mux.HandleFunc("/v1/admin/cheat-flags/{id}/review", h.Review)
+}
+
+// List handles GET /v1/admin/cheat-flags?status=&limit=&offset=
+func (h *HTTPHandlers) List(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httperrors.RespondError(w, http.StatusMethodNotAllowed, httperrors.ErrCodeInvalidRequest, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	status := query.Get("status")
+	switch status {
+	case "", FlagOpen, FlagConfirmed, FlagDismissed:
+	default:
+		httperrors.RespondValidationError(w, httperrors.ErrCodeValidationFailed, "status must be open, confirmed or dismissed", "status")
+		return
+	}
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	flags, err := h.service.List(r.Context(), status, limit, offset)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to list cheat flags")
+		httperrors.RespondError(w, http.StatusInternalServerError, httperrors.ErrCodeAntiCheatFailed, "Failed to list cheat flags")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"flags": flags,
+		"count": len(flags),
+	})
+}
+
+// Review handles POST /v1/admin/cheat-flags/{id}/review
+func (h *HTTPHandlers) Review(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httperrors.RespondError(w, http.StatusMethodNotAllowed, httperrors.ErrCodeInvalidRequest, "Method not allowed")
+		return
+	}
+	claims, ok := r.Context().Value("claims").(*jwt.Claims)
+	if !ok || claims == nil {
+		httperrors.RespondUnauthorized(w, httperrors.ErrCodeAuthenticationRequired, "Authentication required")
+		return
+	}
+	flagID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		httperrors.RespondValidationError(w, httperrors.ErrCodeValidationFailed, "Invalid flag ID", "id")
+		return
+	}
+
+	var review Review
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		httperrors.RespondBadRequest(w, httperrors.ErrCodeInvalidRequest, "Invalid JSON payload")
+		return
+	}
+
+	result, err := h.service.Review(r.Context(), claims.UserID, flagID, review)
+	switch {
+	case err == nil:
+		h.respondJSON(w, http.StatusOK, result)
+	case errors.Is(err, ErrFlagNotFound):
+		httperrors.RespondNotFound(w, httperrors.ErrCodeCheatFlagNotFound, "Cheat flag not found")
+	case errors.Is(err, ErrFlagReviewed):
+		httperrors.RespondError(w, http.StatusConflict, httperrors.ErrCodeFlagAlreadyReviewed, "Cheat flag already reviewed")
+	case errors.Is(err, ErrInvalidReview):
+		httperrors.RespondBadRequest(w, httperrors.ErrCodeInvalidReview, err.Error())
+	default:
+		h.logger.Error().Err(err).Str("flag_id", flagID.String()).Msg("cheat flag review failed")
+		httperrors.RespondError(w, http.StatusInternalServerError, httperrors.ErrCodeAntiCheatFailed, "Cheat flag review failed")
+	}
+}
+
+func (h *HTTPHandlers) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error().Err(err).Msg("failed to encode JSON response")
+	}
+}
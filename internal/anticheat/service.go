@@ -0,0 +1,370 @@
+package anticheat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+
+	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
+	"github.com/gokatarajesh/quiz-platform/internal/match/scoring"
+)
+
+// Flag states (cheat_flags.status).
+const (
+	FlagOpen      = "open"
+	FlagConfirmed = "confirmed"
+	FlagDismissed = "dismissed"
+)
+
+// Audit log actions recorded for flag reviews.
+const (
+	entityFlag            = "cheat_flag"
+	entityMatch           = "match"
+	ActionReviewFlag      = "cheat_flag.review"
+	ActionInvalidateMatch = "match.invalidate"
+)
+
+// historyWindow is how many earlier matches a player's accuracy is averaged over.
+const historyWindow = 50
+
+var (
+	// ErrFlagNotFound is returned when the flag does not exist.
+	ErrFlagNotFound = errors.New("cheat flag not found")
+	// ErrFlagReviewed is returned when reviewing a flag that is no longer open.
+	ErrFlagReviewed = errors.New("cheat flag already reviewed")
+	// ErrInvalidReview wraps a review that fails validation.
+	ErrInvalidReview = errors.New("invalid review")
+)
+
+// Store is the subset of sqlc queries the anti-cheat service needs.
+type Store interface {
+	InsertCheatFlag(ctx context.Context, arg sqlcgen.InsertCheatFlagParams) (int64, error)
+	GetCheatFlag(ctx context.Context, flagID pgtype.UUID) (sqlcgen.CheatFlag, error)
+	ListCheatFlags(ctx context.Context, arg sqlcgen.ListCheatFlagsParams) ([]sqlcgen.CheatFlag, error)
+	ReviewCheatFlag(ctx context.Context, arg sqlcgen.ReviewCheatFlagParams) (sqlcgen.CheatFlag, error)
+	GetPlayerAccuracyHistory(ctx context.Context, arg sqlcgen.GetPlayerAccuracyHistoryParams) (sqlcgen.GetPlayerAccuracyHistoryRow, error)
+	CountRecentPairings(ctx context.Context, arg sqlcgen.CountRecentPairingsParams) (int64, error)
+	InsertAuditLog(ctx context.Context, arg sqlcgen.InsertAuditLogParams) error
+}
+
+// MatchInvalidator voids a finished match's leaderboard results. It returns the
+// number of player results taken back off the leaderboards.
+type MatchInvalidator interface {
+	InvalidateMatch(ctx context.Context, matchID uuid.UUID) (int, error)
+}
+
+// Options configures the anti-cheat service.
+type Options struct {
+	MinHumanLatency  time.Duration // correct answers faster than this are not humanly possible (default 400ms)
+	PairingThreshold int           // matchmade games between the same two accounts that count as win trading (default 5)
+	PairingWindow    time.Duration // how far back pairings are counted (default 7 days)
+}
+
+// Player is one human player's finished match.
+type Player struct {
+	UserID  uuid.UUID
+	Answers []scoring.AnswerRecord // every question, unanswered ones included
+}
+
+// Match is a finished match as handed over for inspection.
+type Match struct {
+	ID                  uuid.UUID
+	Matchmade           bool // paired by the random queue rather than a private room
+	LeaderboardEligible bool
+	Players             []Player
+}
+
+// Flag is a stored anti-cheat flag as shown to admins.
+type Flag struct {
+	ID         uuid.UUID       `json:"flag_id"`
+	MatchID    uuid.UUID       `json:"match_id"`
+	UserID     uuid.UUID       `json:"user_id"`
+	Kind       string          `json:"kind"`
+	Details    json.RawMessage `json:"details"`
+	Status     string          `json:"status"`
+	ReviewedBy *uuid.UUID      `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time      `json:"reviewed_at,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// Review is an admin decision on a flag.
+type Review struct {
+	Outcome         string `json:"outcome"`                    // confirmed or dismissed
+	InvalidateMatch bool   `json:"invalidate_match,omitempty"` // confirmed: void the match's leaderboard results
+	Note            string `json:"note,omitempty"`
+}
+
+// ReviewResult reports what a review changed.
+type ReviewResult struct {
+	Flag             Flag `json:"flag"`
+	MatchInvalidated bool `json:"match_invalidated"`
+	ResultsReversed  int  `json:"results_reversed"`
+}
+
+// Service runs the anti-cheat detectors over finished matches and manages the
+// flags they raise. Review decisions are written to audit_logs.
+type Service struct {
+	store            Store
+	invalidator      MatchInvalidator
+	minLatencyMs     int
+	pairingThreshold int
+	pairingWindow    time.Duration
+	logger           zerolog.Logger
+}
+
+// NewService creates an anti-cheat service.
+func NewService(store Store, opts Options, logger zerolog.Logger) *Service {
+	minLatency := opts.MinHumanLatency
+	if minLatency <= 0 {
+		minLatency = 400 * time.Millisecond
+	}
+	threshold := opts.PairingThreshold
+	if threshold <= 0 {
+		threshold = 5
+	}
+	window := opts.PairingWindow
+	if window <= 0 {
+		window = 7 * 24 * time.Hour
+	}
+
+	return &Service{
+		store:            store,
+		minLatencyMs:     int(minLatency / time.Millisecond),
+		pairingThreshold: threshold,
+		pairingWindow:    window,
+		logger:           logger.With().Str("component", "anticheat").Logger(),
+	}
+}
+
+// UseInvalidator sets what confirmed flags use to void a match. The match service
+// depends on this one, so it is wired after both are built.
+func (s *Service) UseInvalidator(invalidator MatchInvalidator) {
+	s.invalidator = invalidator
+}
+
+// raisedFlag pairs a finding with the player it is about.
+type raisedFlag struct {
+	userID uuid.UUID
+	Finding
+}
+
+// Inspect runs every detector over a finished match and stores a flag for each
+// finding. It returns the number of new flags.
+func (s *Service) Inspect(ctx context.Context, m Match) (int, error) {
+	var raised []raisedFlag
+	add := func(userID uuid.UUID, f *Finding) {
+		if f != nil {
+			raised = append(raised, raisedFlag{userID: userID, Finding: *f})
+		}
+	}
+
+	for _, p := range m.Players {
+		add(p.UserID, detectImpossibleLatency(p.Answers, s.minLatencyMs))
+		add(p.UserID, detectUniformTiming(p.Answers))
+
+		history, err := s.store.GetPlayerAccuracyHistory(ctx, sqlcgen.GetPlayerAccuracyHistoryParams{
+			UserID:         pgtype.UUID{Bytes: p.UserID, Valid: true},
+			ExcludeMatchID: pgtype.UUID{Bytes: m.ID, Valid: true},
+			MaxMatches:     historyWindow,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("load accuracy history: %w", err)
+		}
+		add(p.UserID, detectAccuracyJump(accuracy(p.Answers), history.Matches, history.AvgAccuracy))
+	}
+
+	// Win trading only means something where results count and the queue picked the pairing
+	if m.Matchmade && m.LeaderboardEligible && len(m.Players) == 2 {
+		a, b := m.Players[0].UserID, m.Players[1].UserID
+		count, err := s.store.CountRecentPairings(ctx, sqlcgen.CountRecentPairingsParams{
+			PlayerA: pgtype.UUID{Bytes: a, Valid: true},
+			PlayerB: pgtype.UUID{Bytes: b, Valid: true},
+			Since:   pgtype.Timestamptz{Time: time.Now().Add(-s.pairingWindow), Valid: true},
+		})
+		if err != nil {
+			return 0, fmt.Errorf("count pairings: %w", err)
+		}
+		if count >= int64(s.pairingThreshold) {
+			details := map[string]interface{}{
+				"pairings":     count,
+				"window_hours": int(s.pairingWindow / time.Hour),
+			}
+			add(a, &Finding{Kind: KindWinTrading, Details: withOpponent(details, b)})
+			add(b, &Finding{Kind: KindWinTrading, Details: withOpponent(details, a)})
+		}
+	}
+
+	flagged := 0
+	for _, f := range raised {
+		details, _ := json.Marshal(f.Details)
+		n, err := s.store.InsertCheatFlag(ctx, sqlcgen.InsertCheatFlagParams{
+			MatchID: pgtype.UUID{Bytes: m.ID, Valid: true},
+			UserID:  pgtype.UUID{Bytes: f.userID, Valid: true},
+			Kind:    f.Kind,
+			Details: details,
+		})
+		if err != nil {
+			return flagged, fmt.Errorf("insert flag: %w", err)
+		}
+		if n == 0 {
+			continue
+		}
+		flagged++
+		s.logger.Warn().
+			Str("match_id", m.ID.String()).
+			Str("user_id", f.userID.String()).
+			Str("kind", f.Kind).
+			RawJSON("details", details).
+			Msg("cheat flag raised")
+	}
+	return flagged, nil
+}
+
+func withOpponent(details map[string]interface{}, opponentID uuid.UUID) map[string]interface{} {
+	out := make(map[string]interface{}, len(details)+1)
+	for k, v := range details {
+		out[k] = v
+	}
+	out["opponent_id"] = opponentID.String()
+	return out
+}
+
+// List returns flags in the given status (default open), newest first.
+func (s *Service) List(ctx context.Context, status string, limit, offset int) ([]Flag, error) {
+	if status == "" {
+		status = FlagOpen
+	}
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	rows, err := s.store.ListCheatFlags(ctx, sqlcgen.ListCheatFlagsParams{
+		Status:   status,
+		MaxCount: int32(limit),
+		Skip:     int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list flags: %w", err)
+	}
+	flags := make([]Flag, 0, len(rows))
+	for _, row := range rows {
+		flags = append(flags, toFlag(row))
+	}
+	return flags, nil
+}
+
+// Review closes an open flag. Confirming it with InvalidateMatch also marks the
+// match leaderboard-ineligible and reverses its leaderboard results; that happens
+// first, so a failed invalidation leaves the flag open to retry.
+func (s *Service) Review(ctx context.Context, actorID, flagID uuid.UUID, review Review) (*ReviewResult, error) {
+	switch review.Outcome {
+	case FlagConfirmed, FlagDismissed:
+	default:
+		return nil, fmt.Errorf("%w: outcome must be %s or %s", ErrInvalidReview, FlagConfirmed, FlagDismissed)
+	}
+	if review.InvalidateMatch && review.Outcome != FlagConfirmed {
+		return nil, fmt.Errorf("%w: invalidate_match requires a confirmed outcome", ErrInvalidReview)
+	}
+
+	pgFlagID := pgtype.UUID{Bytes: flagID, Valid: true}
+	current, err := s.store.GetCheatFlag(ctx, pgFlagID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrFlagNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load flag: %w", err)
+	}
+	if current.Status != FlagOpen {
+		return nil, ErrFlagReviewed
+	}
+
+	result := &ReviewResult{}
+	matchID := uuid.UUID(current.MatchID.Bytes)
+	if review.InvalidateMatch {
+		if s.invalidator == nil {
+			return nil, errors.New("match invalidation not configured")
+		}
+		result.ResultsReversed, err = s.invalidator.InvalidateMatch(ctx, matchID)
+		if err != nil {
+			return nil, fmt.Errorf("invalidate match: %w", err)
+		}
+		result.MatchInvalidated = true
+		s.audit(ctx, actorID, entityMatch, matchID, ActionInvalidateMatch, map[string]interface{}{
+			"flag_id":          flagID,
+			"results_reversed": result.ResultsReversed,
+		})
+	}
+
+	row, err := s.store.ReviewCheatFlag(ctx, sqlcgen.ReviewCheatFlagParams{
+		Status:     review.Outcome,
+		ReviewedBy: pgtype.UUID{Bytes: actorID, Valid: true},
+		FlagID:     pgFlagID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrFlagReviewed
+	}
+	if err != nil {
+		return nil, fmt.Errorf("review flag: %w", err)
+	}
+	result.Flag = toFlag(row)
+
+	s.audit(ctx, actorID, entityFlag, flagID, ActionReviewFlag, map[string]interface{}{
+		"outcome":          review.Outcome,
+		"kind":             row.Kind,
+		"match_id":         matchID,
+		"user_id":          uuid.UUID(row.UserID.Bytes),
+		"invalidate_match": review.InvalidateMatch,
+		"note":             strings.TrimSpace(review.Note),
+	})
+	return result, nil
+}
+
+func (s *Service) audit(ctx context.Context, actorID uuid.UUID, entityType string, entityID uuid.UUID, action string, payload map[string]interface{}) {
+	data, _ := json.Marshal(payload)
+	err := s.store.InsertAuditLog(ctx, sqlcgen.InsertAuditLogParams{
+		ActorID:    pgtype.UUID{Bytes: actorID, Valid: actorID != uuid.Nil},
+		EntityType: entityType,
+		EntityID:   pgtype.UUID{Bytes: entityID, Valid: true},
+		Action:     action,
+		Payload:    data,
+	})
+	if err != nil {
+		s.logger.Error().Err(err).
+			Str("actor_id", actorID.String()).
+			Str("entity_id", entityID.String()).
+			Str("action", action).
+			Msg("failed to write audit log")
+	}
+}
+
+func toFlag(row sqlcgen.CheatFlag) Flag {
+	flag := Flag{
+		ID:        uuid.UUID(row.FlagID.Bytes),
+		MatchID:   uuid.UUID(row.MatchID.Bytes),
+		UserID:    uuid.UUID(row.UserID.Bytes),
+		Kind:      row.Kind,
+		Details:   json.RawMessage(row.Details),
+		Status:    row.Status,
+		CreatedAt: row.CreatedAt.Time,
+	}
+	if row.ReviewedBy.Valid {
+		reviewer := uuid.UUID(row.ReviewedBy.Bytes)
+		flag.ReviewedBy = &reviewer
+	}
+	if row.ReviewedAt.Valid {
+		reviewedAt := row.ReviewedAt.Time
+		flag.ReviewedAt = &reviewedAt
+	}
+	return flag
+}
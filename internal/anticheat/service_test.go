@@ -0,0 +1,192 @@
+package anticheat
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
+)
+
+// fakeStore keeps flags and audit rows in memory, with canned history and pairings.
+type fakeStore struct {
+	flags    []sqlcgen.CheatFlag
+	audit    []sqlcgen.InsertAuditLogParams
+	history  map[uuid.UUID]sqlcgen.GetPlayerAccuracyHistoryRow
+	pairings int64
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{history: map[uuid.UUID]sqlcgen.GetPlayerAccuracyHistoryRow{}}
+}
+
+func (s *fakeStore) InsertCheatFlag(ctx context.Context, arg sqlcgen.InsertCheatFlagParams) (int64, error) {
+	for _, f := range s.flags {
+		if f.MatchID == arg.MatchID && f.UserID == arg.UserID && f.Kind == arg.Kind {
+			return 0, nil
+		}
+	}
+	s.flags = append(s.flags, sqlcgen.CheatFlag{
+		FlagID:  pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		MatchID: arg.MatchID,
+		UserID:  arg.UserID,
+		Kind:    arg.Kind,
+		Details: arg.Details,
+		Status:  FlagOpen,
+	})
+	return 1, nil
+}
+
+func (s *fakeStore) GetCheatFlag(ctx context.Context, flagID pgtype.UUID) (sqlcgen.CheatFlag, error) {
+	for _, f := range s.flags {
+		if f.FlagID == flagID {
+			return f, nil
+		}
+	}
+	return sqlcgen.CheatFlag{}, pgx.ErrNoRows
+}
+
+func (s *fakeStore) ListCheatFlags(ctx context.Context, arg sqlcgen.ListCheatFlagsParams) ([]sqlcgen.CheatFlag, error) {
+	var rows []sqlcgen.CheatFlag
+	for _, f := range s.flags {
+		if f.Status == arg.Status {
+			rows = append(rows, f)
+		}
+	}
+	return rows, nil
+}
+
+func (s *fakeStore) ReviewCheatFlag(ctx context.Context, arg sqlcgen.ReviewCheatFlagParams) (sqlcgen.CheatFlag, error) {
+	for i := range s.flags {
+		if s.flags[i].FlagID == arg.FlagID && s.flags[i].Status == FlagOpen {
+			s.flags[i].Status = arg.Status
+			s.flags[i].ReviewedBy = arg.ReviewedBy
+			return s.flags[i], nil
+		}
+	}
+	return sqlcgen.CheatFlag{}, pgx.ErrNoRows
+}
+
+func (s *fakeStore) GetPlayerAccuracyHistory(ctx context.Context, arg sqlcgen.GetPlayerAccuracyHistoryParams) (sqlcgen.GetPlayerAccuracyHistoryRow, error) {
+	return s.history[uuid.UUID(arg.UserID.Bytes)], nil
+}
+
+func (s *fakeStore) CountRecentPairings(ctx context.Context, arg sqlcgen.CountRecentPairingsParams) (int64, error) {
+	return s.pairings, nil
+}
+
+func (s *fakeStore) InsertAuditLog(ctx context.Context, arg sqlcgen.InsertAuditLogParams) error {
+	s.audit = append(s.audit, arg)
+	return nil
+}
+
+type fakeInvalidator struct {
+	matches []uuid.UUID
+}
+
+func (f *fakeInvalidator) InvalidateMatch(ctx context.Context, matchID uuid.UUID) (int, error) {
+	f.matches = append(f.matches, matchID)
+	return 2, nil
+}
+
+func kinds(flags []sqlcgen.CheatFlag, userID uuid.UUID) []string {
+	var out []string
+	for _, f := range flags {
+		if uuid.UUID(f.UserID.Bytes) == userID {
+			out = append(out, f.Kind)
+		}
+	}
+	return out
+}
+
+func TestInspectStoresFlagsOnce(t *testing.T) {
+	cheater, honest := uuid.New(), uuid.New()
+	store := newFakeStore()
+	store.history[cheater] = sqlcgen.GetPlayerAccuracyHistoryRow{Matches: 30, AvgAccuracy: 0.4}
+	store.history[honest] = sqlcgen.GetPlayerAccuracyHistoryRow{Matches: 30, AvgAccuracy: 0.6}
+	store.pairings = 6
+	svc := NewService(store, Options{}, zerolog.New(io.Discard))
+
+	m := Match{
+		ID:                  uuid.New(),
+		Matchmade:           true,
+		LeaderboardEligible: true,
+		Players: []Player{
+			{UserID: cheater, Answers: timed(210, 190, 205, 200, 195)},
+			{UserID: honest, Answers: timed(2400, 5100, 1800, 7300, 3900)},
+		},
+	}
+	m.Players[1].Answers[4].IsCorrect = false
+
+	n, err := svc.Inspect(context.Background(), m)
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.ElementsMatch(t, []string{KindImpossibleLatency, KindUniformTiming, KindAccuracyJump, KindWinTrading}, kinds(store.flags, cheater))
+	assert.Equal(t, []string{KindWinTrading}, kinds(store.flags, honest))
+
+	// Inspecting the same match again raises nothing new
+	n, err = svc.Inspect(context.Background(), m)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestInspectSkipsWinTradingOutsideMatchmaking(t *testing.T) {
+	store := newFakeStore()
+	store.pairings = 20
+	svc := NewService(store, Options{}, zerolog.New(io.Discard))
+
+	n, err := svc.Inspect(context.Background(), Match{
+		ID:                  uuid.New(),
+		LeaderboardEligible: true,
+		Players: []Player{
+			{UserID: uuid.New(), Answers: timed(2400, 5100, 1800)},
+			{UserID: uuid.New(), Answers: timed(3100, 4200, 6900)},
+		},
+	})
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestReviewConfirmInvalidatesMatch(t *testing.T) {
+	store := newFakeStore()
+	matchID, admin := uuid.New(), uuid.New()
+	_, err := store.InsertCheatFlag(context.Background(), sqlcgen.InsertCheatFlagParams{
+		MatchID: pgtype.UUID{Bytes: matchID, Valid: true},
+		UserID:  pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Kind:    KindImpossibleLatency,
+	})
+	require.NoError(t, err)
+	flagID := uuid.UUID(store.flags[0].FlagID.Bytes)
+
+	invalidator := &fakeInvalidator{}
+	svc := NewService(store, Options{}, zerolog.New(io.Discard))
+	svc.UseInvalidator(invalidator)
+
+	_, err = svc.Review(context.Background(), admin, flagID, Review{Outcome: FlagDismissed, InvalidateMatch: true})
+	assert.ErrorIs(t, err, ErrInvalidReview)
+	assert.Empty(t, invalidator.matches)
+
+	result, err := svc.Review(context.Background(), admin, flagID, Review{Outcome: FlagConfirmed, InvalidateMatch: true, Note: "scripted"})
+	require.NoError(t, err)
+	assert.True(t, result.MatchInvalidated)
+	assert.Equal(t, 2, result.ResultsReversed)
+	assert.Equal(t, FlagConfirmed, result.Flag.Status)
+	assert.Equal(t, []uuid.UUID{matchID}, invalidator.matches)
+
+	require.Len(t, store.audit, 2)
+	assert.Equal(t, ActionInvalidateMatch, store.audit[0].Action)
+	assert.Equal(t, ActionReviewFlag, store.audit[1].Action)
+	assert.Equal(t, admin, uuid.UUID(store.audit[1].ActorID.Bytes))
+
+	_, err = svc.Review(context.Background(), admin, flagID, Review{Outcome: FlagDismissed})
+	assert.ErrorIs(t, err, ErrFlagReviewed)
+	_, err = svc.Review(context.Background(), admin, uuid.New(), Review{Outcome: FlagDismissed})
+	assert.ErrorIs(t, err, ErrFlagNotFound)
+}
@@ -7,11 +7,14 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
+	_ "time/tzdata" // leaderboard timezones must load in minimal images
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
 
+	"github.com/gokatarajesh/quiz-platform/internal/anticheat"
 	"github.com/gokatarajesh/quiz-platform/internal/auth"
 	"github.com/gokatarajesh/quiz-platform/internal/auth/jwt"
 	"github.com/gokatarajesh/quiz-platform/internal/config"
@@ -21,8 +24,10 @@ import (
 	"github.com/gokatarajesh/quiz-platform/internal/logging"
 	"github.com/gokatarajesh/quiz-platform/internal/match"
 	matchqueue "github.com/gokatarajesh/quiz-platform/internal/match/queue"
+	"github.com/gokatarajesh/quiz-platform/internal/moderation"
 	"github.com/gokatarajesh/quiz-platform/internal/question"
 	"github.com/gokatarajesh/quiz-platform/internal/question/ai"
+	"github.com/gokatarajesh/quiz-platform/internal/rating"
 	"github.com/gokatarajesh/quiz-platform/internal/server"
 	ws "github.com/gokatarajesh/quiz-platform/pkg/http/ws"
 )
@@ -38,6 +43,10 @@ type Application struct {
 
 	lbBroadcaster  *leaderboard.Broadcaster
 	snapshotWorker *leaderboard.SnapshotWorker
+	seasonWorker   *leaderboard.SeasonWorker
+	statsWorker    *question.StatsWorker
+	queueMatcher   *matchqueue.Matcher
+	wsHub          *ws.Hub
 	bgCancels      []context.CancelFunc
 }
 
@@ -49,6 +58,15 @@ func New(ctx context.Context, cfg *config.App) (*Application, error) {
 	connString := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s pool_max_conns=10",
 		cfg.Postgres.Host, cfg.Postgres.Port, cfg.Postgres.User, cfg.Postgres.Password, cfg.Postgres.Database, cfg.Postgres.SSLMode)
 
+	seasons, err := leaderboard.ParseSeasons(cfg.Seasons.Definitions)
+	if err != nil {
+		return nil, fmt.Errorf("parse seasons: %w", err)
+	}
+	leaderboardLoc, err := time.LoadLocation(cfg.Leaderboard.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("load leaderboard timezone: %w", err)
+	}
+
 	pool, err := pgxpool.New(ctx, connString)
 	if err != nil {
 		return nil, fmt.Errorf("connect postgres: %w", err)
@@ -152,13 +170,32 @@ func New(ctx context.Context, cfg *config.App) (*Application, error) {
 			HMACSecret: []byte(cfg.Security.QuestionHMACSecret),
 			Redis:      redisClient,
 		},
+		logger,
 	)
 
 	stateMgr := match.NewStateManager(redisClient, logger)
-	queueMgr := matchqueue.NewManager(redisClient, logger, 10)
+	queueMgr := matchqueue.NewManager(redisClient, logger, cfg.Bot.WaitSeconds, matchqueue.Bands{
+		Base:      cfg.Matchmaking.RatingBand,
+		PerSecond: cfg.Matchmaking.BandGrowth,
+		Max:       cfg.Matchmaking.MaxRatingBand,
+	})
 	roomMgr := match.NewRoomManager(redisClient, logger)
-	leaderboardSvc := leaderboard.NewService(redisClient, logger, leaderboard.ServiceOptions{})
+	leaderboardSvc := leaderboard.NewService(redisClient, logger, leaderboard.ServiceOptions{
+		Location:        leaderboardLoc,
+		PeriodRetention: cfg.Leaderboard.PeriodRetention,
+	})
 	wsHub := ws.NewHub(logger)
+	wsHub.UseBackplane(ws.NewRedisBackplane(redisClient, "", logger))
+	moderationSvc := moderation.NewService(queries, moderation.Options{
+		ReportThreshold: cfg.Moderation.ReportThreshold,
+		StatsMinSamples: cfg.QuestionStats.MinSamples,
+	}, logger)
+	anticheatSvc := anticheat.NewService(queries, anticheat.Options{
+		MinHumanLatency:  cfg.AntiCheat.MinHumanLatency,
+		PairingThreshold: cfg.AntiCheat.PairingThreshold,
+		PairingWindow:    cfg.AntiCheat.PairingWindow,
+	}, logger)
+	ratingSvc := rating.NewService(rating.NewStore(pool), rating.Options{Tau: cfg.Rating.Tau}, logger)
 
 	matchSvc := match.NewService(
 		matchRepo,
@@ -167,24 +204,33 @@ func New(ctx context.Context, cfg *config.App) (*Application, error) {
 		queueMgr,
 		roomMgr,
 		leaderboardSvc,
+		moderationSvc,
+		anticheatSvc,
+		ratingSvc,
 		match.ServiceOptions{
-			HMACSecret: []byte(cfg.Security.QuestionHMACSecret),
+			HMACSecret:       []byte(cfg.Security.QuestionHMACSecret),
+			BotProfiles:      botProfiles(cfg.Bot),
+			ResumeGrace:      cfg.Runtime.ResumeGrace,
+			ShufflePerPlayer: cfg.Runtime.ShuffleOptionsPerPlayer,
 		},
 		logger,
 	)
+	anticheatSvc.UseInvalidator(matchSvc)
 
 	matchWSHandler := match.NewHandler(matchSvc, wsHub, authSvc, logger)
+	queueMatcher := matchqueue.NewMatcher(queueMgr, matchWSHandler.HandleQueuePair, matchWSHandler.SendQueueUpdate, matchWSHandler.SendQueueExpired, logger)
 	matchHTTPHandlers := match.NewHTTPHandlers(matchSvc, logger)
 	
 	// Apply auth middleware chain to room creation endpoint
 	// Middleware order: authMiddleware validates token first, then requireAuth checks claims, then requireRegistered checks user type
-	var matchRoomHandler http.Handler
+	var matchRoomHandler, matchReportHandler http.Handler
 	if matchHTTPHandlers != nil && authSvc != nil {
 		authMiddleware := auth.AuthMiddleware(authSvc, logger)
 		requireAuth := auth.RequireAuth
 		requireRegistered := auth.RequireRegistered
 		roomsHandler := http.HandlerFunc(matchHTTPHandlers.CreateRoom)
 		matchRoomHandler = authMiddleware(requireAuth(requireRegistered(roomsHandler)))
+		matchReportHandler = authMiddleware(requireAuth(http.HandlerFunc(matchHTTPHandlers.ReportQuestion)))
 	}
 	
 	lbBroadcaster := leaderboard.NewBroadcaster(redisClient, wsHub, "", logger)
@@ -199,8 +245,34 @@ func New(ctx context.Context, cfg *config.App) (*Application, error) {
 			logger,
 		)
 	}
+	seasonSvc := leaderboard.NewSeasonService(queries, leaderboardSvc, leaderboard.SeasonOptions{
+		RatingCarryOver: cfg.Seasons.RatingCarryOver,
+		ResetDeviation:  cfg.Seasons.ResetDeviation,
+	}, logger)
+	lbHTTPHandler.UseSeasons(seasonSvc)
+	leaderboardSvc.UseSeasons(seasonSvc)
+	if authSvc != nil {
+		lbHTTPHandler.UseAuth(func(next http.Handler) http.Handler {
+			return auth.AuthMiddleware(authSvc, logger)(auth.RequireAuth(next))
+		})
+	}
+	seasonWorker := leaderboard.NewSeasonWorker(seasonSvc, seasons, cfg.Seasons.CheckInterval, logger)
+	var statsWorker *question.StatsWorker
+	if interval := cfg.QuestionStats.RefreshInterval; interval > 0 {
+		statsWorker = question.NewStatsWorker(queries, interval, cfg.QuestionStats.MinSamples, logger)
+	}
 
-	apiServer := server.NewHTTPServer(cfg, logger, pool, redisClient, authHandlers, authSvc, matchHTTPHandlers.GetRoom, matchRoomHandler, matchWSHandler.HandleWebSocket, lbHTTPHandler.HandleGet)
+	// Admin endpoints: authMiddleware, requireAuth, then the admin role check
+	var adminHandler http.Handler
+	if authSvc != nil {
+		adminMux := http.NewServeMux()
+		moderation.NewHTTPHandlers(moderationSvc, logger).Register(adminMux)
+		anticheat.NewHTTPHandlers(anticheatSvc, logger).Register(adminMux)
+		leaderboard.NewSeasonAdminHandlers(seasonSvc, logger).Register(adminMux)
+		adminHandler = auth.AuthMiddleware(authSvc, logger)(auth.RequireAuth(auth.RequireAdmin(adminMux)))
+	}
+
+	apiServer := server.NewHTTPServer(cfg, logger, pool, redisClient, authHandlers, authSvc, matchHTTPHandlers.GetRoom, matchRoomHandler, matchWSHandler.HandleWebSocket, lbHTTPHandler.HandleGet, matchReportHandler, adminHandler)
 
 	return &Application{
 		cfg:            cfg,
@@ -210,7 +282,11 @@ func New(ctx context.Context, cfg *config.App) (*Application, error) {
 		http:           apiServer,
 		lbBroadcaster:  lbBroadcaster,
 		snapshotWorker: snapshotWorker,
-		bgCancels:      make([]context.CancelFunc, 0, 2),
+		seasonWorker:   seasonWorker,
+		statsWorker:    statsWorker,
+		queueMatcher:   queueMatcher,
+		wsHub:          wsHub,
+		bgCancels:      make([]context.CancelFunc, 0, 4),
 	}, nil
 }
 
@@ -260,6 +336,16 @@ func (a *Application) Run(ctx context.Context) error {
 }
 
 func (a *Application) startBackgroundWorkers(ctx context.Context) {
+	if a.wsHub != nil {
+		bgCtx, cancel := context.WithCancel(ctx)
+		a.bgCancels = append(a.bgCancels, cancel)
+		go func() {
+			if err := a.wsHub.Run(bgCtx); err != nil && err != context.Canceled {
+				a.logger.Warn().Err(err).Msg("websocket backplane stopped")
+			}
+		}()
+	}
+
 	if a.lbBroadcaster != nil {
 		bgCtx, cancel := context.WithCancel(ctx)
 		a.bgCancels = append(a.bgCancels, cancel)
@@ -279,4 +365,53 @@ func (a *Application) startBackgroundWorkers(ctx context.Context) {
 			}
 		}()
 	}
+
+	if a.seasonWorker != nil {
+		bgCtx, cancel := context.WithCancel(ctx)
+		a.bgCancels = append(a.bgCancels, cancel)
+		go func() {
+			if err := a.seasonWorker.Run(bgCtx); err != nil && err != context.Canceled {
+				a.logger.Warn().Err(err).Msg("season worker stopped")
+			}
+		}()
+	}
+
+	if a.statsWorker != nil {
+		bgCtx, cancel := context.WithCancel(ctx)
+		a.bgCancels = append(a.bgCancels, cancel)
+		go func() {
+			if err := a.statsWorker.Run(bgCtx); err != nil && err != context.Canceled {
+				a.logger.Warn().Err(err).Msg("question stats worker stopped")
+			}
+		}()
+	}
+
+	if a.queueMatcher != nil {
+		bgCtx, cancel := context.WithCancel(ctx)
+		a.bgCancels = append(a.bgCancels, cancel)
+		go func() {
+			if err := a.queueMatcher.Run(bgCtx); err != nil && err != context.Canceled {
+				a.logger.Warn().Err(err).Msg("queue matcher stopped")
+			}
+		}()
+	}
+}
+
+// botProfiles applies configured bot accuracies on top of the default bot tuning.
+func botProfiles(cfg config.Bot) map[string]match.BotProfile {
+	profiles := match.DefaultBotProfiles()
+	accuracies := map[string]float64{
+		question.DifficultyEasy:   cfg.AccuracyEasy,
+		question.DifficultyMedium: cfg.AccuracyMedium,
+		question.DifficultyHard:   cfg.AccuracyHard,
+	}
+	for level, accuracy := range accuracies {
+		if accuracy <= 0 || accuracy > 1 {
+			continue
+		}
+		profile := profiles[level]
+		profile.Accuracy = accuracy
+		profiles[level] = profile
+	}
+	return profiles
 }
@@ -322,6 +322,7 @@ func (h *HTTPHandlers) GetMe(w http.ResponseWriter, r *http.Request) {
 		"username":         username,
 		"username_required": usernameRequired,
 		"user_type":        claims.UserType,
+		"role":             claims.Role,
 		"is_guest":         claims.IsGuest,
 	})
 }
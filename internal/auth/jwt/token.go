@@ -14,6 +14,7 @@ type Claims struct {
 	Email    string    `json:"email,omitempty"`
 	Username string    `json:"username"`
 	UserType string    `json:"user_type"`
+	Role     string    `json:"role,omitempty"`
 	IsGuest  bool      `json:"is_guest"`
 	jwt.RegisteredClaims
 }
@@ -68,6 +69,7 @@ type User struct {
 	Email    *string
 	Username string
 	UserType string
+	Role     string
 	IsGuest  bool
 }
 
@@ -79,6 +81,7 @@ func (m *Manager) GenerateAccessToken(user User) (string, error) {
 		Email:    "",
 		Username: user.Username,
 		UserType: user.UserType,
+		Role:     user.Role,
 		IsGuest:  user.IsGuest,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    m.issuer,
@@ -105,6 +108,7 @@ func (m *Manager) GenerateRefreshToken(user User) (string, error) {
 		Email:       "",
 		Username: user.Username,
 		UserType:    user.UserType,
+		Role:        user.Role,
 		IsGuest:     user.IsGuest,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    m.issuer,
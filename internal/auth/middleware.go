@@ -76,3 +76,15 @@ func RequireRegistered(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// RequireAdmin ensures the user has the admin role.
+func RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := r.Context().Value("claims").(*jwt.Claims)
+		if !ok || claims == nil || claims.IsGuest || claims.Role != RoleAdmin {
+			httperrors.RespondForbidden(w, httperrors.ErrCodeForbidden, "Admin role required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
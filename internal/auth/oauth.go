@@ -149,6 +149,7 @@ func (s *OAuthService) CreateOrGetOAuthUser(ctx context.Context, authSvc *Servic
 			Email:       &info.Email,
 			Username:    username,
 			UserType:    dbUser.UserType,
+			Role:        dbUser.Role,
 			IsGuest:     false,
 		}
 
@@ -196,6 +197,7 @@ func (s *OAuthService) CreateOrGetOAuthUser(ctx context.Context, authSvc *Servic
 		Email:       &info.Email,
 		Username:    username,
 		UserType:    "registered",
+		Role:        RolePlayer,
 		IsGuest:     false,
 	}
 
@@ -105,6 +105,7 @@ func (s *Service) Register(ctx context.Context, req RegisterRequest) (*User, *To
 		Email:       &req.Email,
 		Username:    username,
 		UserType:    "registered",
+		Role:        RolePlayer,
 		IsGuest:     false,
 	}
 
@@ -148,6 +149,7 @@ func (s *Service) Login(ctx context.Context, req LoginRequest) (*User, *TokenPai
 		ID:          userID,
 		Username:    username,
 		UserType:    dbUser.UserType,
+		Role:        dbUser.Role,
 		IsGuest:     dbUser.UserType == "guest",
 	}
 
@@ -297,6 +299,7 @@ func (s *Service) ConvertGuest(ctx context.Context, req ConvertGuestRequest) (*U
 		Email:       &req.Email,
 		Username:    username,
 		UserType:    "registered",
+		Role:        RolePlayer,
 		IsGuest:     false,
 	}
 
@@ -347,6 +350,7 @@ func (s *Service) RefreshToken(ctx context.Context, refreshToken string) (*Token
 		ID:          userID,
 		Username:    username,
 		UserType:    dbUser.UserType,
+		Role:        dbUser.Role,
 		IsGuest:     dbUser.UserType == "guest",
 	}
 
@@ -408,6 +412,7 @@ func (s *Service) SetUsername(ctx context.Context, userID uuid.UUID, username st
 		ID:          userIDFromDB,
 		Username:    usernameStr,
 		UserType:    dbUser.UserType,
+		Role:        dbUser.Role,
 		IsGuest:     dbUser.UserType == "guest",
 	}
 	
@@ -611,6 +616,7 @@ func (s *Service) generateTokenPair(user User) (*TokenPair, error) {
 		Email:       user.Email,
 		Username:    user.Username,
 		UserType:    user.UserType,
+		Role:        user.Role,
 		IsGuest:     user.IsGuest,
 	}
 
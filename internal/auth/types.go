@@ -12,9 +12,16 @@ type User struct {
 	Email       *string
 	Username    string
 	UserType    string // "registered" or "guest"
+	Role        string // RolePlayer or RoleAdmin; empty for guests
 	IsGuest     bool
 }
 
+// User roles (users.role).
+const (
+	RolePlayer = "player"
+	RoleAdmin  = "admin"
+)
+
 // TokenPair holds access and refresh tokens.
 type TokenPair struct {
 	AccessToken  string
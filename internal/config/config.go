@@ -15,15 +15,22 @@ type App struct {
 	HTTPAddr                string        `env:"HTTP_ADDR" envDefault:"0.0.0.0:8080"`
 	GracefulShutdownTimeout time.Duration `env:"GRACEFUL_SHUTDOWN_SECONDS" envDefault:"20s"`
 
-	Postgres    Postgres
-	Redis       Redis
-	Security    Security
-	Runtime     Runtime
-	OAuth       OAuth
-	Leaderboard Leaderboard
-	AI          AI
-	SMTP        SMTP
-	CORS        CORS
+	Postgres      Postgres
+	Redis         Redis
+	Security      Security
+	Runtime       Runtime
+	OAuth         OAuth
+	Leaderboard   Leaderboard
+	Moderation    Moderation
+	QuestionStats QuestionStats
+	AntiCheat     AntiCheat
+	Rating        Rating
+	Matchmaking   Matchmaking
+	Seasons       Seasons
+	Bot           Bot
+	AI            AI
+	SMTP          SMTP
+	CORS          CORS
 }
 
 // Postgres captures connection info for the SQL database.
@@ -51,16 +58,68 @@ type Security struct {
 
 // Runtime groups gameplay defaults.
 type Runtime struct {
-	QuestionFetchTimeout   time.Duration `env:"QUESTION_FETCH_TIMEOUT_SECONDS" envDefault:"4s"`
-	DefaultQuestionCount   int           `env:"DEFAULT_QUESTION_COUNT" envDefault:"5"`
-	DefaultQuestionSeconds time.Duration `env:"DEFAULT_PER_QUESTION_SECONDS" envDefault:"15s"`
-	GlobalPaddingSeconds   time.Duration `env:"GLOBAL_TIMEOUT_PADDING_SECONDS" envDefault:"20s"`
+	QuestionFetchTimeout    time.Duration `env:"QUESTION_FETCH_TIMEOUT_SECONDS" envDefault:"4s"`
+	DefaultQuestionCount    int           `env:"DEFAULT_QUESTION_COUNT" envDefault:"5"`
+	DefaultQuestionSeconds  time.Duration `env:"DEFAULT_PER_QUESTION_SECONDS" envDefault:"15s"`
+	GlobalPaddingSeconds    time.Duration `env:"GLOBAL_TIMEOUT_PADDING_SECONDS" envDefault:"20s"`
+	ResumeGrace             time.Duration `env:"RESUME_GRACE_SECONDS" envDefault:"30s"`
+	ShuffleOptionsPerPlayer bool          `env:"SHUFFLE_OPTIONS_PER_PLAYER" envDefault:"false"`
 }
 
-// Leaderboard governs snapshotting and broadcast behavior.
+// Leaderboard governs snapshotting, broadcast behavior and the calendar periods
+// daily, weekly and monthly boards roll over on.
 type Leaderboard struct {
 	SnapshotInterval time.Duration `env:"LEADERBOARD_SNAPSHOT_INTERVAL" envDefault:"5m"`
 	SnapshotTopN     int           `env:"LEADERBOARD_SNAPSHOT_TOP" envDefault:"50"`
+	Timezone         string        `env:"LEADERBOARD_TIMEZONE" envDefault:"UTC"`          // IANA name, e.g. Asia/Kolkata
+	PeriodRetention  time.Duration `env:"LEADERBOARD_PERIOD_RETENTION" envDefault:"168h"` // how long closed periods stay in Redis
+}
+
+// Moderation tunes player question reports.
+type Moderation struct {
+	ReportThreshold int `env:"QUESTION_REPORT_THRESHOLD" envDefault:"3"`
+}
+
+// QuestionStats governs the per-question answer rollup and difficulty calibration.
+type QuestionStats struct {
+	RefreshInterval time.Duration `env:"QUESTION_STATS_INTERVAL" envDefault:"1h"`
+	MinSamples      int           `env:"QUESTION_STATS_MIN_SAMPLES" envDefault:"20"`
+}
+
+// AntiCheat tunes the detectors run over finished matches.
+type AntiCheat struct {
+	MinHumanLatency  time.Duration `env:"ANTICHEAT_MIN_HUMAN_LATENCY" envDefault:"400ms"`
+	PairingThreshold int           `env:"ANTICHEAT_PAIRING_THRESHOLD" envDefault:"5"`
+	PairingWindow    time.Duration `env:"ANTICHEAT_PAIRING_WINDOW" envDefault:"168h"`
+}
+
+// Rating tunes the Glicko-2 skill ratings of registered players.
+type Rating struct {
+	Tau float64 `env:"RATING_TAU" envDefault:"0.5"` // how fast volatility may change
+}
+
+// Matchmaking tunes the rating bands the random queue pairs players within.
+type Matchmaking struct {
+	RatingBand    int `env:"MATCHMAKING_RATING_BAND" envDefault:"100"`           // allowed rating gap on joining
+	BandGrowth    int `env:"MATCHMAKING_BAND_GROWTH_PER_SECOND" envDefault:"20"` // widening per second waited
+	MaxRatingBand int `env:"MATCHMAKING_MAX_RATING_BAND" envDefault:"800"`
+}
+
+// Seasons defines ranked seasons and how ratings soft-reset between them. Each
+// definition is id=start..end with UTC dates, e.g. 2026-q4=2026-10-01..2027-01-01.
+type Seasons struct {
+	Definitions     []string      `env:"SEASONS" envSeparator:"," envDefault:""`
+	RatingCarryOver float64       `env:"SEASON_RATING_CARRY_OVER" envDefault:"0.5"` // share of rating above or below 1500 kept
+	ResetDeviation  float64       `env:"SEASON_RESET_DEVIATION" envDefault:"200"`
+	CheckInterval   time.Duration `env:"SEASON_CHECK_INTERVAL" envDefault:"1m"`
+}
+
+// Bot tunes bot opponents offered to players waiting in the queue.
+type Bot struct {
+	WaitSeconds    int     `env:"BOT_WAIT_SECONDS" envDefault:"10"`
+	AccuracyEasy   float64 `env:"BOT_ACCURACY_EASY" envDefault:"0.55"`
+	AccuracyMedium float64 `env:"BOT_ACCURACY_MEDIUM" envDefault:"0.70"`
+	AccuracyHard   float64 `env:"BOT_ACCURACY_HARD" envDefault:"0.85"`
 }
 
 // OAuth holds OAuth provider configuration.
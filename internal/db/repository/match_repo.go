@@ -16,6 +16,9 @@ type matchStore interface {
 	UpdatePlayerMatchResult(ctx context.Context, arg sqlcgen.UpdatePlayerMatchResultParams) error
 	GetPlayerStatesByMatch(ctx context.Context, matchID pgtype.UUID) ([]sqlcgen.PlayerMatchState, error)
 	GetMatchForSummary(ctx context.Context, matchID pgtype.UUID) (sqlcgen.Match, error)
+	InsertMatchQuestion(ctx context.Context, arg sqlcgen.InsertMatchQuestionParams) error
+	GetMatchQuestionForPlayer(ctx context.Context, arg sqlcgen.GetMatchQuestionForPlayerParams) (sqlcgen.MatchQuestion, error)
+	RevokeLeaderboardEligibility(ctx context.Context, matchID pgtype.UUID) (int64, error)
 }
 
 // MatchRepository contains DB helpers for matches and player states.
@@ -55,9 +58,25 @@ func (r *MatchRepository) ListPlayerStates(ctx context.Context, matchID pgtype.U
 
 // GetSummary fetches match metadata for leaderboard / reporting.
 func (r *MatchRepository) GetSummary(ctx context.Context, matchID uuid.UUID) (sqlcgen.Match, error) {
-	var pgMatchID pgtype.UUID
-	if err := pgMatchID.Scan(matchID); err != nil {
-		return sqlcgen.Match{}, err
+	return r.store.GetMatchForSummary(ctx, pgtype.UUID{Bytes: matchID, Valid: true})
+}
+
+// RecordQuestion stores which question was issued at an order in a match.
+func (r *MatchRepository) RecordQuestion(ctx context.Context, params sqlcgen.InsertMatchQuestionParams) error {
+	return r.store.InsertMatchQuestion(ctx, params)
+}
+
+// FindQuestionForPlayer resolves a question token in a match the user played in.
+func (r *MatchRepository) FindQuestionForPlayer(ctx context.Context, params sqlcgen.GetMatchQuestionForPlayerParams) (sqlcgen.MatchQuestion, error) {
+	return r.store.GetMatchQuestionForPlayer(ctx, params)
+}
+
+// RevokeEligibility marks a match leaderboard-ineligible. It reports false when the
+// match was already ineligible.
+func (r *MatchRepository) RevokeEligibility(ctx context.Context, matchID uuid.UUID) (bool, error) {
+	n, err := r.store.RevokeLeaderboardEligibility(ctx, pgtype.UUID{Bytes: matchID, Valid: true})
+	if err != nil {
+		return false, err
 	}
-	return r.store.GetMatchForSummary(ctx, pgMatchID)
+	return n > 0, nil
 }
@@ -42,6 +42,20 @@ func (m *mockMatchStore) GetMatchForSummary(ctx context.Context, matchID pgtype.
 	return args.Get(0).(sqlcgen.Match), args.Error(1)
 }
 
+func (m *mockMatchStore) InsertMatchQuestion(ctx context.Context, arg sqlcgen.InsertMatchQuestionParams) error {
+	return m.Called(ctx, arg).Error(0)
+}
+
+func (m *mockMatchStore) GetMatchQuestionForPlayer(ctx context.Context, arg sqlcgen.GetMatchQuestionForPlayerParams) (sqlcgen.MatchQuestion, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sqlcgen.MatchQuestion), args.Error(1)
+}
+
+func (m *mockMatchStore) RevokeLeaderboardEligibility(ctx context.Context, matchID pgtype.UUID) (int64, error) {
+	args := m.Called(ctx, matchID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func TestMatchRepository_Create(t *testing.T) {
 	store := new(mockMatchStore)
 	repo := NewMatchRepository(store)
@@ -7,8 +7,9 @@ import (
 )
 
 type questionStore interface {
-	GetQuestionPool(ctx context.Context, limit int32) ([]sqlcgen.Question, error)
+	GetQuestionPool(ctx context.Context, arg sqlcgen.GetQuestionPoolParams) ([]sqlcgen.Question, error)
 	InsertQuestion(ctx context.Context, arg sqlcgen.InsertQuestionParams) (sqlcgen.Question, error)
+	UpsertAIQuestion(ctx context.Context, arg sqlcgen.UpsertAIQuestionParams) (sqlcgen.UpsertAIQuestionRow, error)
 }
 
 // QuestionRepository wraps sqlc queries for curated question access.
@@ -20,12 +21,22 @@ func NewQuestionRepository(store questionStore) *QuestionRepository {
 	return &QuestionRepository{store: store}
 }
 
-// FetchPool retrieves verified questions (no filters - type/difficulty/category removed).
-func (r *QuestionRepository) FetchPool(ctx context.Context, limit int32) ([]sqlcgen.Question, error) {
-	return r.store.GetQuestionPool(ctx, limit)
+// FetchPool retrieves random verified questions of one difficulty; an empty category matches all categories.
+func (r *QuestionRepository) FetchPool(ctx context.Context, category, difficulty string, limit int32) ([]sqlcgen.Question, error) {
+	return r.store.GetQuestionPool(ctx, sqlcgen.GetQuestionPoolParams{
+		Category:   category,
+		Difficulty: difficulty,
+		MaxCount:   limit,
+	})
 }
 
 // Insert stores newly verified questions (e.g., from AI fallback) into Postgres.
 func (r *QuestionRepository) Insert(ctx context.Context, params sqlcgen.InsertQuestionParams) (sqlcgen.Question, error) {
 	return r.store.InsertQuestion(ctx, params)
 }
+
+// UpsertAI stores an AI-generated question keyed by its content hash and returns
+// the stored row (the existing one for a re-generated duplicate).
+func (r *QuestionRepository) UpsertAI(ctx context.Context, params sqlcgen.UpsertAIQuestionParams) (sqlcgen.UpsertAIQuestionRow, error) {
+	return r.store.UpsertAIQuestion(ctx, params)
+}
@@ -11,6 +11,34 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const getPeriodSnapshot = `-- name: GetPeriodSnapshot :one
+SELECT snapshot_id, time_window, generated_at, entries, source_hash, created_at, season_id, period
+FROM leaderboard_snapshots
+WHERE time_window = $1
+  AND period = $2
+`
+
+type GetPeriodSnapshotParams struct {
+	TimeWindow string      `json:"time_window"`
+	Period     pgtype.Text `json:"period"`
+}
+
+func (q *Queries) GetPeriodSnapshot(ctx context.Context, arg GetPeriodSnapshotParams) (LeaderboardSnapshot, error) {
+	row := q.db.QueryRow(ctx, getPeriodSnapshot, arg.TimeWindow, arg.Period)
+	var i LeaderboardSnapshot
+	err := row.Scan(
+		&i.SnapshotID,
+		&i.TimeWindow,
+		&i.GeneratedAt,
+		&i.Entries,
+		&i.SourceHash,
+		&i.CreatedAt,
+		&i.SeasonID,
+		&i.Period,
+	)
+	return i, err
+}
+
 const insertLeaderboardSnapshot = `-- name: InsertLeaderboardSnapshot :one
 INSERT INTO leaderboard_snapshots (
     time_window,
@@ -23,7 +51,7 @@ INSERT INTO leaderboard_snapshots (
     $3,
     $4
 )
-RETURNING snapshot_id, time_window, generated_at, entries, source_hash, created_at
+RETURNING snapshot_id, time_window, generated_at, entries, source_hash, created_at, season_id, period
 `
 
 type InsertLeaderboardSnapshotParams struct {
@@ -48,14 +76,58 @@ func (q *Queries) InsertLeaderboardSnapshot(ctx context.Context, arg InsertLeade
 		&i.Entries,
 		&i.SourceHash,
 		&i.CreatedAt,
+		&i.SeasonID,
+		&i.Period,
 	)
 	return i, err
 }
 
+const insertPeriodSnapshot = `-- name: InsertPeriodSnapshot :execrows
+INSERT INTO leaderboard_snapshots (
+    time_window,
+    generated_at,
+    entries,
+    source_hash,
+    period
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5
+)
+ON CONFLICT (time_window, period) WHERE period IS NOT NULL DO NOTHING
+`
+
+type InsertPeriodSnapshotParams struct {
+	TimeWindow  string             `json:"time_window"`
+	GeneratedAt pgtype.Timestamptz `json:"generated_at"`
+	Entries     []byte             `json:"entries"`
+	SourceHash  string             `json:"source_hash"`
+	Period      pgtype.Text        `json:"period"`
+}
+
+// Affects no row when the period's final standings are already stored.
+func (q *Queries) InsertPeriodSnapshot(ctx context.Context, arg InsertPeriodSnapshotParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertPeriodSnapshot,
+		arg.TimeWindow,
+		arg.GeneratedAt,
+		arg.Entries,
+		arg.SourceHash,
+		arg.Period,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const listRecentSnapshots = `-- name: ListRecentSnapshots :many
-SELECT snapshot_id, time_window, generated_at, entries, source_hash, created_at
+SELECT snapshot_id, time_window, generated_at, entries, source_hash, created_at, season_id, period
 FROM leaderboard_snapshots
 WHERE time_window = $1
+  AND season_id IS NULL
+  AND period IS NULL
 ORDER BY generated_at DESC
 LIMIT $2
 `
@@ -81,6 +153,8 @@ func (q *Queries) ListRecentSnapshots(ctx context.Context, arg ListRecentSnapsho
 			&i.Entries,
 			&i.SourceHash,
 			&i.CreatedAt,
+			&i.SeasonID,
+			&i.Period,
 		); err != nil {
 			return nil, err
 		}
@@ -144,8 +144,34 @@ func (q *Queries) GetMatchForSummary(ctx context.Context, matchID pgtype.UUID) (
 	return i, err
 }
 
+const getMatchQuestionForPlayer = `-- name: GetMatchQuestionForPlayer :one
+SELECT mq.match_id, mq.question_order, mq.question_id
+FROM match_questions mq
+JOIN player_match_state pms ON pms.match_id = mq.match_id AND pms.user_id = $1
+WHERE mq.match_id = $2
+  AND mq.question_order = $3
+`
+
+type GetMatchQuestionForPlayerParams struct {
+	UserID        pgtype.UUID `json:"user_id"`
+	MatchID       pgtype.UUID `json:"match_id"`
+	QuestionOrder int16       `json:"question_order"`
+}
+
+// Resolves a question by order within a match the user played in.
+func (q *Queries) GetMatchQuestionForPlayer(ctx context.Context, arg GetMatchQuestionForPlayerParams) (MatchQuestion, error) {
+	row := q.db.QueryRow(ctx, getMatchQuestionForPlayer, arg.UserID, arg.MatchID, arg.QuestionOrder)
+	var i MatchQuestion
+	err := row.Scan(
+		&i.MatchID,
+		&i.QuestionOrder,
+		&i.QuestionID,
+	)
+	return i, err
+}
+
 const getPlayerStatesByMatch = `-- name: GetPlayerStatesByMatch :many
-SELECT match_id, user_id, is_guest, joined_at, left_at, final_score, status, accuracy, streak_bonus_pct, answers
+SELECT match_id, user_id, is_guest, joined_at, left_at, final_score, status, accuracy, streak_bonus_pct, answers, leave_reason, updated_at
 FROM player_match_state
 WHERE match_id = $1
 `
@@ -170,6 +196,8 @@ func (q *Queries) GetPlayerStatesByMatch(ctx context.Context, matchID pgtype.UUI
 			&i.Accuracy,
 			&i.StreakBonusPct,
 			&i.Answers,
+			&i.LeaveReason,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -181,6 +209,77 @@ func (q *Queries) GetPlayerStatesByMatch(ctx context.Context, matchID pgtype.UUI
 	return items, nil
 }
 
+const insertMatchQuestion = `-- name: InsertMatchQuestion :exec
+INSERT INTO match_questions (
+    match_id,
+    question_order,
+    question_id
+) VALUES (
+    $1,
+    $2,
+    $3
+)
+ON CONFLICT (match_id, question_order) DO NOTHING
+`
+
+type InsertMatchQuestionParams struct {
+	MatchID       pgtype.UUID `json:"match_id"`
+	QuestionOrder int16       `json:"question_order"`
+	QuestionID    pgtype.UUID `json:"question_id"`
+}
+
+func (q *Queries) InsertMatchQuestion(ctx context.Context, arg InsertMatchQuestionParams) error {
+	_, err := q.db.Exec(ctx, insertMatchQuestion, arg.MatchID, arg.QuestionOrder, arg.QuestionID)
+	return err
+}
+
+const listMatchQuestionsByQuestion = `-- name: ListMatchQuestionsByQuestion :many
+SELECT match_id, question_order, question_id
+FROM match_questions
+WHERE question_id = $1
+`
+
+func (q *Queries) ListMatchQuestionsByQuestion(ctx context.Context, questionID pgtype.UUID) ([]MatchQuestion, error) {
+	rows, err := q.db.Query(ctx, listMatchQuestionsByQuestion, questionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MatchQuestion
+	for rows.Next() {
+		var i MatchQuestion
+		if err := rows.Scan(
+			&i.MatchID,
+			&i.QuestionOrder,
+			&i.QuestionID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revokeLeaderboardEligibility = `-- name: RevokeLeaderboardEligibility :execrows
+UPDATE matches
+SET leaderboard_eligible = FALSE,
+    updated_at = NOW()
+WHERE match_id = $1
+  AND leaderboard_eligible
+`
+
+// Affects no row when the match was already ineligible, so results are reversed once.
+func (q *Queries) RevokeLeaderboardEligibility(ctx context.Context, matchID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeLeaderboardEligibility, matchID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateMatchStatus = `-- name: UpdateMatchStatus :exec
 UPDATE matches
 SET status = $1,
@@ -214,10 +313,11 @@ SET final_score = $1,
     accuracy = $3,
     streak_bonus_pct = $4,
     left_at = COALESCE($5, left_at),
-    answers = COALESCE($6, answers),
+    leave_reason = COALESCE($6, leave_reason),
+    answers = COALESCE($7, answers),
     updated_at = NOW()
-WHERE match_id = $7
-  AND user_id = $8
+WHERE match_id = $8
+  AND user_id = $9
 `
 
 type UpdatePlayerMatchResultParams struct {
@@ -226,6 +326,7 @@ type UpdatePlayerMatchResultParams struct {
 	Accuracy       pgtype.Numeric     `json:"accuracy"`
 	StreakBonusPct pgtype.Numeric     `json:"streak_bonus_pct"`
 	LeftAt         pgtype.Timestamptz `json:"left_at"`
+	LeaveReason    pgtype.Text        `json:"leave_reason"`
 	Answers        []byte             `json:"answers"`
 	MatchID        pgtype.UUID        `json:"match_id"`
 	UserID         pgtype.UUID        `json:"user_id"`
@@ -238,6 +339,7 @@ func (q *Queries) UpdatePlayerMatchResult(ctx context.Context, arg UpdatePlayerM
 		arg.Accuracy,
 		arg.StreakBonusPct,
 		arg.LeftAt,
+		arg.LeaveReason,
 		arg.Answers,
 		arg.MatchID,
 		arg.UserID,
@@ -19,6 +19,18 @@ type AuditLog struct {
 	TraceID    pgtype.UUID        `json:"trace_id"`
 }
 
+type CheatFlag struct {
+	FlagID     pgtype.UUID        `json:"flag_id"`
+	MatchID    pgtype.UUID        `json:"match_id"`
+	UserID     pgtype.UUID        `json:"user_id"`
+	Kind       string             `json:"kind"`
+	Details    []byte             `json:"details"`
+	Status     string             `json:"status"`
+	ReviewedBy pgtype.UUID        `json:"reviewed_by"`
+	ReviewedAt pgtype.Timestamptz `json:"reviewed_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
 type LeaderboardSnapshot struct {
 	SnapshotID  pgtype.UUID        `json:"snapshot_id"`
 	TimeWindow  string             `json:"time_window"`
@@ -26,6 +38,8 @@ type LeaderboardSnapshot struct {
 	Entries     []byte             `json:"entries"`
 	SourceHash  string             `json:"source_hash"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	SeasonID    pgtype.Text        `json:"season_id"`
+	Period      pgtype.Text        `json:"period"`
 }
 
 type Match struct {
@@ -45,6 +59,12 @@ type Match struct {
 	Metadata             []byte             `json:"metadata"`
 }
 
+type MatchQuestion struct {
+	MatchID       pgtype.UUID `json:"match_id"`
+	QuestionOrder int16       `json:"question_order"`
+	QuestionID    pgtype.UUID `json:"question_id"`
+}
+
 type PlayerMatchState struct {
 	MatchID        pgtype.UUID        `json:"match_id"`
 	UserID         pgtype.UUID        `json:"user_id"`
@@ -56,6 +76,17 @@ type PlayerMatchState struct {
 	Accuracy       pgtype.Numeric     `json:"accuracy"`
 	StreakBonusPct pgtype.Numeric     `json:"streak_bonus_pct"`
 	Answers        []byte             `json:"answers"`
+	LeaveReason    pgtype.Text        `json:"leave_reason"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+type PlayerRating struct {
+	UserID     pgtype.UUID        `json:"user_id"`
+	Rating     float64            `json:"rating"`
+	Deviation  float64            `json:"deviation"`
+	Volatility float64            `json:"volatility"`
+	Games      int32              `json:"games"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
 }
 
 type Question struct {
@@ -68,6 +99,61 @@ type Question struct {
 	Verified      bool               `json:"verified"`
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	Category      string             `json:"category"`
+	Difficulty    string             `json:"difficulty"`
+	Tags          []string           `json:"tags"`
+	ContentHash   pgtype.Text        `json:"content_hash"`
+	ReviewStatus  string             `json:"review_status"`
+}
+
+type QuestionReport struct {
+	ReportID   pgtype.UUID        `json:"report_id"`
+	QuestionID pgtype.UUID        `json:"question_id"`
+	MatchID    pgtype.UUID        `json:"match_id"`
+	ReporterID pgtype.UUID        `json:"reporter_id"`
+	Reason     string             `json:"reason"`
+	Details    string             `json:"details"`
+	Status     string             `json:"status"`
+	ResolvedBy pgtype.UUID        `json:"resolved_by"`
+	ResolvedAt pgtype.Timestamptz `json:"resolved_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type QuestionStat struct {
+	QuestionID          pgtype.UUID        `json:"question_id"`
+	Exposures           int32              `json:"exposures"`
+	Answered            int32              `json:"answered"`
+	Correct             int32              `json:"correct"`
+	AvgResponseMs       int32              `json:"avg_response_ms"`
+	OptionPicks         []byte             `json:"option_picks"`
+	EmpiricalDifficulty pgtype.Text        `json:"empirical_difficulty"`
+	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
+}
+
+type RatingHistory struct {
+	HistoryID       int64              `json:"history_id"`
+	UserID          pgtype.UUID        `json:"user_id"`
+	MatchID         pgtype.UUID        `json:"match_id"`
+	OpponentID      pgtype.UUID        `json:"opponent_id"`
+	Outcome         float64            `json:"outcome"`
+	RatingBefore    float64            `json:"rating_before"`
+	RatingAfter     float64            `json:"rating_after"`
+	DeviationBefore float64            `json:"deviation_before"`
+	DeviationAfter  float64            `json:"deviation_after"`
+	VolatilityAfter float64            `json:"volatility_after"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	VoidedAt        pgtype.Timestamptz `json:"voided_at"`
+}
+
+type Season struct {
+	SeasonID   string             `json:"season_id"`
+	Name       string             `json:"name"`
+	StartsAt   pgtype.Timestamptz `json:"starts_at"`
+	EndsAt     pgtype.Timestamptz `json:"ends_at"`
+	Status     string             `json:"status"`
+	ArchivedAt pgtype.Timestamptz `json:"archived_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	ResetAt    pgtype.Timestamptz `json:"reset_at"`
 }
 
 type User struct {
@@ -81,4 +167,5 @@ type User struct {
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
 	LastLoginAt  pgtype.Timestamptz `json:"last_login_at"`
 	Metadata     []byte             `json:"metadata"`
+	Role         string             `json:"role"`
 }
@@ -11,27 +11,108 @@ import (
 )
 
 type Querier interface {
+	// Moves scheduled seasons that have started to active and returns them.
+	ActivateDueSeasons(ctx context.Context, now pgtype.Timestamptz) ([]Season, error)
+	// Archives an active season and soft-resets every rating in the same statement, so
+	// ratings are reset exactly once: with the claim, or not at all. Ratings are pulled
+	// toward the starting 1500, keeping carry_over of the distance, and deviations are
+	// widened to at least min_deviation. claimed is 0 when the season was already archived.
+	ArchiveSeason(ctx context.Context, arg ArchiveSeasonParams) (ArchiveSeasonRow, error)
+	CountOpenReportsForQuestion(ctx context.Context, questionID pgtype.UUID) (int64, error)
+	CountOverlappingSeasons(ctx context.Context, arg CountOverlappingSeasonsParams) (int64, error)
+	// Leaderboard-eligible random matches both players finished since the given time.
+	CountRecentPairings(ctx context.Context, arg CountRecentPairingsParams) (int64, error)
 	CreateMatch(ctx context.Context, arg CreateMatchParams) (Match, error)
 	CreatePlayerMatchState(ctx context.Context, arg CreatePlayerMatchStateParams) error
+	// Returns no row when a season with the same ID already exists.
+	CreateSeason(ctx context.Context, arg CreateSeasonParams) (Season, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	// Gives an unrated player a row at the starting rating, so it can be locked.
+	EnsurePlayerRating(ctx context.Context, arg EnsurePlayerRatingParams) error
+	GetCheatFlag(ctx context.Context, flagID pgtype.UUID) (CheatFlag, error)
 	GetMatchForSummary(ctx context.Context, matchID pgtype.UUID) (Match, error)
+	// Resolves a question by order within a match the user played in.
+	GetMatchQuestionForPlayer(ctx context.Context, arg GetMatchQuestionForPlayerParams) (MatchQuestion, error)
+	GetPeriodSnapshot(ctx context.Context, arg GetPeriodSnapshotParams) (LeaderboardSnapshot, error)
+	// Average accuracy over the player's most recent finished matches, excluding one match.
+	GetPlayerAccuracyHistory(ctx context.Context, arg GetPlayerAccuracyHistoryParams) (GetPlayerAccuracyHistoryRow, error)
+	GetPlayerRating(ctx context.Context, userID pgtype.UUID) (PlayerRating, error)
 	GetPlayerStatesByMatch(ctx context.Context, matchID pgtype.UUID) ([]PlayerMatchState, error)
-	GetQuestionPool(ctx context.Context, limit int32) ([]Question, error)
+	GetQuestionByID(ctx context.Context, questionID pgtype.UUID) (Question, error)
+	// An empty category matches every category. Difficulty matches the empirical
+	// difficulty once one has been calibrated, the labelled difficulty until then.
+	GetQuestionPool(ctx context.Context, arg GetQuestionPoolParams) ([]Question, error)
+	GetSeason(ctx context.Context, seasonID string) (Season, error)
 	GetUserByEmail(ctx context.Context, email pgtype.Text) (User, error)
 	GetUserByID(ctx context.Context, userID pgtype.UUID) (User, error)
 	GetUserByUsername(ctx context.Context, username pgtype.Text) (User, error)
 	InsertAuditLog(ctx context.Context, arg InsertAuditLogParams) error
+	// Affects no row when the player was already flagged for this kind in the match.
+	InsertCheatFlag(ctx context.Context, arg InsertCheatFlagParams) (int64, error)
 	InsertLeaderboardSnapshot(ctx context.Context, arg InsertLeaderboardSnapshotParams) (LeaderboardSnapshot, error)
+	InsertMatchQuestion(ctx context.Context, arg InsertMatchQuestionParams) error
+	// Affects no row when the period's final standings are already stored.
+	InsertPeriodSnapshot(ctx context.Context, arg InsertPeriodSnapshotParams) (int64, error)
 	InsertQuestion(ctx context.Context, arg InsertQuestionParams) (Question, error)
+	// Returns no row when the reporter already reported this question.
+	InsertQuestionReport(ctx context.Context, arg InsertQuestionReportParams) (QuestionReport, error)
+	// Affects no row when the player was already rated for this match.
+	InsertRatingHistory(ctx context.Context, arg InsertRatingHistoryParams) (int64, error)
+	// Affects no row when the season's standings for this board are already stored.
+	InsertSeasonStandings(ctx context.Context, arg InsertSeasonStandingsParams) (int64, error)
 	ListAuditLogsForEntity(ctx context.Context, arg ListAuditLogsForEntityParams) ([]AuditLog, error)
+	// Flags in the given status, newest first.
+	ListCheatFlags(ctx context.Context, arg ListCheatFlagsParams) ([]CheatFlag, error)
+	// Active seasons whose end has passed, and archived seasons whose boards were not
+	// reset yet, oldest first.
+	ListEndedSeasons(ctx context.Context, now pgtype.Timestamptz) ([]Season, error)
+	ListMatchQuestionsByQuestion(ctx context.Context, questionID pgtype.UUID) ([]MatchQuestion, error)
+	ListPlayerRatings(ctx context.Context) ([]ListPlayerRatingsRow, error)
+	ListQuestionPrompts(ctx context.Context) ([]string, error)
+	// Stats joined with question content for questions with at least min_exposures.
+	ListQuestionStats(ctx context.Context, minExposures int32) ([]ListQuestionStatsRow, error)
+	ListQuestionsForExport(ctx context.Context, arg ListQuestionsForExportParams) ([]Question, error)
+	// Empty filters match everything.
+	ListQuestionsForReview(ctx context.Context, arg ListQuestionsForReviewParams) ([]Question, error)
 	ListRecentSnapshots(ctx context.Context, arg ListRecentSnapshotsParams) ([]LeaderboardSnapshot, error)
+	// Questions with reports in the given status, most reported first.
+	ListReportedQuestions(ctx context.Context, arg ListReportedQuestionsParams) ([]ListReportedQuestionsRow, error)
+	ListReportsForQuestion(ctx context.Context, questionID pgtype.UUID) ([]QuestionReport, error)
+	ListSeasonStandings(ctx context.Context, seasonID pgtype.Text) ([]LeaderboardSnapshot, error)
+	ListSeasons(ctx context.Context) ([]Season, error)
+	// Holds the player's rating row until the transaction ends, so concurrent
+	// matches rate them one after the other.
+	LockPlayerRating(ctx context.Context, userID pgtype.UUID) (PlayerRating, error)
+	// Records that an archived season's Redis boards have been reset.
+	MarkSeasonReset(ctx context.Context, seasonID string) error
 	PromoteGuestToRegistered(ctx context.Context, arg PromoteGuestToRegisteredParams) (User, error)
+	// Recomputes every question's stats from the answers of finished matches, bots
+	// excluded. Response time is the recorded latency, or for older answers the time
+	// since the question's window opened.
+	RefreshQuestionStats(ctx context.Context, arg RefreshQuestionStatsParams) (int64, error)
+	ReviewCheatFlag(ctx context.Context, arg ReviewCheatFlagParams) (CheatFlag, error)
+	ResolveQuestionReports(ctx context.Context, arg ResolveQuestionReportsParams) (int64, error)
+	// Affects no row when the match was already ineligible, so results are reversed once.
+	RevokeLeaderboardEligibility(ctx context.Context, matchID pgtype.UUID) (int64, error)
 	UpdateMatchStatus(ctx context.Context, arg UpdateMatchStatusParams) error
 	UpdatePassword(ctx context.Context, arg UpdatePasswordParams) error
 	UpdatePlayerMatchResult(ctx context.Context, arg UpdatePlayerMatchResultParams) error
+	UpdateQuestionContent(ctx context.Context, arg UpdateQuestionContentParams) (Question, error)
 	UpdateUserLogin(ctx context.Context, userID pgtype.UUID) error
 	UpdateUsername(ctx context.Context, arg UpdateUsernameParams) (User, error)
+	// Stores an AI-generated question as unverified, or touches the existing row with
+	// the same content hash. Either way the stored question is returned, so a
+	// re-generated duplicate is served as moderation left it.
+	UpsertAIQuestion(ctx context.Context, arg UpsertAIQuestionParams) (UpsertAIQuestionRow, error)
+	UpsertPlayerRating(ctx context.Context, arg UpsertPlayerRatingParams) error
 	UpsertQuestionVerification(ctx context.Context, arg UpsertQuestionVerificationParams) (Question, error)
+	// Voids a match's rating changes and takes them back off the players: the rating
+	// won or lost is reversed and the deviation widened back to what it was before the
+	// match, keeping any games played since. A match rated before the last season
+	// rollover is only marked void, since the soft reset already shrank what it moved.
+	// Returns no rows when the match was not rated, was already voided or is from an
+	// earlier season.
+	VoidMatchRatings(ctx context.Context, matchID pgtype.UUID) ([]VoidMatchRatingsRow, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -11,16 +11,55 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const getQuestionPool = `-- name: GetQuestionPool :many
-SELECT question_id, source, prompt, options, correct_answer, metadata, verified, created_at, updated_at
+const getQuestionByID = `-- name: GetQuestionByID :one
+SELECT question_id, source, prompt, options, correct_answer, metadata, verified, created_at, updated_at, category, difficulty, tags, content_hash, review_status
 FROM questions
-WHERE verified = true
+WHERE question_id = $1
+`
+
+func (q *Queries) GetQuestionByID(ctx context.Context, questionID pgtype.UUID) (Question, error) {
+	row := q.db.QueryRow(ctx, getQuestionByID, questionID)
+	var i Question
+	err := row.Scan(
+		&i.QuestionID,
+		&i.Source,
+		&i.Prompt,
+		&i.Options,
+		&i.CorrectAnswer,
+		&i.Metadata,
+		&i.Verified,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Category,
+		&i.Difficulty,
+		&i.Tags,
+		&i.ContentHash,
+		&i.ReviewStatus,
+	)
+	return i, err
+}
+
+const getQuestionPool = `-- name: GetQuestionPool :many
+SELECT q.question_id, q.source, q.prompt, q.options, q.correct_answer, q.metadata, q.verified, q.created_at, q.updated_at, q.category, q.difficulty, q.tags, q.content_hash, q.review_status
+FROM questions q
+LEFT JOIN question_stats s ON s.question_id = q.question_id
+WHERE q.verified = true
+  AND ($1::text = '' OR q.category = $1::text)
+  AND COALESCE(s.empirical_difficulty, q.difficulty) = $2::text
 ORDER BY RANDOM()
-LIMIT $1
+LIMIT $3
 `
 
-func (q *Queries) GetQuestionPool(ctx context.Context, limit int32) ([]Question, error) {
-	rows, err := q.db.Query(ctx, getQuestionPool, limit)
+type GetQuestionPoolParams struct {
+	Category   string `json:"category"`
+	Difficulty string `json:"difficulty"`
+	MaxCount   int32  `json:"max_count"`
+}
+
+// An empty category matches every category. Difficulty matches the empirical
+// difficulty once one has been calibrated, the labelled difficulty until then.
+func (q *Queries) GetQuestionPool(ctx context.Context, arg GetQuestionPoolParams) ([]Question, error) {
+	rows, err := q.db.Query(ctx, getQuestionPool, arg.Category, arg.Difficulty, arg.MaxCount)
 	if err != nil {
 		return nil, err
 	}
@@ -38,6 +77,11 @@ func (q *Queries) GetQuestionPool(ctx context.Context, limit int32) ([]Question,
 			&i.Verified,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Category,
+			&i.Difficulty,
+			&i.Tags,
+			&i.ContentHash,
+			&i.ReviewStatus,
 		); err != nil {
 			return nil, err
 		}
@@ -56,16 +100,26 @@ INSERT INTO questions (
     options,
     correct_answer,
     metadata,
-    verified
+    verified,
+    review_status,
+    category,
+    difficulty,
+    tags,
+    content_hash
 ) VALUES (
     $1,
     $2,
     $3,
     $4,
     COALESCE($5, '{}'::jsonb),
-    $6
+    $6,
+    CASE WHEN $6::bool THEN 'approved' ELSE 'pending' END,
+    $7,
+    $8,
+    COALESCE($9::text[], '{}'),
+    $10
 )
-RETURNING question_id, source, prompt, options, correct_answer, metadata, verified, created_at, updated_at
+RETURNING question_id, source, prompt, options, correct_answer, metadata, verified, created_at, updated_at, category, difficulty, tags, content_hash, review_status
 `
 
 type InsertQuestionParams struct {
@@ -75,6 +129,10 @@ type InsertQuestionParams struct {
 	CorrectAnswer string      `json:"correct_answer"`
 	Metadata      interface{} `json:"metadata"`
 	Verified      bool        `json:"verified"`
+	Category      string      `json:"category"`
+	Difficulty    string      `json:"difficulty"`
+	Tags          []string    `json:"tags"`
+	ContentHash   pgtype.Text `json:"content_hash"`
 }
 
 func (q *Queries) InsertQuestion(ctx context.Context, arg InsertQuestionParams) (Question, error) {
@@ -85,6 +143,10 @@ func (q *Queries) InsertQuestion(ctx context.Context, arg InsertQuestionParams)
 		arg.CorrectAnswer,
 		arg.Metadata,
 		arg.Verified,
+		arg.Category,
+		arg.Difficulty,
+		arg.Tags,
+		arg.ContentHash,
 	)
 	var i Question
 	err := row.Scan(
@@ -97,6 +159,265 @@ func (q *Queries) InsertQuestion(ctx context.Context, arg InsertQuestionParams)
 		&i.Verified,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Category,
+		&i.Difficulty,
+		&i.Tags,
+		&i.ContentHash,
+		&i.ReviewStatus,
+	)
+	return i, err
+}
+
+const listQuestionPrompts = `-- name: ListQuestionPrompts :many
+SELECT prompt
+FROM questions
+`
+
+func (q *Queries) ListQuestionPrompts(ctx context.Context) ([]string, error) {
+	rows, err := q.db.Query(ctx, listQuestionPrompts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var prompt string
+		if err := rows.Scan(&prompt); err != nil {
+			return nil, err
+		}
+		items = append(items, prompt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listQuestionsForExport = `-- name: ListQuestionsForExport :many
+SELECT question_id, source, prompt, options, correct_answer, metadata, verified, created_at, updated_at, category, difficulty, tags, content_hash, review_status
+FROM questions
+WHERE ($1::text = '' OR category = $1::text)
+  AND (verified OR $2::bool)
+ORDER BY created_at, question_id
+`
+
+type ListQuestionsForExportParams struct {
+	Category          string `json:"category"`
+	IncludeUnverified bool   `json:"include_unverified"`
+}
+
+func (q *Queries) ListQuestionsForExport(ctx context.Context, arg ListQuestionsForExportParams) ([]Question, error) {
+	rows, err := q.db.Query(ctx, listQuestionsForExport, arg.Category, arg.IncludeUnverified)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Question
+	for rows.Next() {
+		var i Question
+		if err := rows.Scan(
+			&i.QuestionID,
+			&i.Source,
+			&i.Prompt,
+			&i.Options,
+			&i.CorrectAnswer,
+			&i.Metadata,
+			&i.Verified,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Category,
+			&i.Difficulty,
+			&i.Tags,
+			&i.ContentHash,
+			&i.ReviewStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listQuestionsForReview = `-- name: ListQuestionsForReview :many
+SELECT question_id, source, prompt, options, correct_answer, metadata, verified, created_at, updated_at, category, difficulty, tags, content_hash, review_status
+FROM questions
+WHERE review_status = $1
+  AND ($2::text = '' OR source = $2::text)
+  AND ($3::text = '' OR category = $3::text)
+  AND ($4::text = '' OR difficulty = $4::text)
+ORDER BY created_at, question_id
+LIMIT $5 OFFSET $6
+`
+
+type ListQuestionsForReviewParams struct {
+	ReviewStatus string `json:"review_status"`
+	Source       string `json:"source"`
+	Category     string `json:"category"`
+	Difficulty   string `json:"difficulty"`
+	MaxCount     int32  `json:"max_count"`
+	Skip         int32  `json:"skip"`
+}
+
+// Empty filters match everything.
+func (q *Queries) ListQuestionsForReview(ctx context.Context, arg ListQuestionsForReviewParams) ([]Question, error) {
+	rows, err := q.db.Query(ctx, listQuestionsForReview,
+		arg.ReviewStatus,
+		arg.Source,
+		arg.Category,
+		arg.Difficulty,
+		arg.MaxCount,
+		arg.Skip,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Question
+	for rows.Next() {
+		var i Question
+		if err := rows.Scan(
+			&i.QuestionID,
+			&i.Source,
+			&i.Prompt,
+			&i.Options,
+			&i.CorrectAnswer,
+			&i.Metadata,
+			&i.Verified,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Category,
+			&i.Difficulty,
+			&i.Tags,
+			&i.ContentHash,
+			&i.ReviewStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateQuestionContent = `-- name: UpdateQuestionContent :one
+UPDATE questions
+SET prompt = $1,
+    options = $2,
+    correct_answer = $3,
+    category = $4,
+    difficulty = $5,
+    tags = COALESCE($6::text[], '{}'),
+    updated_at = NOW()
+WHERE question_id = $7
+RETURNING question_id, source, prompt, options, correct_answer, metadata, verified, created_at, updated_at, category, difficulty, tags, content_hash, review_status
+`
+
+type UpdateQuestionContentParams struct {
+	Prompt        string      `json:"prompt"`
+	Options       []string    `json:"options"`
+	CorrectAnswer string      `json:"correct_answer"`
+	Category      string      `json:"category"`
+	Difficulty    string      `json:"difficulty"`
+	Tags          []string    `json:"tags"`
+	QuestionID    pgtype.UUID `json:"question_id"`
+}
+
+func (q *Queries) UpdateQuestionContent(ctx context.Context, arg UpdateQuestionContentParams) (Question, error) {
+	row := q.db.QueryRow(ctx, updateQuestionContent,
+		arg.Prompt,
+		arg.Options,
+		arg.CorrectAnswer,
+		arg.Category,
+		arg.Difficulty,
+		arg.Tags,
+		arg.QuestionID,
+	)
+	var i Question
+	err := row.Scan(
+		&i.QuestionID,
+		&i.Source,
+		&i.Prompt,
+		&i.Options,
+		&i.CorrectAnswer,
+		&i.Metadata,
+		&i.Verified,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Category,
+		&i.Difficulty,
+		&i.Tags,
+		&i.ContentHash,
+		&i.ReviewStatus,
+	)
+	return i, err
+}
+
+const upsertAIQuestion = `-- name: UpsertAIQuestion :one
+INSERT INTO questions (
+    source,
+    prompt,
+    options,
+    correct_answer,
+    verified,
+    category,
+    difficulty,
+    content_hash
+) VALUES (
+    'ai',
+    $1,
+    $2,
+    $3,
+    false,
+    $4,
+    $5,
+    $6
+)
+ON CONFLICT (content_hash) WHERE content_hash IS NOT NULL
+DO UPDATE SET updated_at = NOW()
+RETURNING question_id, prompt, options, correct_answer, review_status
+`
+
+type UpsertAIQuestionParams struct {
+	Prompt        string      `json:"prompt"`
+	Options       []string    `json:"options"`
+	CorrectAnswer string      `json:"correct_answer"`
+	Category      string      `json:"category"`
+	Difficulty    string      `json:"difficulty"`
+	ContentHash   pgtype.Text `json:"content_hash"`
+}
+
+type UpsertAIQuestionRow struct {
+	QuestionID    pgtype.UUID `json:"question_id"`
+	Prompt        string      `json:"prompt"`
+	Options       []string    `json:"options"`
+	CorrectAnswer string      `json:"correct_answer"`
+	ReviewStatus  string      `json:"review_status"`
+}
+
+// Stores an AI-generated question as unverified, or touches the existing row with
+// the same content hash. Either way the stored question is returned, so a
+// re-generated duplicate is served as moderation left it.
+func (q *Queries) UpsertAIQuestion(ctx context.Context, arg UpsertAIQuestionParams) (UpsertAIQuestionRow, error) {
+	row := q.db.QueryRow(ctx, upsertAIQuestion,
+		arg.Prompt,
+		arg.Options,
+		arg.CorrectAnswer,
+		arg.Category,
+		arg.Difficulty,
+		arg.ContentHash,
+	)
+	var i UpsertAIQuestionRow
+	err := row.Scan(
+		&i.QuestionID,
+		&i.Prompt,
+		&i.Options,
+		&i.CorrectAnswer,
+		&i.ReviewStatus,
 	)
 	return i, err
 }
@@ -104,20 +425,27 @@ func (q *Queries) InsertQuestion(ctx context.Context, arg InsertQuestionParams)
 const upsertQuestionVerification = `-- name: UpsertQuestionVerification :one
 UPDATE questions
 SET verified = $1,
-    metadata = COALESCE($2, metadata),
+    review_status = $2,
+    metadata = COALESCE($3, metadata),
     updated_at = NOW()
-WHERE question_id = $3
-RETURNING question_id, source, prompt, options, correct_answer, metadata, verified, created_at, updated_at
+WHERE question_id = $4
+RETURNING question_id, source, prompt, options, correct_answer, metadata, verified, created_at, updated_at, category, difficulty, tags, content_hash, review_status
 `
 
 type UpsertQuestionVerificationParams struct {
-	Verified   bool        `json:"verified"`
-	Metadata   []byte      `json:"metadata"`
-	QuestionID pgtype.UUID `json:"question_id"`
+	Verified     bool        `json:"verified"`
+	ReviewStatus string      `json:"review_status"`
+	Metadata     []byte      `json:"metadata"`
+	QuestionID   pgtype.UUID `json:"question_id"`
 }
 
 func (q *Queries) UpsertQuestionVerification(ctx context.Context, arg UpsertQuestionVerificationParams) (Question, error) {
-	row := q.db.QueryRow(ctx, upsertQuestionVerification, arg.Verified, arg.Metadata, arg.QuestionID)
+	row := q.db.QueryRow(ctx, upsertQuestionVerification,
+		arg.Verified,
+		arg.ReviewStatus,
+		arg.Metadata,
+		arg.QuestionID,
+	)
 	var i Question
 	err := row.Scan(
 		&i.QuestionID,
@@ -129,6 +457,11 @@ func (q *Queries) UpsertQuestionVerification(ctx context.Context, arg UpsertQues
 		&i.Verified,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Category,
+		&i.Difficulty,
+		&i.Tags,
+		&i.ContentHash,
+		&i.ReviewStatus,
 	)
 	return i, err
 }
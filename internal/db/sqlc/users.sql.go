@@ -25,7 +25,7 @@ INSERT INTO users (
     $4,
     COALESCE($5, '{}'::jsonb)
 )
-RETURNING user_id, email, password_hash, username, user_type, status, created_at, updated_at, last_login_at, metadata
+RETURNING user_id, email, password_hash, username, user_type, status, created_at, updated_at, last_login_at, metadata, role
 `
 
 type CreateUserParams struct {
@@ -56,12 +56,13 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.UpdatedAt,
 		&i.LastLoginAt,
 		&i.Metadata,
+		&i.Role,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT user_id, email, password_hash, username, user_type, status, created_at, updated_at, last_login_at, metadata FROM users
+SELECT user_id, email, password_hash, username, user_type, status, created_at, updated_at, last_login_at, metadata, role FROM users
 WHERE email = $1
 `
 
@@ -79,12 +80,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email pgtype.Text) (User,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
 		&i.Metadata,
+		&i.Role,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT user_id, email, password_hash, username, user_type, status, created_at, updated_at, last_login_at, metadata FROM users
+SELECT user_id, email, password_hash, username, user_type, status, created_at, updated_at, last_login_at, metadata, role FROM users
 WHERE user_id = $1
 `
 
@@ -102,12 +104,13 @@ func (q *Queries) GetUserByID(ctx context.Context, userID pgtype.UUID) (User, er
 		&i.UpdatedAt,
 		&i.LastLoginAt,
 		&i.Metadata,
+		&i.Role,
 	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT user_id, email, password_hash, username, user_type, status, created_at, updated_at, last_login_at, metadata FROM users
+SELECT user_id, email, password_hash, username, user_type, status, created_at, updated_at, last_login_at, metadata, role FROM users
 WHERE username = $1
 `
 
@@ -125,6 +128,7 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username pgtype.Text) (
 		&i.UpdatedAt,
 		&i.LastLoginAt,
 		&i.Metadata,
+		&i.Role,
 	)
 	return i, err
 }
@@ -137,7 +141,7 @@ SET email = $1,
     user_type = 'registered',
     updated_at = NOW()
 WHERE user_id = $4
-RETURNING user_id, email, password_hash, username, user_type, status, created_at, updated_at, last_login_at, metadata
+RETURNING user_id, email, password_hash, username, user_type, status, created_at, updated_at, last_login_at, metadata, role
 `
 
 type PromoteGuestToRegisteredParams struct {
@@ -166,6 +170,7 @@ func (q *Queries) PromoteGuestToRegistered(ctx context.Context, arg PromoteGuest
 		&i.UpdatedAt,
 		&i.LastLoginAt,
 		&i.Metadata,
+		&i.Role,
 	)
 	return i, err
 }
@@ -204,7 +209,7 @@ UPDATE users
 SET username = $1,
     updated_at = NOW()
 WHERE user_id = $2 AND username IS NULL
-RETURNING user_id, email, password_hash, username, user_type, status, created_at, updated_at, last_login_at, metadata
+RETURNING user_id, email, password_hash, username, user_type, status, created_at, updated_at, last_login_at, metadata, role
 `
 
 type UpdateUsernameParams struct {
@@ -226,6 +231,7 @@ func (q *Queries) UpdateUsername(ctx context.Context, arg UpdateUsernameParams)
 		&i.UpdatedAt,
 		&i.LastLoginAt,
 		&i.Metadata,
+		&i.Role,
 	)
 	return i, err
 }
@@ -3,10 +3,15 @@ package leaderboard
 import ws "github.com/gokatarajesh/quiz-platform/pkg/http/ws"
 
 func toWSEntries(entries []Entry) []ws.LeaderboardEntry {
+	return rankedEntries(entries, 1)
+}
+
+// rankedEntries converts consecutive board entries, the first of which is ranked firstRank.
+func rankedEntries(entries []Entry, firstRank int) []ws.LeaderboardEntry {
 	result := make([]ws.LeaderboardEntry, len(entries))
 	for i, e := range entries {
 		result[i] = ws.LeaderboardEntry{
-			Rank:     i + 1,
+			Rank:     firstRank + i,
 			UserID:   e.UserID.String(),
 			Username: e.Username,
 			Score:    e.Score,
@@ -17,3 +22,14 @@ func toWSEntries(entries []Entry) []ws.LeaderboardEntry {
 	}
 	return result
 }
+
+// withoutWindow returns windows minus window, leaving the input untouched.
+func withoutWindow(windows []string, window string) []string {
+	result := make([]string, 0, len(windows))
+	for _, w := range windows {
+		if w != window {
+			result = append(result, w)
+		}
+	}
+	return result
+}
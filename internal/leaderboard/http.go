@@ -3,13 +3,17 @@ package leaderboard
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/rs/zerolog"
 
+	"github.com/gokatarajesh/quiz-platform/internal/auth/jwt"
 	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
 	ws "github.com/gokatarajesh/quiz-platform/pkg/http/ws"
 	httperrors "github.com/gokatarajesh/quiz-platform/pkg/http/errors"
@@ -19,6 +23,8 @@ import (
 type HTTPHandler struct {
 	svc     *Service
 	queries *sqlcgen.Queries
+	seasons *SeasonService
+	me      http.Handler
 	logger  zerolog.Logger
 }
 
@@ -31,10 +37,26 @@ func NewHTTPHandler(svc *Service, queries *sqlcgen.Queries, logger zerolog.Logge
 	}
 }
 
-// HandleGet responds with the current leaderboard for a given window or private room.
-// Routes: GET /v1/leaderboards/{window}?limit=10
+// UseSeasons enables the season endpoints.
+func (h *HTTPHandler) UseSeasons(seasons *SeasonService) {
+	h.seasons = seasons
+}
+
+// UseAuth enables GET /v1/leaderboards/{window}/me, wrapping it in requireAuth so
+// the caller's claims are in the request context.
+func (h *HTTPHandler) UseAuth(requireAuth func(http.Handler) http.Handler) {
+	h.me = requireAuth(http.HandlerFunc(h.HandleGetMe))
+}
+
+// HandleGet responds with the current leaderboard for a given window, private room or season.
+// Daily, weekly and monthly boards take an optional closed period, e.g.
+// ?period=2026-10-15, ?period=2026-W41 or ?period=2026-09.
+// Routes: GET /v1/leaderboards/{window}?limit=10&period=
 //
+//	GET /v1/leaderboards/{window}/me?neighbours=2
 //	GET /v1/leaderboards/private/{room_code}?limit=10
+//	GET /v1/leaderboards/seasons
+//	GET /v1/leaderboards/seasons/{id}?limit=10
 func (h *HTTPHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		httperrors.RespondError(w, http.StatusMethodNotAllowed, httperrors.ErrCodeInvalidRequest, "Method not allowed")
@@ -49,6 +71,18 @@ func (h *HTTPHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
 		h.HandleGetPrivateRoom(w, r)
 		return
 	}
+	if path == "seasons" || strings.HasPrefix(path, "seasons/") {
+		h.HandleGetSeason(w, r)
+		return
+	}
+	if strings.HasSuffix(path, "/me") {
+		if h.me == nil {
+			httperrors.RespondUnauthorized(w, httperrors.ErrCodeAuthenticationRequired, "Authentication required")
+			return
+		}
+		h.me.ServeHTTP(w, r)
+		return
+	}
 
 	// Otherwise, treat as window-based leaderboard
 	window := path
@@ -64,6 +98,17 @@ func (h *HTTPHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	period := r.URL.Query().Get("period")
+	current := period == ""
+	if !current {
+		if err := checkPeriod(window, period); err != nil {
+			httperrors.RespondValidationError(w, httperrors.ErrCodeValidationFailed, "Invalid period for this leaderboard window", "period")
+			return
+		}
+	} else if h.svc != nil {
+		period = h.svc.CurrentPeriod(window)
+	}
+
 	ctx := r.Context()
 	var (
 		top    []ws.LeaderboardEntry
@@ -71,16 +116,20 @@ func (h *HTTPHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
 	)
 
 	if h.svc != nil {
-		if entries, err := h.svc.Top(ctx, window, limit); err == nil {
+		if entries, err := h.svc.TopForPeriod(ctx, window, period, limit); err == nil {
 			top = toWSEntries(entries)
 		} else {
-			h.logger.Warn().Err(err).Str("window", window).Msg("redis leaderboard fetch failed")
+			h.logger.Warn().Err(err).Str("window", window).Str("period", period).Msg("redis leaderboard fetch failed")
 		}
 	}
 
 	if len(top) == 0 {
 		source = "snapshot"
-		top = h.snapshotFallback(ctx, window, limit)
+		if current {
+			top = h.snapshotFallback(ctx, window, period, limit)
+		} else {
+			top = h.periodSnapshot(ctx, window, period, limit)
+		}
 	}
 
 	resp := map[string]interface{}{
@@ -89,11 +138,34 @@ func (h *HTTPHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
 		"source":      source,
 		"retrievedAt": time.Now().UTC().Format(time.RFC3339),
 	}
+	if period != "" {
+		resp["period"] = period
+	}
 
 	writeJSON(w, resp)
 }
 
-func (h *HTTPHandler) snapshotFallback(ctx context.Context, window string, limit int) []ws.LeaderboardEntry {
+// periodSnapshot returns the stored final standings of a closed period.
+func (h *HTTPHandler) periodSnapshot(ctx context.Context, window, period string, limit int) []ws.LeaderboardEntry {
+	if h.queries == nil {
+		return nil
+	}
+	row, err := h.queries.GetPeriodSnapshot(ctx, sqlcgen.GetPeriodSnapshotParams{
+		TimeWindow: window,
+		Period:     pgtype.Text{String: period, Valid: true},
+	})
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			h.logger.Warn().Err(err).Str("window", window).Str("period", period).Msg("period snapshot fetch failed")
+		}
+		return nil
+	}
+	return h.decodeSnapshot(row.Entries, limit)
+}
+
+// snapshotFallback returns the latest rolling snapshot of a window, as long as it
+// was taken in the current period.
+func (h *HTTPHandler) snapshotFallback(ctx context.Context, window, period string, limit int) []ws.LeaderboardEntry {
 	if h.queries == nil {
 		return nil
 	}
@@ -107,9 +179,15 @@ func (h *HTTPHandler) snapshotFallback(ctx context.Context, window string, limit
 		}
 		return nil
 	}
+	if h.svc != nil && h.svc.periodAt(window, rows[0].GeneratedAt.Time) != period {
+		return nil
+	}
+	return h.decodeSnapshot(rows[0].Entries, limit)
+}
 
+func (h *HTTPHandler) decodeSnapshot(data []byte, limit int) []ws.LeaderboardEntry {
 	var entries []ws.LeaderboardEntry
-	if err := json.Unmarshal(rows[0].Entries, &entries); err != nil {
+	if err := json.Unmarshal(data, &entries); err != nil {
 		h.logger.Warn().Err(err).Msg("snapshot payload decode failed")
 		return nil
 	}
@@ -119,9 +197,68 @@ func (h *HTTPHandler) snapshotFallback(ctx context.Context, window string, limit
 	return entries
 }
 
+// HandleGetMe responds with the caller's rank and score on a window's current
+// board, with the players just above and below them.
+// Route: GET /v1/leaderboards/{window}/me?neighbours=2
+func (h *HTTPHandler) HandleGetMe(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httperrors.RespondError(w, http.StatusMethodNotAllowed, httperrors.ErrCodeInvalidRequest, "Method not allowed")
+		return
+	}
+
+	claims, ok := r.Context().Value("claims").(*jwt.Claims)
+	if !ok || claims == nil {
+		httperrors.RespondUnauthorized(w, httperrors.ErrCodeAuthenticationRequired, "Authentication required")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/leaderboards/")
+	window := strings.TrimSuffix(strings.TrimSuffix(path, "/"), "/me")
+	if !isValidWindow(window) {
+		httperrors.RespondNotFound(w, httperrors.ErrCodeUnknownWindow, "Unknown leaderboard window")
+		return
+	}
+
+	neighbours := 2
+	if raw := r.URL.Query().Get("neighbours"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed >= 0 && parsed <= 10 {
+			neighbours = parsed
+		}
+	}
+
+	if h.svc == nil {
+		httperrors.RespondInternalError(w, "Failed to fetch leaderboard")
+		return
+	}
+	pos, err := h.svc.RankOf(r.Context(), claims.UserID, window, neighbours)
+	if errors.Is(err, ErrNotRanked) {
+		httperrors.RespondNotFound(w, httperrors.ErrCodeNotRanked, "You are not ranked on this leaderboard yet")
+		return
+	}
+	if err != nil {
+		h.logger.Warn().Err(err).Str("window", window).Str("user_id", claims.UserID.String()).Msg("leaderboard rank fetch failed")
+		httperrors.RespondError(w, http.StatusInternalServerError, httperrors.ErrCodeLeaderboardFetchFailed, "Failed to fetch leaderboard rank")
+		return
+	}
+
+	resp := map[string]interface{}{
+		"window":      window,
+		"rank":        pos.Rank,
+		"score":       pos.Score,
+		"above":       pos.Above,
+		"below":       pos.Below,
+		"retrievedAt": time.Now().UTC().Format(time.RFC3339),
+	}
+	if period := h.svc.CurrentPeriod(window); period != "" {
+		resp["period"] = period
+	}
+
+	writeJSON(w, resp)
+}
+
 func isValidWindow(window string) bool {
 	switch window {
-	case WindowDaily, WindowWeekly, WindowMonthly, WindowAllTime:
+	case WindowDaily, WindowWeekly, WindowMonthly, WindowAllTime, WindowRating, WindowSeason:
 		return true
 	default:
 		return false
@@ -0,0 +1,53 @@
+package leaderboard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gokatarajesh/quiz-platform/internal/auth/jwt"
+)
+
+func TestHandleGetMeRequiresAuth(t *testing.T) {
+	h := NewHTTPHandler(nil, nil, zerolog.Nop())
+
+	// Without UseAuth the route is never served anonymously
+	rec := httptest.NewRecorder()
+	h.HandleGet(rec, httptest.NewRequest(http.MethodGet, "/v1/leaderboards/weekly/me", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	var wrapped bool
+	h.UseAuth(func(next http.Handler) http.Handler {
+		wrapped = true
+		return next
+	})
+	assert.True(t, wrapped)
+
+	rec = httptest.NewRecorder()
+	h.HandleGet(rec, httptest.NewRequest(http.MethodGet, "/v1/leaderboards/weekly/me", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/leaderboards/yearly/me", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "claims", &jwt.Claims{UserID: uuid.New()}))
+	rec = httptest.NewRecorder()
+	h.HandleGet(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRankedEntries(t *testing.T) {
+	entries := []Entry{
+		{UserID: uuid.New(), Username: "ana", Score: 900},
+		{UserID: uuid.New(), Username: "bo", Score: 850},
+	}
+
+	ranked := rankedEntries(entries, 41)
+	assert.Equal(t, 41, ranked[0].Rank)
+	assert.Equal(t, 42, ranked[1].Rank)
+	assert.Equal(t, "bo", ranked[1].Username)
+	assert.Equal(t, 1, toWSEntries(entries)[0].Rank)
+}
@@ -0,0 +1,128 @@
+package leaderboard
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Daily, weekly and monthly boards roll over on calendar boundaries in the
+// configured timezone. Each period has its own board, keyed by the period:
+// lb:daily:2026-10-16, lb:weekly:2026-W42 (ISO week), lb:monthly:2026-10.
+
+const (
+	dayLayout   = "2006-01-02"
+	monthLayout = "2006-01"
+)
+
+// ErrInvalidPeriod is returned for a period that is malformed or does not belong
+// to the window.
+var ErrInvalidPeriod = errors.New("invalid leaderboard period")
+
+// periodicWindows are the windows kept per calendar period.
+var periodicWindows = []string{WindowDaily, WindowWeekly, WindowMonthly}
+
+func isPeriodic(window string) bool {
+	switch window {
+	case WindowDaily, WindowWeekly, WindowMonthly:
+		return true
+	default:
+		return false
+	}
+}
+
+// periodOf returns the period of window that contains t, in t's location.
+// Windows that never roll over have no period ("").
+func periodOf(window string, t time.Time) string {
+	switch window {
+	case WindowDaily:
+		return t.Format(dayLayout)
+	case WindowWeekly:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	case WindowMonthly:
+		return t.Format(monthLayout)
+	default:
+		return ""
+	}
+}
+
+// periodBounds returns when a period of window starts and ends in loc.
+func periodBounds(window, period string, loc *time.Location) (time.Time, time.Time, error) {
+	var start, end time.Time
+	switch window {
+	case WindowDaily:
+		day, err := time.ParseInLocation(dayLayout, period, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidPeriod
+		}
+		start, end = day, day.AddDate(0, 0, 1)
+	case WindowWeekly:
+		var year, week int
+		if _, err := fmt.Sscanf(period, "%4d-W%2d", &year, &week); err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidPeriod
+		}
+		// ISO week 1 is the week with January 4th in it; weeks start on Monday
+		jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+		monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+		start = monday.AddDate(0, 0, (week-1)*7)
+		end = start.AddDate(0, 0, 7)
+	case WindowMonthly:
+		month, err := time.ParseInLocation(monthLayout, period, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidPeriod
+		}
+		start, end = month, month.AddDate(0, 1, 0)
+	default:
+		return time.Time{}, time.Time{}, ErrInvalidPeriod
+	}
+
+	// Rejects non-canonical spellings and weeks past the end of the year
+	if periodOf(window, start) != period {
+		return time.Time{}, time.Time{}, ErrInvalidPeriod
+	}
+	return start, end, nil
+}
+
+// checkPeriod reports whether period can be read from window. Windows that never
+// roll over only have the "" period.
+func checkPeriod(window, period string) error {
+	if !isPeriodic(window) {
+		if period != "" {
+			return ErrInvalidPeriod
+		}
+		return nil
+	}
+	_, _, err := periodBounds(window, period, time.UTC)
+	return err
+}
+
+// closedPeriods returns the periods of window that ended at or before now but whose
+// boards are still retained, oldest first.
+func closedPeriods(window string, now time.Time, retention time.Duration) []string {
+	if !isPeriodic(window) {
+		return nil
+	}
+
+	var periods []string
+	start, _, err := periodBounds(window, periodOf(window, now), now.Location())
+	if err != nil {
+		return nil
+	}
+	for {
+		// start is where the previous period ended
+		if !start.Add(retention).After(now) {
+			break
+		}
+		previous := periodOf(window, start.Add(-time.Nanosecond))
+		periods = append(periods, previous)
+		if start, _, err = periodBounds(window, previous, now.Location()); err != nil {
+			break
+		}
+	}
+
+	for i, j := 0, len(periods)-1; i < j; i, j = i+1, j-1 {
+		periods[i], periods[j] = periods[j], periods[i]
+	}
+	return periods
+}
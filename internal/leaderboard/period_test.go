@@ -0,0 +1,72 @@
+package leaderboard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeriodOf(t *testing.T) {
+	at := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	assert.Equal(t, "2026-10-16", periodOf(WindowDaily, at))
+	assert.Equal(t, "2026-W42", periodOf(WindowWeekly, at))
+	assert.Equal(t, "2026-10", periodOf(WindowMonthly, at))
+	assert.Equal(t, "", periodOf(WindowAllTime, at))
+
+	// Periods follow the configured timezone, not UTC
+	kolkata := time.FixedZone("IST", 5*60*60+30*60)
+	lateUTC := time.Date(2026, 10, 15, 20, 0, 0, 0, time.UTC)
+	assert.Equal(t, "2026-10-15", periodOf(WindowDaily, lateUTC))
+	assert.Equal(t, "2026-10-16", periodOf(WindowDaily, lateUTC.In(kolkata)))
+
+	// ISO weeks belong to the year their Thursday is in
+	assert.Equal(t, "2026-W01", periodOf(WindowWeekly, time.Date(2025, 12, 29, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestPeriodBounds(t *testing.T) {
+	start, end, err := periodBounds(WindowWeekly, "2026-W42", time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), end)
+
+	start, end, err = periodBounds(WindowWeekly, "2026-W01", time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 12, 29, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), end)
+
+	start, end, err = periodBounds(WindowMonthly, "2026-12", time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), end)
+
+	_, _, err = periodBounds(WindowWeekly, "2020-W53", time.UTC)
+	assert.NoError(t, err)
+	for window, period := range map[string]string{
+		WindowDaily:   "2026-10-32",
+		WindowWeekly:  "2025-W53",
+		WindowMonthly: "2026-10-01",
+		WindowAllTime: "2026",
+	} {
+		_, _, err := periodBounds(window, period, time.UTC)
+		assert.ErrorIs(t, err, ErrInvalidPeriod, window)
+	}
+	assert.ErrorIs(t, checkPeriod(WindowWeekly, "2026-W7"), ErrInvalidPeriod)
+	assert.ErrorIs(t, checkPeriod(WindowDaily, ""), ErrInvalidPeriod)
+	assert.NoError(t, checkPeriod(WindowAllTime, ""))
+	assert.ErrorIs(t, checkPeriod(WindowAllTime, "2026-10-16"), ErrInvalidPeriod)
+}
+
+func TestClosedPeriods(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, []string{"2026-10-13", "2026-10-14", "2026-10-15"}, closedPeriods(WindowDaily, now, 3*24*time.Hour))
+	assert.Equal(t, []string{"2026-W41"}, closedPeriods(WindowWeekly, now, 7*24*time.Hour))
+	assert.Equal(t, []string{"2026-09"}, closedPeriods(WindowMonthly, now, 30*24*time.Hour))
+	assert.Empty(t, closedPeriods(WindowAllTime, now, 30*24*time.Hour))
+
+	// A period is kept for the full retention after it ends
+	assert.Equal(t, []string{"2026-10-15"}, closedPeriods(WindowDaily, now, 9*time.Hour+31*time.Minute))
+	assert.Empty(t, closedPeriods(WindowDaily, now, 9*time.Hour+30*time.Minute))
+}
@@ -0,0 +1,456 @@
+package leaderboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+
+	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
+	ws "github.com/gokatarajesh/quiz-platform/pkg/http/ws"
+)
+
+// Season statuses.
+const (
+	SeasonScheduled = "scheduled"
+	SeasonActive    = "active"
+	SeasonArchived  = "archived"
+)
+
+// Where season standings are read from.
+const (
+	StandingsLive    = "redis"
+	StandingsArchive = "archive"
+)
+
+var (
+	ErrSeasonNotFound = errors.New("season not found")
+	ErrSeasonExists   = errors.New("season already exists")
+	ErrSeasonOverlap  = errors.New("season overlaps another season")
+	ErrInvalidSeason  = errors.New("invalid season")
+)
+
+// seasonBoards are the boards archived as a season's final standings.
+var seasonBoards = []string{WindowSeason, WindowRating}
+
+var seasonIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// Season is a named competitive season. Seasons never overlap.
+type Season struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	StartsAt   time.Time  `json:"starts_at"`
+	EndsAt     time.Time  `json:"ends_at"`
+	Status     string     `json:"status"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+}
+
+// Standings are a season's point and rating boards: live while the season runs,
+// the archived final standings once it has ended, and empty before it starts.
+type Standings struct {
+	Season    Season                `json:"season"`
+	Source    string                `json:"source,omitempty"`
+	Top       []ws.LeaderboardEntry `json:"top"`
+	RatingTop []ws.LeaderboardEntry `json:"rating_top"`
+}
+
+// SeasonStore is the subset of sqlc queries the season service needs.
+type SeasonStore interface {
+	ActivateDueSeasons(ctx context.Context, now pgtype.Timestamptz) ([]sqlcgen.Season, error)
+	ArchiveSeason(ctx context.Context, arg sqlcgen.ArchiveSeasonParams) (sqlcgen.ArchiveSeasonRow, error)
+	CountOverlappingSeasons(ctx context.Context, arg sqlcgen.CountOverlappingSeasonsParams) (int64, error)
+	CreateSeason(ctx context.Context, arg sqlcgen.CreateSeasonParams) (sqlcgen.Season, error)
+	GetSeason(ctx context.Context, seasonID string) (sqlcgen.Season, error)
+	InsertSeasonStandings(ctx context.Context, arg sqlcgen.InsertSeasonStandingsParams) (int64, error)
+	ListEndedSeasons(ctx context.Context, now pgtype.Timestamptz) ([]sqlcgen.Season, error)
+	ListPlayerRatings(ctx context.Context) ([]sqlcgen.ListPlayerRatingsRow, error)
+	ListSeasonStandings(ctx context.Context, seasonID pgtype.Text) ([]sqlcgen.LeaderboardSnapshot, error)
+	ListSeasons(ctx context.Context) ([]sqlcgen.Season, error)
+	MarkSeasonReset(ctx context.Context, seasonID string) error
+}
+
+// SeasonBoard is the part of the leaderboard service seasons read and reset.
+type SeasonBoard interface {
+	Top(ctx context.Context, window string, limit int) ([]Entry, error)
+	SnapshotTop(ctx context.Context, window string) ([]Entry, error)
+	ClearWindow(ctx context.Context, window string) error
+	SetRatings(ctx context.Context, ratings map[uuid.UUID]int) error
+}
+
+// SeasonOptions configures season rollover.
+type SeasonOptions struct {
+	// RatingCarryOver is the share of a rating's distance from the starting rating
+	// kept at rollover: 0 resets everyone, 1 keeps ratings as they are (default 0.5).
+	RatingCarryOver float64
+	// ResetDeviation is the deviation every rating is widened to at least, so the
+	// new season's first games move ratings quickly (default 200).
+	ResetDeviation float64
+}
+
+// SeasonService schedules competitive seasons, archives their final standings and
+// soft-resets ratings and the season board when one ends.
+type SeasonService struct {
+	store          SeasonStore
+	board          SeasonBoard
+	carryOver      float64
+	resetDeviation float64
+	logger         zerolog.Logger
+}
+
+// NewSeasonService creates a season service.
+func NewSeasonService(store SeasonStore, board SeasonBoard, opts SeasonOptions, logger zerolog.Logger) *SeasonService {
+	carryOver := opts.RatingCarryOver
+	if carryOver < 0 || carryOver > 1 {
+		carryOver = 0.5
+	}
+	resetDeviation := opts.ResetDeviation
+	if resetDeviation <= 0 {
+		resetDeviation = 200
+	}
+
+	return &SeasonService{
+		store:          store,
+		board:          board,
+		carryOver:      carryOver,
+		resetDeviation: resetDeviation,
+		logger:         logger.With().Str("component", "seasons").Logger(),
+	}
+}
+
+// ParseSeason parses a season definition of the form "id=2026-10-01..2027-01-01".
+// Dates are UTC midnights; the season ends as the end date begins.
+func ParseSeason(def string) (Season, error) {
+	id, span, ok := strings.Cut(strings.TrimSpace(def), "=")
+	if !ok {
+		return Season{}, fmt.Errorf("%w: %q: want id=start..end", ErrInvalidSeason, def)
+	}
+	start, end, ok := strings.Cut(span, "..")
+	if !ok {
+		return Season{}, fmt.Errorf("%w: %q: want id=start..end", ErrInvalidSeason, def)
+	}
+	startsAt, err := time.Parse(time.DateOnly, strings.TrimSpace(start))
+	if err != nil {
+		return Season{}, fmt.Errorf("%w: %q: bad start date", ErrInvalidSeason, def)
+	}
+	endsAt, err := time.Parse(time.DateOnly, strings.TrimSpace(end))
+	if err != nil {
+		return Season{}, fmt.Errorf("%w: %q: bad end date", ErrInvalidSeason, def)
+	}
+
+	season := Season{ID: strings.TrimSpace(id), StartsAt: startsAt, EndsAt: endsAt}
+	if err := validateSeason(season); err != nil {
+		return Season{}, err
+	}
+	return season, nil
+}
+
+// ParseSeasons parses configured season definitions, skipping blank ones.
+func ParseSeasons(defs []string) ([]Season, error) {
+	seasons := make([]Season, 0, len(defs))
+	for _, def := range defs {
+		if strings.TrimSpace(def) == "" {
+			continue
+		}
+		season, err := ParseSeason(def)
+		if err != nil {
+			return nil, err
+		}
+		seasons = append(seasons, season)
+	}
+	return seasons, nil
+}
+
+func validateSeason(season Season) error {
+	if !seasonIDPattern.MatchString(season.ID) {
+		return fmt.Errorf("%w: id must be lowercase letters, digits, '-' or '_'", ErrInvalidSeason)
+	}
+	if !season.EndsAt.After(season.StartsAt) {
+		return fmt.Errorf("%w: season %s must end after it starts", ErrInvalidSeason, season.ID)
+	}
+	return nil
+}
+
+// Create schedules a season. The name defaults to the ID. Seasons that have
+// already ended or overlap an existing season are rejected.
+func (s *SeasonService) Create(ctx context.Context, season Season) (Season, error) {
+	season.ID = strings.TrimSpace(season.ID)
+	season.Name = strings.TrimSpace(season.Name)
+	if season.Name == "" {
+		season.Name = season.ID
+	}
+	if err := validateSeason(season); err != nil {
+		return Season{}, err
+	}
+	if _, err := s.store.GetSeason(ctx, season.ID); err == nil {
+		return Season{}, ErrSeasonExists
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return Season{}, fmt.Errorf("load season: %w", err)
+	}
+	if !season.EndsAt.After(time.Now()) {
+		return Season{}, fmt.Errorf("%w: season %s has already ended", ErrInvalidSeason, season.ID)
+	}
+
+	startsAt := pgtype.Timestamptz{Time: season.StartsAt.UTC(), Valid: true}
+	endsAt := pgtype.Timestamptz{Time: season.EndsAt.UTC(), Valid: true}
+	overlaps, err := s.store.CountOverlappingSeasons(ctx, sqlcgen.CountOverlappingSeasonsParams{
+		EndsAt:   endsAt,
+		StartsAt: startsAt,
+	})
+	if err != nil {
+		return Season{}, fmt.Errorf("check season overlap: %w", err)
+	}
+	if overlaps > 0 {
+		return Season{}, ErrSeasonOverlap
+	}
+
+	row, err := s.store.CreateSeason(ctx, sqlcgen.CreateSeasonParams{
+		SeasonID: season.ID,
+		Name:     season.Name,
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Season{}, ErrSeasonExists
+	}
+	if err != nil {
+		return Season{}, fmt.Errorf("create season: %w", err)
+	}
+
+	s.logger.Info().
+		Str("season_id", row.SeasonID).
+		Time("starts_at", row.StartsAt.Time).
+		Time("ends_at", row.EndsAt.Time).
+		Msg("season scheduled")
+	return seasonFromRow(row), nil
+}
+
+// Seed schedules configured seasons that do not exist yet. Seasons already
+// stored, or that have ended, are left alone.
+func (s *SeasonService) Seed(ctx context.Context, seasons []Season) {
+	for _, season := range seasons {
+		_, err := s.Create(ctx, season)
+		switch {
+		case err == nil, errors.Is(err, ErrSeasonExists):
+		case errors.Is(err, ErrInvalidSeason), errors.Is(err, ErrSeasonOverlap):
+			s.logger.Warn().Err(err).Str("season_id", season.ID).Msg("configured season skipped")
+		default:
+			s.logger.Error().Err(err).Str("season_id", season.ID).Msg("failed to seed season")
+		}
+	}
+}
+
+// List returns every season, latest first.
+func (s *SeasonService) List(ctx context.Context) ([]Season, error) {
+	rows, err := s.store.ListSeasons(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list seasons: %w", err)
+	}
+	seasons := make([]Season, 0, len(rows))
+	for _, row := range rows {
+		seasons = append(seasons, seasonFromRow(row))
+	}
+	return seasons, nil
+}
+
+// Current returns the running season, or nil between seasons.
+func (s *SeasonService) Current(ctx context.Context) (*Season, error) {
+	rows, err := s.store.ListSeasons(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list seasons: %w", err)
+	}
+	for _, row := range rows {
+		if row.Status == SeasonActive {
+			season := seasonFromRow(row)
+			return &season, nil
+		}
+	}
+	return nil, nil
+}
+
+// Get returns one season.
+func (s *SeasonService) Get(ctx context.Context, id string) (Season, error) {
+	row, err := s.store.GetSeason(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Season{}, ErrSeasonNotFound
+	}
+	if err != nil {
+		return Season{}, fmt.Errorf("load season: %w", err)
+	}
+	return seasonFromRow(row), nil
+}
+
+// Standings returns up to limit entries of a season's point and rating boards.
+func (s *SeasonService) Standings(ctx context.Context, id string, limit int) (Standings, error) {
+	season, err := s.Get(ctx, id)
+	if err != nil {
+		return Standings{}, err
+	}
+	standings := Standings{
+		Season:    season,
+		Top:       []ws.LeaderboardEntry{},
+		RatingTop: []ws.LeaderboardEntry{},
+	}
+
+	switch season.Status {
+	case SeasonActive:
+		standings.Source = StandingsLive
+		top, err := s.board.Top(ctx, WindowSeason, limit)
+		if err != nil {
+			return Standings{}, err
+		}
+		ratingTop, err := s.board.Top(ctx, WindowRating, limit)
+		if err != nil {
+			return Standings{}, err
+		}
+		standings.Top, standings.RatingTop = toWSEntries(top), toWSEntries(ratingTop)
+
+	case SeasonArchived:
+		standings.Source = StandingsArchive
+		rows, err := s.store.ListSeasonStandings(ctx, pgtype.Text{String: id, Valid: true})
+		if err != nil {
+			return Standings{}, fmt.Errorf("load season standings: %w", err)
+		}
+		for _, row := range rows {
+			var entries []ws.LeaderboardEntry
+			if err := json.Unmarshal(row.Entries, &entries); err != nil {
+				return Standings{}, fmt.Errorf("decode season standings: %w", err)
+			}
+			if len(entries) > limit {
+				entries = entries[:limit]
+			}
+			switch row.TimeWindow {
+			case WindowSeason:
+				standings.Top = entries
+			case WindowRating:
+				standings.RatingTop = entries
+			}
+		}
+	}
+	return standings, nil
+}
+
+// Advance rolls over seasons that have ended by now and starts those that are due.
+func (s *SeasonService) Advance(ctx context.Context, now time.Time) error {
+	ended, err := s.store.ListEndedSeasons(ctx, pgtype.Timestamptz{Time: now, Valid: true})
+	if err != nil {
+		return fmt.Errorf("list ended seasons: %w", err)
+	}
+	for _, row := range ended {
+		if err := s.rollover(ctx, row, now); err != nil {
+			return fmt.Errorf("roll over season %s: %w", row.SeasonID, err)
+		}
+	}
+
+	started, err := s.store.ActivateDueSeasons(ctx, pgtype.Timestamptz{Time: now, Valid: true})
+	if err != nil {
+		return fmt.Errorf("activate seasons: %w", err)
+	}
+	for _, row := range started {
+		// Points scored between seasons do not count toward the new one
+		if err := s.board.ClearWindow(ctx, WindowSeason); err != nil {
+			return fmt.Errorf("start season %s: %w", row.SeasonID, err)
+		}
+		s.logger.Info().Str("season_id", row.SeasonID).Msg("season started")
+	}
+	return nil
+}
+
+// rollover archives a season's final standings, then soft-resets ratings and clears
+// the season board. The standings are stored before the season is claimed, so they
+// survive a failed rollover. The claim soft-resets the stored ratings with it, so
+// only one instance resets them; the Redis boards are reset after and retried until
+// they are marked done.
+func (s *SeasonService) rollover(ctx context.Context, row sqlcgen.Season, now time.Time) error {
+	if row.Status == SeasonArchived {
+		return s.resetBoards(ctx, row.SeasonID)
+	}
+
+	for _, window := range seasonBoards {
+		entries, err := s.board.SnapshotTop(ctx, window)
+		if err != nil {
+			return fmt.Errorf("read %s board: %w", window, err)
+		}
+		data, sourceHash, err := encodeSnapshot(entries)
+		if err != nil {
+			return err
+		}
+		if _, err := s.store.InsertSeasonStandings(ctx, sqlcgen.InsertSeasonStandingsParams{
+			TimeWindow:  window,
+			GeneratedAt: pgtype.Timestamptz{Time: now, Valid: true},
+			Entries:     data,
+			SourceHash:  sourceHash,
+			SeasonID:    pgtype.Text{String: row.SeasonID, Valid: true},
+		}); err != nil {
+			return fmt.Errorf("archive %s standings: %w", window, err)
+		}
+	}
+
+	claim, err := s.store.ArchiveSeason(ctx, sqlcgen.ArchiveSeasonParams{
+		SeasonID:     row.SeasonID,
+		CarryOver:    s.carryOver,
+		MinDeviation: s.resetDeviation,
+	})
+	if err != nil {
+		return fmt.Errorf("archive season: %w", err)
+	}
+	if claim.Claimed == 0 {
+		return nil
+	}
+	s.logger.Info().
+		Str("season_id", row.SeasonID).
+		Int64("ratings_reset", claim.RatingsReset).
+		Float64("carry_over", s.carryOver).
+		Msg("season archived")
+
+	return s.resetBoards(ctx, row.SeasonID)
+}
+
+// resetBoards brings the rating board in line with the soft-reset ratings and
+// clears the season board, then marks the season reset. Every step can be
+// repeated, so an archived season is retried until this succeeds.
+func (s *SeasonService) resetBoards(ctx context.Context, seasonID string) error {
+	rows, err := s.store.ListPlayerRatings(ctx)
+	if err != nil {
+		return fmt.Errorf("list ratings: %w", err)
+	}
+	ratings := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		ratings[row.UserID.Bytes] = int(math.Round(row.Rating))
+	}
+	if err := s.board.SetRatings(ctx, ratings); err != nil {
+		return err
+	}
+	if err := s.board.ClearWindow(ctx, WindowSeason); err != nil {
+		return err
+	}
+	if err := s.store.MarkSeasonReset(ctx, seasonID); err != nil {
+		return fmt.Errorf("mark season reset: %w", err)
+	}
+
+	s.logger.Info().Str("season_id", seasonID).Int("ratings", len(ratings)).Msg("season boards reset")
+	return nil
+}
+
+func seasonFromRow(row sqlcgen.Season) Season {
+	season := Season{
+		ID:       row.SeasonID,
+		Name:     row.Name,
+		StartsAt: row.StartsAt.Time,
+		EndsAt:   row.EndsAt.Time,
+		Status:   row.Status,
+	}
+	if row.ArchivedAt.Valid {
+		archivedAt := row.ArchivedAt.Time
+		season.ArchivedAt = &archivedAt
+	}
+	return season
+}
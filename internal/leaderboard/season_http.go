@@ -0,0 +1,160 @@
+package leaderboard
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	httperrors "github.com/gokatarajesh/quiz-platform/pkg/http/errors"
+)
+
+// HandleGetSeason lists seasons or responds with one season's standings: live
+// while it runs, archived once it has ended.
+// Routes: GET /v1/leaderboards/seasons
+//
+//	GET /v1/leaderboards/seasons/{id}?limit=10
+func (h *HTTPHandler) HandleGetSeason(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httperrors.RespondError(w, http.StatusMethodNotAllowed, httperrors.ErrCodeInvalidRequest, "Method not allowed")
+		return
+	}
+	if h.seasons == nil {
+		httperrors.RespondNotFound(w, httperrors.ErrCodeUnknownWindow, "Unknown leaderboard window")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/leaderboards/seasons")
+	id := strings.Trim(path, "/")
+	ctx := r.Context()
+
+	if id == "" {
+		seasons, err := h.seasons.List(ctx)
+		if err != nil {
+			h.logger.Warn().Err(err).Msg("season list failed")
+			httperrors.RespondError(w, http.StatusInternalServerError, httperrors.ErrCodeLeaderboardFetchFailed, "Failed to fetch seasons")
+			return
+		}
+		writeJSON(w, map[string]interface{}{
+			"seasons": seasons,
+			"count":   len(seasons),
+		})
+		return
+	}
+
+	limit := 10
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	standings, err := h.seasons.Standings(ctx, id, limit)
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrSeasonNotFound):
+		httperrors.RespondNotFound(w, httperrors.ErrCodeSeasonNotFound, "Season not found")
+		return
+	default:
+		h.logger.Warn().Err(err).Str("season_id", id).Msg("season standings fetch failed")
+		httperrors.RespondError(w, http.StatusInternalServerError, httperrors.ErrCodeLeaderboardFetchFailed, "Failed to fetch season standings")
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"season":      standings.Season,
+		"top":         standings.Top,
+		"rating_top":  standings.RatingTop,
+		"source":      standings.Source,
+		"retrievedAt": time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// SeasonAdminHandlers provides the admin endpoints for scheduling seasons. They
+// expect the auth and admin middleware to have run.
+type SeasonAdminHandlers struct {
+	seasons *SeasonService
+	logger  zerolog.Logger
+}
+
+// NewSeasonAdminHandlers creates HTTP handlers for season administration.
+func NewSeasonAdminHandlers(seasons *SeasonService, logger zerolog.Logger) *SeasonAdminHandlers {
+	return &SeasonAdminHandlers{
+		seasons: seasons,
+		logger:  logger.With().Str("component", "season_admin_http").Logger(),
+	}
+}
+
+// Register mounts the season admin endpoints on mux.
+func (h *SeasonAdminHandlers) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/v1/admin/seasons", h.Seasons)
+}
+
+// createSeasonRequest is the body of POST /v1/admin/seasons.
+type createSeasonRequest struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+// Seasons handles GET and POST /v1/admin/seasons
+func (h *SeasonAdminHandlers) Seasons(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		seasons, err := h.seasons.List(r.Context())
+		if err != nil {
+			h.logger.Error().Err(err).Msg("failed to list seasons")
+			httperrors.RespondError(w, http.StatusInternalServerError, httperrors.ErrCodeSeasonFailed, "Failed to list seasons")
+			return
+		}
+		h.respondJSON(w, http.StatusOK, map[string]interface{}{
+			"seasons": seasons,
+			"count":   len(seasons),
+		})
+	case http.MethodPost:
+		h.create(w, r)
+	default:
+		httperrors.RespondError(w, http.StatusMethodNotAllowed, httperrors.ErrCodeInvalidRequest, "Method not allowed")
+	}
+}
+
+func (h *SeasonAdminHandlers) create(w http.ResponseWriter, r *http.Request) {
+	var req createSeasonRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperrors.RespondBadRequest(w, httperrors.ErrCodeInvalidRequest, "Invalid JSON payload")
+		return
+	}
+
+	season, err := h.seasons.Create(r.Context(), Season{
+		ID:       req.ID,
+		Name:     req.Name,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+	})
+	switch {
+	case err == nil:
+		h.respondJSON(w, http.StatusCreated, season)
+	case errors.Is(err, ErrInvalidSeason):
+		httperrors.RespondBadRequest(w, httperrors.ErrCodeInvalidSeason, err.Error())
+	case errors.Is(err, ErrSeasonExists):
+		httperrors.RespondError(w, http.StatusConflict, httperrors.ErrCodeSeasonExists, "Season already exists")
+	case errors.Is(err, ErrSeasonOverlap):
+		httperrors.RespondError(w, http.StatusConflict, httperrors.ErrCodeSeasonOverlap, "Season overlaps another season")
+	default:
+		h.logger.Error().Err(err).Str("season_id", req.ID).Msg("season creation failed")
+		httperrors.RespondError(w, http.StatusInternalServerError, httperrors.ErrCodeSeasonFailed, "Season creation failed")
+	}
+}
+
+func (h *SeasonAdminHandlers) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error().Err(err).Msg("failed to encode JSON response")
+	}
+}
@@ -0,0 +1,386 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
+)
+
+// fakeSeasonStore keeps seasons and archived standings in memory.
+type fakeSeasonStore struct {
+	seasons   map[string]sqlcgen.Season
+	standings []sqlcgen.InsertSeasonStandingsParams
+	resets    []sqlcgen.ArchiveSeasonParams
+	ratings   []sqlcgen.ListPlayerRatingsRow
+}
+
+func newFakeSeasonStore() *fakeSeasonStore {
+	return &fakeSeasonStore{seasons: map[string]sqlcgen.Season{}}
+}
+
+func (s *fakeSeasonStore) ActivateDueSeasons(ctx context.Context, now pgtype.Timestamptz) ([]sqlcgen.Season, error) {
+	var started []sqlcgen.Season
+	for id, row := range s.seasons {
+		if row.Status == SeasonScheduled && !row.StartsAt.Time.After(now.Time) {
+			row.Status = SeasonActive
+			s.seasons[id] = row
+			started = append(started, row)
+		}
+	}
+	return started, nil
+}
+
+func (s *fakeSeasonStore) ArchiveSeason(ctx context.Context, arg sqlcgen.ArchiveSeasonParams) (sqlcgen.ArchiveSeasonRow, error) {
+	row, ok := s.seasons[arg.SeasonID]
+	if !ok || row.Status != SeasonActive {
+		return sqlcgen.ArchiveSeasonRow{}, nil
+	}
+	row.Status = SeasonArchived
+	row.ArchivedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	s.seasons[arg.SeasonID] = row
+	s.resets = append(s.resets, arg)
+	for i := range s.ratings {
+		s.ratings[i].Rating = 1500 + (s.ratings[i].Rating-1500)*arg.CarryOver
+	}
+	return sqlcgen.ArchiveSeasonRow{Claimed: 1, RatingsReset: int64(len(s.ratings))}, nil
+}
+
+func (s *fakeSeasonStore) CountOverlappingSeasons(ctx context.Context, arg sqlcgen.CountOverlappingSeasonsParams) (int64, error) {
+	var n int64
+	for _, row := range s.seasons {
+		if row.StartsAt.Time.Before(arg.EndsAt.Time) && row.EndsAt.Time.After(arg.StartsAt.Time) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *fakeSeasonStore) CreateSeason(ctx context.Context, arg sqlcgen.CreateSeasonParams) (sqlcgen.Season, error) {
+	if _, ok := s.seasons[arg.SeasonID]; ok {
+		return sqlcgen.Season{}, pgx.ErrNoRows
+	}
+	row := sqlcgen.Season{
+		SeasonID: arg.SeasonID,
+		Name:     arg.Name,
+		StartsAt: arg.StartsAt,
+		EndsAt:   arg.EndsAt,
+		Status:   SeasonScheduled,
+	}
+	s.seasons[arg.SeasonID] = row
+	return row, nil
+}
+
+func (s *fakeSeasonStore) GetSeason(ctx context.Context, seasonID string) (sqlcgen.Season, error) {
+	row, ok := s.seasons[seasonID]
+	if !ok {
+		return sqlcgen.Season{}, pgx.ErrNoRows
+	}
+	return row, nil
+}
+
+func (s *fakeSeasonStore) InsertSeasonStandings(ctx context.Context, arg sqlcgen.InsertSeasonStandingsParams) (int64, error) {
+	for _, st := range s.standings {
+		if st.SeasonID == arg.SeasonID && st.TimeWindow == arg.TimeWindow {
+			return 0, nil
+		}
+	}
+	s.standings = append(s.standings, arg)
+	return 1, nil
+}
+
+func (s *fakeSeasonStore) ListEndedSeasons(ctx context.Context, now pgtype.Timestamptz) ([]sqlcgen.Season, error) {
+	var ended []sqlcgen.Season
+	for _, row := range s.seasons {
+		if row.Status == SeasonActive && !row.EndsAt.Time.After(now.Time) ||
+			row.Status == SeasonArchived && !row.ResetAt.Valid {
+			ended = append(ended, row)
+		}
+	}
+	return ended, nil
+}
+
+func (s *fakeSeasonStore) ListPlayerRatings(ctx context.Context) ([]sqlcgen.ListPlayerRatingsRow, error) {
+	return s.ratings, nil
+}
+
+func (s *fakeSeasonStore) ListSeasonStandings(ctx context.Context, seasonID pgtype.Text) ([]sqlcgen.LeaderboardSnapshot, error) {
+	var rows []sqlcgen.LeaderboardSnapshot
+	for _, st := range s.standings {
+		if st.SeasonID == seasonID {
+			rows = append(rows, sqlcgen.LeaderboardSnapshot{TimeWindow: st.TimeWindow, Entries: st.Entries, SeasonID: st.SeasonID})
+		}
+	}
+	return rows, nil
+}
+
+func (s *fakeSeasonStore) ListSeasons(ctx context.Context) ([]sqlcgen.Season, error) {
+	var rows []sqlcgen.Season
+	for _, row := range s.seasons {
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].StartsAt.Time.After(rows[j].StartsAt.Time) })
+	return rows, nil
+}
+
+func (s *fakeSeasonStore) MarkSeasonReset(ctx context.Context, seasonID string) error {
+	row := s.seasons[seasonID]
+	row.ResetAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	s.seasons[seasonID] = row
+	return nil
+}
+
+// fakeBoard serves fixed boards and records resets.
+type fakeBoard struct {
+	boards  map[string][]Entry
+	cleared []string
+	set     []map[uuid.UUID]int
+	failSet bool
+}
+
+func (b *fakeBoard) Top(ctx context.Context, window string, limit int) ([]Entry, error) {
+	entries := b.boards[window]
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+func (b *fakeBoard) SnapshotTop(ctx context.Context, window string) ([]Entry, error) {
+	return b.boards[window], nil
+}
+
+func (b *fakeBoard) ClearWindow(ctx context.Context, window string) error {
+	b.cleared = append(b.cleared, window)
+	delete(b.boards, window)
+	return nil
+}
+
+func (b *fakeBoard) SetRatings(ctx context.Context, ratings map[uuid.UUID]int) error {
+	if b.failSet {
+		return errors.New("redis down")
+	}
+	b.set = append(b.set, ratings)
+	return nil
+}
+
+func TestParseSeason(t *testing.T) {
+	season, err := ParseSeason(" 2026-q4=2026-10-01..2027-01-01 ")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-q4", season.ID)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), season.StartsAt)
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), season.EndsAt)
+
+	for _, def := range []string{
+		"2026-q4",
+		"2026-q4=2026-10-01",
+		"2026-q4=2026-10-01..soon",
+		"2026-q4=2027-01-01..2026-10-01",
+		"Spring Cup=2026-10-01..2027-01-01",
+	} {
+		_, err := ParseSeason(def)
+		assert.ErrorIs(t, err, ErrInvalidSeason, def)
+	}
+
+	seasons, err := ParseSeasons([]string{"", "a=2026-10-01..2027-01-01", "b=2027-01-01..2027-04-01"})
+	require.NoError(t, err)
+	assert.Len(t, seasons, 2)
+}
+
+func TestCreateSeasonRejectsOverlapAndDuplicates(t *testing.T) {
+	store := newFakeSeasonStore()
+	svc := NewSeasonService(store, &fakeBoard{}, SeasonOptions{}, zerolog.New(io.Discard))
+	ctx := context.Background()
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+
+	season, err := svc.Create(ctx, Season{ID: "s1", StartsAt: start, EndsAt: start.Add(30 * 24 * time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, "s1", season.Name)
+	assert.Equal(t, SeasonScheduled, season.Status)
+
+	_, err = svc.Create(ctx, Season{ID: "s1", StartsAt: start.Add(60 * 24 * time.Hour), EndsAt: start.Add(90 * 24 * time.Hour)})
+	assert.ErrorIs(t, err, ErrSeasonExists)
+
+	_, err = svc.Create(ctx, Season{ID: "s2", StartsAt: start.Add(10 * 24 * time.Hour), EndsAt: start.Add(40 * 24 * time.Hour)})
+	assert.ErrorIs(t, err, ErrSeasonOverlap)
+
+	_, err = svc.Create(ctx, Season{ID: "old", StartsAt: start.Add(-90 * 24 * time.Hour), EndsAt: start.Add(-60 * 24 * time.Hour)})
+	assert.ErrorIs(t, err, ErrInvalidSeason)
+
+	// Back-to-back seasons do not overlap
+	_, err = svc.Create(ctx, Season{ID: "s2", StartsAt: start.Add(30 * 24 * time.Hour), EndsAt: start.Add(60 * 24 * time.Hour)})
+	assert.NoError(t, err)
+}
+
+func TestAdvanceRollsOverSeasonOnce(t *testing.T) {
+	store := newFakeSeasonStore()
+	player := uuid.New()
+	board := &fakeBoard{boards: map[string][]Entry{
+		WindowSeason: {{UserID: player, Username: "ada", Score: 900, Wins: 4, Games: 5}},
+		WindowRating: {{UserID: player, Username: "ada", Score: 1720, Wins: 4, Games: 5}},
+	}}
+	svc := NewSeasonService(store, board, SeasonOptions{RatingCarryOver: 0.4, ResetDeviation: 180}, zerolog.New(io.Discard))
+	ctx := context.Background()
+	store.ratings = []sqlcgen.ListPlayerRatingsRow{{UserID: pgtype.UUID{Bytes: player, Valid: true}, Rating: 1720}}
+
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 3, 0)
+	store.seasons["2026-q4"] = sqlcgen.Season{
+		SeasonID: "2026-q4",
+		Name:     "Autumn",
+		StartsAt: pgtype.Timestamptz{Time: start, Valid: true},
+		EndsAt:   pgtype.Timestamptz{Time: end, Valid: true},
+		Status:   SeasonActive,
+	}
+
+	// Still running: nothing happens
+	require.NoError(t, svc.Advance(ctx, end.Add(-time.Minute)))
+	assert.Empty(t, store.standings)
+	assert.Empty(t, board.cleared)
+
+	live, err := svc.Standings(ctx, "2026-q4", 10)
+	require.NoError(t, err)
+	assert.Equal(t, StandingsLive, live.Source)
+	require.Len(t, live.Top, 1)
+	assert.Equal(t, 900, live.Top[0].Score)
+
+	require.NoError(t, svc.Advance(ctx, end))
+	assert.Equal(t, SeasonArchived, store.seasons["2026-q4"].Status)
+	assert.Len(t, store.standings, 2)
+	assert.Equal(t, []sqlcgen.ArchiveSeasonParams{{SeasonID: "2026-q4", CarryOver: 0.4, MinDeviation: 180}}, store.resets)
+	assert.Equal(t, []map[uuid.UUID]int{{player: 1588}}, board.set)
+	assert.Equal(t, []string{WindowSeason}, board.cleared)
+	assert.True(t, store.seasons["2026-q4"].ResetAt.Valid)
+
+	// Another instance that listed the season before it was archived does not reset again
+	stale := store.seasons["2026-q4"]
+	stale.Status = SeasonActive
+	require.NoError(t, svc.rollover(ctx, stale, end))
+	assert.Len(t, store.standings, 2)
+	assert.Len(t, store.resets, 1)
+	assert.Len(t, board.set, 1)
+
+	archived, err := svc.Standings(ctx, "2026-q4", 10)
+	require.NoError(t, err)
+	assert.Equal(t, StandingsArchive, archived.Source)
+	require.Len(t, archived.Top, 1)
+	assert.Equal(t, 900, archived.Top[0].Score)
+	assert.Equal(t, 1, archived.Top[0].Rank)
+	require.Len(t, archived.RatingTop, 1)
+	assert.Equal(t, 1720, archived.RatingTop[0].Score)
+
+	_, err = svc.Standings(ctx, "missing", 10)
+	assert.ErrorIs(t, err, ErrSeasonNotFound)
+}
+
+func TestAdvanceRetriesUnfinishedBoardReset(t *testing.T) {
+	store := newFakeSeasonStore()
+	player := uuid.New()
+	board := &fakeBoard{boards: map[string][]Entry{WindowSeason: {{UserID: player, Score: 900}}}, failSet: true}
+	svc := NewSeasonService(store, board, SeasonOptions{RatingCarryOver: 0.5}, zerolog.New(io.Discard))
+	ctx := context.Background()
+	store.ratings = []sqlcgen.ListPlayerRatingsRow{{UserID: pgtype.UUID{Bytes: player, Valid: true}, Rating: 1700}}
+
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 3, 0)
+	store.seasons["2026-q4"] = sqlcgen.Season{
+		SeasonID: "2026-q4",
+		StartsAt: pgtype.Timestamptz{Time: start, Valid: true},
+		EndsAt:   pgtype.Timestamptz{Time: end, Valid: true},
+		Status:   SeasonActive,
+	}
+	store.seasons["2027-q1"] = sqlcgen.Season{
+		SeasonID: "2027-q1",
+		StartsAt: pgtype.Timestamptz{Time: end, Valid: true},
+		EndsAt:   pgtype.Timestamptz{Time: end.AddDate(0, 3, 0), Valid: true},
+		Status:   SeasonScheduled,
+	}
+
+	// The season is claimed and ratings reset in the store, but Redis is down
+	assert.Error(t, svc.Advance(ctx, end))
+	assert.Equal(t, SeasonArchived, store.seasons["2026-q4"].Status)
+	assert.False(t, store.seasons["2026-q4"].ResetAt.Valid)
+	assert.Equal(t, SeasonScheduled, store.seasons["2027-q1"].Status, "next season waits for the reset")
+
+	// The retry resets the boards without resetting the stored ratings again
+	board.failSet = false
+	require.NoError(t, svc.Advance(ctx, end.Add(time.Minute)))
+	assert.Len(t, store.resets, 1)
+	assert.Equal(t, []map[uuid.UUID]int{{player: 1600}}, board.set)
+	assert.True(t, store.seasons["2026-q4"].ResetAt.Valid)
+	assert.Equal(t, SeasonActive, store.seasons["2027-q1"].Status)
+
+	// Nothing left to retry
+	require.NoError(t, svc.Advance(ctx, end.Add(2*time.Minute)))
+	assert.Len(t, board.set, 1)
+}
+
+func TestAdvanceStartsDueSeasons(t *testing.T) {
+	store := newFakeSeasonStore()
+	board := &fakeBoard{boards: map[string][]Entry{WindowSeason: {{UserID: uuid.New(), Score: 50}}}}
+	svc := NewSeasonService(store, board, SeasonOptions{}, zerolog.New(io.Discard))
+	ctx := context.Background()
+
+	start := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.seasons["2027-q1"] = sqlcgen.Season{
+		SeasonID: "2027-q1",
+		StartsAt: pgtype.Timestamptz{Time: start, Valid: true},
+		EndsAt:   pgtype.Timestamptz{Time: start.AddDate(0, 3, 0), Valid: true},
+		Status:   SeasonScheduled,
+	}
+
+	scheduled, err := svc.Standings(ctx, "2027-q1", 10)
+	require.NoError(t, err)
+	assert.Empty(t, scheduled.Source)
+	assert.Empty(t, scheduled.Top)
+
+	require.NoError(t, svc.Advance(ctx, start))
+	assert.Equal(t, SeasonActive, store.seasons["2027-q1"].Status)
+	// Points scored between seasons are dropped
+	assert.Equal(t, []string{WindowSeason}, board.cleared)
+	assert.Empty(t, store.resets)
+}
+
+func TestReversalsSkipSeasonBoardForPastSeasons(t *testing.T) {
+	store := newFakeSeasonStore()
+	seasons := NewSeasonService(store, &fakeBoard{}, SeasonOptions{}, zerolog.New(io.Discard))
+	svc := NewService(nil, zerolog.New(io.Discard), ServiceOptions{})
+	ctx := context.Background()
+
+	start := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	// Without seasons the season board is never cleared
+	assert.True(t, svc.inCurrentSeason(ctx, start.Add(-time.Hour)))
+
+	svc.UseSeasons(seasons)
+	store.seasons["2026-q4"] = sqlcgen.Season{
+		SeasonID: "2026-q4",
+		StartsAt: pgtype.Timestamptz{Time: start.AddDate(0, -3, 0), Valid: true},
+		EndsAt:   pgtype.Timestamptz{Time: start, Valid: true},
+		Status:   SeasonArchived,
+	}
+	// Between seasons nothing recorded is still on the board
+	assert.False(t, svc.inCurrentSeason(ctx, start.Add(-time.Hour)))
+
+	store.seasons["2027-q1"] = sqlcgen.Season{
+		SeasonID: "2027-q1",
+		StartsAt: pgtype.Timestamptz{Time: start, Valid: true},
+		EndsAt:   pgtype.Timestamptz{Time: start.AddDate(0, 3, 0), Valid: true},
+		Status:   SeasonActive,
+	}
+	assert.False(t, svc.inCurrentSeason(ctx, start.Add(-time.Hour)))
+	assert.True(t, svc.inCurrentSeason(ctx, start))
+	assert.True(t, svc.inCurrentSeason(ctx, start.Add(time.Hour)))
+
+	assert.Equal(t, []string{WindowDaily, WindowAllTime}, withoutWindow([]string{WindowDaily, WindowSeason, WindowAllTime}, WindowSeason))
+}
@@ -0,0 +1,61 @@
+package leaderboard
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// SeasonWorker schedules the configured seasons and periodically starts and rolls
+// over seasons as their dates pass.
+type SeasonWorker struct {
+	svc      *SeasonService
+	seasons  []Season
+	logger   zerolog.Logger
+	interval time.Duration
+}
+
+// NewSeasonWorker creates a worker that seeds seasons from config and advances
+// them every interval.
+func NewSeasonWorker(svc *SeasonService, seasons []Season, interval time.Duration, logger zerolog.Logger) *SeasonWorker {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &SeasonWorker{
+		svc:      svc,
+		seasons:  seasons,
+		logger:   logger.With().Str("component", "season_worker").Logger(),
+		interval: interval,
+	}
+}
+
+// Run blocks until context cancellation.
+func (w *SeasonWorker) Run(ctx context.Context) error {
+	if w.svc == nil {
+		return nil
+	}
+
+	w.svc.Seed(ctx, w.seasons)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	// run immediately
+	w.tick(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			w.tick(ctx)
+		}
+	}
+}
+
+func (w *SeasonWorker) tick(ctx context.Context) {
+	if err := w.svc.Advance(ctx, time.Now().UTC()); err != nil {
+		w.logger.Warn().Err(err).Msg("season rollover failed")
+	}
+}
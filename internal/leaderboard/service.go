@@ -3,6 +3,7 @@ package leaderboard
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -20,9 +21,32 @@ const (
 	WindowWeekly  = "weekly"
 	WindowMonthly = "monthly"
 	WindowAllTime = "all_time"
+	// WindowSeason accumulates points for the current competitive season and is
+	// cleared when a season starts or ends.
+	WindowSeason = "season"
+	// WindowRating ranks players by skill rating rather than accumulated points.
+	WindowRating = "rating"
 )
 
-var defaultWindows = []string{WindowDaily, WindowWeekly, WindowMonthly, WindowAllTime}
+// clearBatch is how many keys ClearWindow scans and deletes at a time.
+const clearBatch = 500
+
+var defaultWindows = []string{WindowDaily, WindowWeekly, WindowMonthly, WindowAllTime, WindowSeason}
+
+// snapshotWindows are the windows persisted to leaderboard_snapshots.
+var snapshotWindows = append(append([]string{}, defaultWindows...), WindowRating)
+
+// ErrNotRanked is returned when a player has no entry on the board asked about.
+var ErrNotRanked = errors.New("player not ranked")
+
+// Position is a player's place on a board, with the players ranked just above and
+// below them.
+type Position struct {
+	Rank  int                   `json:"rank"`
+	Score int                   `json:"score"`
+	Above []ws.LeaderboardEntry `json:"above"`
+	Below []ws.LeaderboardEntry `json:"below"`
+}
 
 // Entry represents a leaderboard record sent to clients.
 type Entry struct {
@@ -47,6 +71,9 @@ type RecordRequest struct {
 	MatchID       uuid.UUID
 	Windows       []string
 	Eligible      bool
+	// RecordedAt places the result in the periods it was first recorded in; zero
+	// means now.
+	RecordedAt time.Time
 }
 
 // ServiceOptions configures leaderboard service behavior.
@@ -58,6 +85,10 @@ type ServiceOptions struct {
 	EntryTTL         time.Duration
 	RedisKeyPrefix   string
 	SnapshotTopLimit int
+	// Location is the timezone daily, weekly and monthly periods roll over in (default UTC).
+	Location *time.Location
+	// PeriodRetention is how long a period's board is kept after it closes (default 7 days).
+	PeriodRetention time.Duration
 }
 
 // Service manages leaderboard state in Redis and emits updates over Pub/Sub.
@@ -71,6 +102,9 @@ type Service struct {
 	entryTTL       time.Duration
 	prefix         string
 	snapshotTopLim int
+	loc            *time.Location
+	retention      time.Duration
+	seasons        *SeasonService
 }
 
 // NewService constructs a leaderboard service instance.
@@ -95,6 +129,14 @@ func NewService(redis *redis.Client, logger zerolog.Logger, opts ServiceOptions)
 	if snapTop <= 0 {
 		snapTop = 100
 	}
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	retention := opts.PeriodRetention
+	if retention <= 0 {
+		retention = 7 * 24 * time.Hour
+	}
 
 	return &Service{
 		redis:          redis,
@@ -106,6 +148,8 @@ func NewService(redis *redis.Client, logger zerolog.Logger, opts ServiceOptions)
 		entryTTL:       opts.EntryTTL,
 		prefix:         prefix,
 		snapshotTopLim: snapTop,
+		loc:            loc,
+		retention:      retention,
 	}
 }
 
@@ -136,8 +180,9 @@ func (s *Service) RecordResult(ctx context.Context, req RecordRequest) error {
 		QuestionTotal: req.QuestionCount,
 	}
 
+	at := recordedAt(req)
 	for _, window := range windows {
-		if err := s.updateWindow(ctx, window, entry); err != nil {
+		if err := s.updateWindow(ctx, window, s.periodAt(window, at), entry); err != nil {
 			return err
 		}
 	}
@@ -147,13 +192,125 @@ func (s *Service) RecordResult(ctx context.Context, req RecordRequest) error {
 	return nil
 }
 
-// Top retrieves the top N entries for a given window.
+// UseSeasons lets reversals tell which season a result was recorded in.
+func (s *Service) UseSeasons(seasons *SeasonService) {
+	s.seasons = seasons
+}
+
+// ReverseResult takes a previously recorded result back out of every window, for a
+// match that loses its leaderboard eligibility after the fact. req must carry the
+// values originally recorded. The season board is skipped for results recorded
+// before the current season started: they were cleared off it with their season.
+func (s *Service) ReverseResult(ctx context.Context, req RecordRequest) error {
+	windows := req.Windows
+	if len(windows) == 0 {
+		windows = s.windows
+	}
+
+	at := recordedAt(req)
+	if !s.inCurrentSeason(ctx, at) {
+		windows = withoutWindow(windows, WindowSeason)
+	}
+	for _, window := range windows {
+		period := s.periodAt(window, at)
+		if err := s.reverseEntry(ctx, s.leaderboardKey(window, period), s.metaKey(window, period, req.UserID), req); err != nil {
+			return fmt.Errorf("reverse leaderboard window %s: %w", window, err)
+		}
+	}
+
+	go s.publishUpdate(context.Background(), req.MatchID, windows)
+	return nil
+}
+
+// inCurrentSeason reports whether a result recorded at t is on the season board.
+// Without seasons configured the board is never cleared, so every result is.
+func (s *Service) inCurrentSeason(ctx context.Context, t time.Time) bool {
+	if s.seasons == nil {
+		return true
+	}
+	current, err := s.seasons.Current(ctx)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("failed to load current season, leaving the season board alone")
+		return false
+	}
+	return current != nil && !t.Before(current.StartsAt)
+}
+
+// RecordRating sets a player's place on the rating board to their new skill rating
+// and adds the game to their stats there. Unlike the point windows, the score is
+// replaced rather than accumulated.
+func (s *Service) RecordRating(ctx context.Context, req RecordRequest, rating int) error {
+	if !req.Eligible {
+		return nil
+	}
+
+	zKey := s.leaderboardKey(WindowRating, "")
+	metaKey := s.metaKey(WindowRating, "", req.UserID)
+
+	pipe := s.redis.TxPipeline()
+	pipe.ZAdd(ctx, zKey, redis.Z{Score: float64(rating), Member: req.UserID.String()})
+	pipe.HIncrBy(ctx, metaKey, "wins", int64(boolToInt(req.Won)))
+	pipe.HIncrBy(ctx, metaKey, "games", 1)
+	pipe.HIncrBy(ctx, metaKey, "correct", int64(req.CorrectCount))
+	pipe.HIncrBy(ctx, metaKey, "questions", int64(req.QuestionCount))
+	pipe.HSet(ctx, metaKey, map[string]interface{}{
+		"username": req.Username,
+	})
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("update rating leaderboard: %w", err)
+	}
+
+	go s.publishUpdate(context.Background(), req.MatchID, []string{WindowRating})
+	return nil
+}
+
+// ReverseRating takes a rated game back off the rating board, for a match that is
+// invalidated after the fact: the player's score is set to their restored rating
+// and the game is removed from their stats there. req must carry the values
+// originally recorded.
+func (s *Service) ReverseRating(ctx context.Context, req RecordRequest, rating int) error {
+	zKey := s.leaderboardKey(WindowRating, "")
+	metaKey := s.metaKey(WindowRating, "", req.UserID)
+
+	exists, err := s.redis.Exists(ctx, metaKey).Result()
+	if err != nil {
+		return fmt.Errorf("reverse rating leaderboard: %w", err)
+	}
+	if exists == 0 {
+		return nil
+	}
+
+	pipe := s.redis.TxPipeline()
+	pipe.ZAddXX(ctx, zKey, redis.Z{Score: float64(rating), Member: req.UserID.String()})
+	pipe.HIncrBy(ctx, metaKey, "wins", -int64(boolToInt(req.Won)))
+	pipe.HIncrBy(ctx, metaKey, "games", -1)
+	pipe.HIncrBy(ctx, metaKey, "correct", -int64(req.CorrectCount))
+	pipe.HIncrBy(ctx, metaKey, "questions", -int64(req.QuestionCount))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("reverse rating leaderboard: %w", err)
+	}
+
+	go s.publishUpdate(context.Background(), req.MatchID, []string{WindowRating})
+	return nil
+}
+
+// Top retrieves the top N entries for a given window in its current period.
 func (s *Service) Top(ctx context.Context, window string, limit int) ([]Entry, error) {
+	return s.TopForPeriod(ctx, window, s.CurrentPeriod(window), limit)
+}
+
+// TopForPeriod retrieves the top N entries of one period of a window. Periods
+// older than the retention have expired and come back empty.
+func (s *Service) TopForPeriod(ctx context.Context, window, period string, limit int) ([]Entry, error) {
+	if err := checkPeriod(window, period); err != nil {
+		return nil, err
+	}
 	if limit <= 0 || limit > s.topN {
 		limit = s.topN
 	}
 
-	zKey := s.leaderboardKey(window)
+	zKey := s.leaderboardKey(window, period)
 	results, err := s.redis.ZRevRangeWithScores(ctx, zKey, 0, int64(limit-1)).Result()
 	if err != nil {
 		return nil, fmt.Errorf("fetch leaderboard: %w", err)
@@ -161,7 +318,7 @@ func (s *Service) Top(ctx context.Context, window string, limit int) ([]Entry, e
 
 	entries := make([]Entry, 0, len(results))
 	for _, z := range results {
-		meta, err := s.readMeta(ctx, window, z.Member.(string))
+		meta, err := s.readMeta(ctx, window, period, z.Member.(string))
 		if err != nil {
 			s.logger.Warn().Err(err).Msg("failed to read leaderboard metadata")
 			continue
@@ -172,14 +329,138 @@ func (s *Service) Top(ctx context.Context, window string, limit int) ([]Entry, e
 	return entries, nil
 }
 
+// RankOf returns a player's position on a window's board in its current period,
+// with up to neighbours players above and below them.
+func (s *Service) RankOf(ctx context.Context, userID uuid.UUID, window string, neighbours int) (*Position, error) {
+	period := s.CurrentPeriod(window)
+	return s.position(ctx, s.leaderboardKey(window, period), userID, neighbours, func(member string) (*Entry, error) {
+		return s.readMeta(ctx, window, period, member)
+	})
+}
+
+// PrivateRoomRankOf returns a player's position on a private room's board, with up
+// to neighbours players above and below them.
+func (s *Service) PrivateRoomRankOf(ctx context.Context, roomCode string, userID uuid.UUID, neighbours int) (*Position, error) {
+	return s.position(ctx, s.privateRoomLeaderboardKey(roomCode), userID, neighbours, func(member string) (*Entry, error) {
+		return s.readPrivateRoomMeta(ctx, roomCode, member)
+	})
+}
+
+// position looks a player up on a board with ZREVRANK and reads the slice of the
+// board around them.
+func (s *Service) position(ctx context.Context, zKey string, userID uuid.UUID, neighbours int, readMeta func(member string) (*Entry, error)) (*Position, error) {
+	member := userID.String()
+	pipe := s.redis.Pipeline()
+	rankCmd := pipe.ZRevRank(ctx, zKey, member)
+	scoreCmd := pipe.ZScore(ctx, zKey, member)
+	if _, err := pipe.Exec(ctx); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrNotRanked
+		}
+		return nil, fmt.Errorf("fetch leaderboard rank: %w", err)
+	}
+	rank := rankCmd.Val()
+
+	pos := &Position{
+		Rank:  int(rank) + 1,
+		Score: int(scoreCmd.Val()),
+		Above: []ws.LeaderboardEntry{},
+		Below: []ws.LeaderboardEntry{},
+	}
+	if neighbours <= 0 {
+		return pos, nil
+	}
+
+	first := rank - int64(neighbours)
+	if first < 0 {
+		first = 0
+	}
+	results, err := s.redis.ZRevRangeWithScores(ctx, zKey, first, rank+int64(neighbours)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("fetch leaderboard neighbours: %w", err)
+	}
+	for i, z := range results {
+		if z.Member.(string) == member {
+			continue
+		}
+		entry, err := readMeta(z.Member.(string))
+		if err != nil {
+			s.logger.Warn().Err(err).Msg("failed to read leaderboard metadata")
+			continue
+		}
+		entry.Score = int(z.Score)
+		wsEntry := rankedEntries([]Entry{*entry}, int(first)+i+1)[0]
+		if first+int64(i) < rank {
+			pos.Above = append(pos.Above, wsEntry)
+		} else {
+			pos.Below = append(pos.Below, wsEntry)
+		}
+	}
+	return pos, nil
+}
+
 // SnapshotTop returns the configured snapshot size for persistence jobs.
 func (s *Service) SnapshotTop(ctx context.Context, window string) ([]Entry, error) {
 	return s.Top(ctx, window, s.snapshotTopLim)
 }
 
-func (s *Service) updateWindow(ctx context.Context, window string, entry Entry) error {
-	zKey := s.leaderboardKey(window)
-	metaKey := s.metaKey(window, entry.UserID)
+// CurrentPeriod returns the period window is recording into now, or "" for a
+// window that never rolls over.
+func (s *Service) CurrentPeriod(window string) string {
+	return s.periodAt(window, time.Now())
+}
+
+func (s *Service) periodAt(window string, t time.Time) string {
+	return periodOf(window, t.In(s.loc))
+}
+
+// ClearWindow removes every entry, with its stats, from the board of a window
+// that never rolls over.
+func (s *Service) ClearWindow(ctx context.Context, window string) error {
+	keys := []string{s.leaderboardKey(window, "")}
+	iter := s.redis.Scan(ctx, 0, fmt.Sprintf("%s:%s:meta:*", s.prefix, window), clearBatch).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) >= clearBatch {
+			if err := s.redis.Del(ctx, keys...).Err(); err != nil {
+				return fmt.Errorf("clear leaderboard window %s: %w", window, err)
+			}
+			keys = keys[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("scan leaderboard window %s: %w", window, err)
+	}
+	if len(keys) > 0 {
+		if err := s.redis.Del(ctx, keys...).Err(); err != nil {
+			return fmt.Errorf("clear leaderboard window %s: %w", window, err)
+		}
+	}
+	return nil
+}
+
+// SetRatings moves players already on the rating board to the given ratings, e.g.
+// after a season soft reset of the stored ratings. Players not on the board are
+// left off it. Setting the same ratings twice is harmless.
+func (s *Service) SetRatings(ctx context.Context, ratings map[uuid.UUID]int) error {
+	if len(ratings) == 0 {
+		return nil
+	}
+
+	zKey := s.leaderboardKey(WindowRating, "")
+	pipe := s.redis.Pipeline()
+	for userID, r := range ratings {
+		pipe.ZAddXX(ctx, zKey, redis.Z{Score: float64(r), Member: userID.String()})
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("set rating leaderboard: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) updateWindow(ctx context.Context, window, period string, entry Entry) error {
+	zKey := s.leaderboardKey(window, period)
+	metaKey := s.metaKey(window, period, entry.UserID)
 
 	pipe := s.redis.TxPipeline()
 	pipe.ZIncrBy(ctx, zKey, float64(entry.Score), entry.UserID.String())
@@ -190,7 +471,11 @@ func (s *Service) updateWindow(ctx context.Context, window string, entry Entry)
 	pipe.HSet(ctx, metaKey, map[string]interface{}{
 		"username": entry.Username,
 	})
-	if s.entryTTL > 0 && window != WindowAllTime {
+	if _, end, err := periodBounds(window, period, s.loc); err == nil {
+		// Kept past the period's end so the rollover job can snapshot it
+		pipe.ExpireAt(ctx, zKey, end.Add(s.retention))
+		pipe.ExpireAt(ctx, metaKey, end.Add(s.retention))
+	} else if s.entryTTL > 0 && window != WindowAllTime && window != WindowSeason {
 		pipe.Expire(ctx, zKey, s.entryTTL)
 		pipe.Expire(ctx, metaKey, s.entryTTL)
 	}
@@ -229,8 +514,8 @@ func (s *Service) publishUpdate(ctx context.Context, matchID uuid.UUID, windows
 	}
 }
 
-func (s *Service) readMeta(ctx context.Context, window string, userIDStr string) (*Entry, error) {
-	metaKey := s.metaKey(window, uuid.MustParse(userIDStr))
+func (s *Service) readMeta(ctx context.Context, window, period string, userIDStr string) (*Entry, error) {
+	metaKey := s.metaKey(window, period, uuid.MustParse(userIDStr))
 	data, err := s.redis.HGetAll(ctx, metaKey).Result()
 	if err != nil {
 		return nil, err
@@ -252,12 +537,25 @@ func (s *Service) readMeta(ctx context.Context, window string, userIDStr string)
 	return entry, nil
 }
 
-func (s *Service) leaderboardKey(window string) string {
-	return fmt.Sprintf("%s:%s", s.prefix, window)
+// leaderboardKey returns the board of one period of a window, e.g. lb:daily:2026-10-16,
+// or lb:all_time for windows without periods.
+func (s *Service) leaderboardKey(window, period string) string {
+	if period == "" {
+		return fmt.Sprintf("%s:%s", s.prefix, window)
+	}
+	return fmt.Sprintf("%s:%s:%s", s.prefix, window, period)
+}
+
+func (s *Service) metaKey(window, period string, userID uuid.UUID) string {
+	return fmt.Sprintf("%s:meta:%s", s.leaderboardKey(window, period), userID.String())
 }
 
-func (s *Service) metaKey(window string, userID uuid.UUID) string {
-	return fmt.Sprintf("%s:%s:meta:%s", s.prefix, window, userID.String())
+// recordedAt returns when a result counts from.
+func recordedAt(req RecordRequest) time.Time {
+	if req.RecordedAt.IsZero() {
+		return time.Now()
+	}
+	return req.RecordedAt
 }
 
 // RecordPrivateRoomResult records a result to a room-specific leaderboard (separate from main leaderboard).
@@ -306,6 +604,35 @@ func (s *Service) RecordPrivateRoomResult(ctx context.Context, roomCode string,
 	return nil
 }
 
+// ReversePrivateRoomResult takes a previously recorded result back out of a room leaderboard.
+func (s *Service) ReversePrivateRoomResult(ctx context.Context, roomCode string, req RecordRequest) error {
+	if err := s.reverseEntry(ctx, s.privateRoomLeaderboardKey(roomCode), s.privateRoomMetaKey(roomCode, req.UserID), req); err != nil {
+		return fmt.Errorf("reverse private room leaderboard %s: %w", roomCode, err)
+	}
+	return nil
+}
+
+// reverseEntry subtracts one recorded game from a sorted set and its metadata hash.
+// Entries that have since expired are left alone rather than recreated negative.
+func (s *Service) reverseEntry(ctx context.Context, zKey, metaKey string, req RecordRequest) error {
+	exists, err := s.redis.Exists(ctx, metaKey).Result()
+	if err != nil {
+		return err
+	}
+	if exists == 0 {
+		return nil
+	}
+
+	pipe := s.redis.TxPipeline()
+	pipe.ZIncrBy(ctx, zKey, -float64(req.Score), req.UserID.String())
+	pipe.HIncrBy(ctx, metaKey, "wins", -int64(boolToInt(req.Won)))
+	pipe.HIncrBy(ctx, metaKey, "games", -1)
+	pipe.HIncrBy(ctx, metaKey, "correct", -int64(req.CorrectCount))
+	pipe.HIncrBy(ctx, metaKey, "questions", -int64(req.QuestionCount))
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
 // GetPrivateRoomLeaderboard retrieves the top N entries for a private room.
 func (s *Service) GetPrivateRoomLeaderboard(ctx context.Context, roomCode string, limit int) ([]Entry, error) {
 	if limit <= 0 || limit > s.topN {
@@ -5,15 +5,19 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/rs/zerolog"
 
 	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
 )
 
-// SnapshotWorker periodically persists Redis leaderboards into Postgres.
+// SnapshotWorker periodically persists Redis leaderboards into Postgres, and
+// stores the final standings of each daily, weekly and monthly period once it
+// has closed, before its board expires.
 type SnapshotWorker struct {
 	svc      *Service
 	queries  *sqlcgen.Queries
@@ -61,11 +65,67 @@ func (w *SnapshotWorker) Run(ctx context.Context) error {
 }
 
 func (w *SnapshotWorker) tick(ctx context.Context) {
-	for _, window := range defaultWindows {
+	for _, window := range snapshotWindows {
 		if err := w.snapshotWindow(ctx, window); err != nil {
 			w.logger.Warn().Err(err).Str("window", window).Msg("snapshot failed")
 		}
 	}
+
+	now := time.Now().In(w.svc.loc)
+	for _, window := range periodicWindows {
+		for _, period := range closedPeriods(window, now, w.svc.retention) {
+			if err := w.archivePeriod(ctx, window, period); err != nil {
+				w.logger.Warn().Err(err).Str("window", window).Str("period", period).Msg("period archive failed")
+			}
+		}
+	}
+}
+
+// archivePeriod stores the final standings of a closed period unless they already are.
+func (w *SnapshotWorker) archivePeriod(ctx context.Context, window, period string) error {
+	_, err := w.queries.GetPeriodSnapshot(ctx, sqlcgen.GetPeriodSnapshotParams{
+		TimeWindow: window,
+		Period:     pgtype.Text{String: period, Valid: true},
+	})
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	entries, err := w.svc.TopForPeriod(ctx, window, period, w.topN)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	data, sourceHash, err := encodeSnapshot(entries)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	stored, err := w.queries.InsertPeriodSnapshot(ctx, sqlcgen.InsertPeriodSnapshotParams{
+		TimeWindow:  window,
+		GeneratedAt: pgtype.Timestamptz{Time: now, Valid: true},
+		Entries:     data,
+		SourceHash:  sourceHash,
+		Period:      pgtype.Text{String: period, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+
+	if stored > 0 {
+		w.logger.Info().
+			Str("window", window).
+			Str("period", period).
+			Int("entries", len(entries)).
+			Msg("leaderboard period archived")
+	}
+	return nil
 }
 
 func (w *SnapshotWorker) snapshotWindow(ctx context.Context, window string) error {
@@ -77,13 +137,10 @@ func (w *SnapshotWorker) snapshotWindow(ctx context.Context, window string) erro
 		return nil
 	}
 
-	wsEntries := toWSEntries(entries)
-	data, err := json.Marshal(wsEntries)
+	data, sourceHash, err := encodeSnapshot(entries)
 	if err != nil {
 		return err
 	}
-
-	sourceHash := sha256.Sum256(data)
 	now := time.Now().UTC()
 
 	params := sqlcgen.InsertLeaderboardSnapshotParams{
@@ -93,7 +150,7 @@ func (w *SnapshotWorker) snapshotWindow(ctx context.Context, window string) erro
 			Valid: true,
 		},
 		Entries:    data,
-		SourceHash: hex.EncodeToString(sourceHash[:]),
+		SourceHash: sourceHash,
 	}
 
 	if _, err := w.queries.InsertLeaderboardSnapshot(ctx, params); err != nil {
@@ -102,9 +159,20 @@ func (w *SnapshotWorker) snapshotWindow(ctx context.Context, window string) erro
 
 	w.logger.Info().
 		Str("window", window).
-		Int("entries", len(wsEntries)).
+		Int("entries", len(entries)).
 		Time("generated_at", now).
 		Msg("leaderboard snapshot persisted")
 
 	return nil
 }
+
+// encodeSnapshot serializes entries as stored in leaderboard_snapshots, with the
+// hash of the payload.
+func encodeSnapshot(entries []Entry) ([]byte, string, error) {
+	data, err := json.Marshal(toWSEntries(entries))
+	if err != nil {
+		return nil, "", err
+	}
+	sum := sha256.Sum256(data)
+	return data, hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,175 @@
+package match
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/gokatarajesh/quiz-platform/internal/question"
+)
+
+// BotProfile controls how a bot opponent answers questions.
+// Response latency follows a log-normal distribution around MedianResponse,
+// which gives the long right tail of real players instead of uniform noise.
+type BotProfile struct {
+	Accuracy       float64       // probability of a correct answer (0..1)
+	MedianResponse time.Duration // median answer latency
+	Spread         float64       // sigma of the log-normal latency distribution
+	MinResponse    time.Duration // floor so bots never answer inhumanly fast
+}
+
+// BotIdentity is a seeded bot account (see db/migrations/002_bot_users.sql).
+type BotIdentity struct {
+	UserID   uuid.UUID
+	Username string
+}
+
+// Seeded bot accounts, one per difficulty level.
+var botIdentities = map[string]BotIdentity{
+	question.DifficultyEasy:   {UserID: uuid.MustParse("00000000-0000-0000-0000-00000000b001"), Username: "bot_easy"},
+	question.DifficultyMedium: {UserID: uuid.MustParse("00000000-0000-0000-0000-00000000b002"), Username: "bot_medium"},
+	question.DifficultyHard:   {UserID: uuid.MustParse("00000000-0000-0000-0000-00000000b003"), Username: "bot_hard"},
+}
+
+// DefaultBotProfiles returns the production bot tuning per difficulty.
+func DefaultBotProfiles() map[string]BotProfile {
+	return map[string]BotProfile{
+		question.DifficultyEasy: {
+			Accuracy:       0.55,
+			MedianResponse: 8 * time.Second,
+			Spread:         0.35,
+			MinResponse:    3 * time.Second,
+		},
+		question.DifficultyMedium: {
+			Accuracy:       0.70,
+			MedianResponse: 6 * time.Second,
+			Spread:         0.35,
+			MinResponse:    2 * time.Second,
+		},
+		question.DifficultyHard: {
+			Accuracy:       0.85,
+			MedianResponse: 4500 * time.Millisecond,
+			Spread:         0.30,
+			MinResponse:    1500 * time.Millisecond,
+		},
+	}
+}
+
+// normalizeBotDifficulty maps a requested difficulty to a known bot level (default medium).
+func normalizeBotDifficulty(difficulty string) string {
+	if _, ok := botIdentities[difficulty]; ok {
+		return difficulty
+	}
+	return question.DifficultyMedium
+}
+
+// IsBotUser reports whether a user ID belongs to a seeded bot account.
+func IsBotUser(userID uuid.UUID) bool {
+	for _, bot := range botIdentities {
+		if bot.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Bot plays a match on behalf of a bot identity by submitting answers through Service.SubmitAnswer.
+type Bot struct {
+	service  *Service
+	identity BotIdentity
+	profile  BotProfile
+	rng      *rand.Rand
+	logger   zerolog.Logger
+}
+
+// NewBot creates a bot player for one match.
+func NewBot(service *Service, identity BotIdentity, profile BotProfile, logger zerolog.Logger) *Bot {
+	return &Bot{
+		service:  service,
+		identity: identity,
+		profile:  profile,
+		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		logger:   logger.With().Str("component", "match_bot").Str("bot_id", identity.UserID.String()).Logger(),
+	}
+}
+
+// Play answers every question in order, each within its clock window.
+// active is polled before every answer so the bot stops once the match ends.
+// onAnswer is called after every accepted answer.
+func (b *Bot) Play(ctx context.Context, match *Match, questions []QuestionPackItem, startedAt time.Time, active func() bool, onAnswer func()) {
+	per := time.Duration(match.PerQuestionSeconds) * time.Second
+
+	for _, q := range questions {
+		windowStart := startedAt.Add(time.Duration(q.Order-1) * per)
+		answerAt := windowStart.Add(b.responseTime(per))
+
+		timer := time.NewTimer(time.Until(answerAt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if !active() {
+			return
+		}
+
+		answer := b.pickAnswer(q)
+		if err := b.submit(ctx, match.ID, q.Token, answer); err != nil {
+			b.logger.Warn().Err(err).Str("match_id", match.ID.String()).Int("question_order", q.Order).Msg("bot answer rejected")
+			continue
+		}
+		if onAnswer != nil {
+			onAnswer()
+		}
+	}
+}
+
+// submit retries briefly because SubmitAnswer fails fast when the match lock is held.
+func (b *Bot) submit(ctx context.Context, matchID uuid.UUID, token, answer string) error {
+	var err error
+	for attempt := 0; attempt < 3; attempt++ {
+		if _, err = b.service.SubmitAnswer(ctx, matchID, b.identity.UserID, token, answer, nil, time.Now()); err == nil {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return err
+}
+
+// responseTime samples a log-normal latency, clamped to the question window.
+func (b *Bot) responseTime(window time.Duration) time.Duration {
+	sample := float64(b.profile.MedianResponse) * math.Exp(b.profile.Spread*b.rng.NormFloat64())
+	latency := time.Duration(sample)
+
+	if latency < b.profile.MinResponse {
+		latency = b.profile.MinResponse
+	}
+	if ceiling := window - 500*time.Millisecond; ceiling > 0 && latency > ceiling {
+		latency = ceiling
+	}
+	return latency
+}
+
+// pickAnswer returns the correct answer with probability Accuracy, otherwise a random wrong option.
+func (b *Bot) pickAnswer(q QuestionPackItem) string {
+	if b.rng.Float64() < b.profile.Accuracy {
+		return q.CorrectAnswer
+	}
+
+	wrong := make([]string, 0, len(q.Options))
+	for _, opt := range q.Options {
+		if opt != q.CorrectAnswer {
+			wrong = append(wrong, opt)
+		}
+	}
+	if len(wrong) == 0 {
+		return q.CorrectAnswer
+	}
+	return wrong[b.rng.Intn(len(wrong))]
+}
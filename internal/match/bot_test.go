@@ -0,0 +1,53 @@
+package match
+
+import (
+	"io"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gokatarajesh/quiz-platform/internal/question"
+)
+
+func TestBotResponseTimeStaysInWindow(t *testing.T) {
+	profile := DefaultBotProfiles()[question.DifficultyHard]
+	bot := NewBot(nil, botIdentities[question.DifficultyHard], profile, zerolog.New(io.Discard))
+	bot.rng = rand.New(rand.NewSource(1))
+
+	window := 10 * time.Second
+	for i := 0; i < 1000; i++ {
+		latency := bot.responseTime(window)
+		assert.GreaterOrEqual(t, latency, profile.MinResponse)
+		assert.LessOrEqual(t, latency, window-500*time.Millisecond)
+	}
+}
+
+func TestBotAccuracy(t *testing.T) {
+	profile := BotProfile{Accuracy: 0.7, MedianResponse: time.Second, MinResponse: time.Second}
+	bot := NewBot(nil, botIdentities[question.DifficultyMedium], profile, zerolog.New(io.Discard))
+	bot.rng = rand.New(rand.NewSource(7))
+
+	q := QuestionPackItem{Options: []string{"A", "B", "C", "D"}, CorrectAnswer: "B"}
+	correct := 0
+	const rounds = 5000
+	for i := 0; i < rounds; i++ {
+		answer := bot.pickAnswer(q)
+		assert.Contains(t, q.Options, answer)
+		if answer == q.CorrectAnswer {
+			correct++
+		}
+	}
+	assert.InDelta(t, 0.7, float64(correct)/rounds, 0.03)
+}
+
+func TestIsBotUser(t *testing.T) {
+	for _, bot := range botIdentities {
+		assert.True(t, IsBotUser(bot.UserID))
+	}
+	assert.False(t, IsBotUser(uuid.New()))
+	assert.Equal(t, question.DifficultyMedium, normalizeBotDifficulty("impossible"))
+}
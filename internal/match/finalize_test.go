@@ -0,0 +1,101 @@
+package match
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gokatarajesh/quiz-platform/internal/db/repository"
+	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
+	"github.com/gokatarajesh/quiz-platform/internal/match/scoring"
+)
+
+// fakeMatchStore keeps one match row and counts summary lookups.
+type fakeMatchStore struct {
+	match     sqlcgen.Match
+	summaries int
+}
+
+func (s *fakeMatchStore) CreateMatch(ctx context.Context, arg sqlcgen.CreateMatchParams) (sqlcgen.Match, error) {
+	return s.match, nil
+}
+
+func (s *fakeMatchStore) UpdateMatchStatus(ctx context.Context, arg sqlcgen.UpdateMatchStatusParams) error {
+	s.match.Status = arg.Status
+	return nil
+}
+
+func (s *fakeMatchStore) CreatePlayerMatchState(ctx context.Context, arg sqlcgen.CreatePlayerMatchStateParams) error {
+	return nil
+}
+
+func (s *fakeMatchStore) UpdatePlayerMatchResult(ctx context.Context, arg sqlcgen.UpdatePlayerMatchResultParams) error {
+	return nil
+}
+
+func (s *fakeMatchStore) GetPlayerStatesByMatch(ctx context.Context, matchID pgtype.UUID) ([]sqlcgen.PlayerMatchState, error) {
+	return nil, nil
+}
+
+func (s *fakeMatchStore) GetMatchForSummary(ctx context.Context, matchID pgtype.UUID) (sqlcgen.Match, error) {
+	s.summaries++
+	return s.match, nil
+}
+
+func (s *fakeMatchStore) InsertMatchQuestion(ctx context.Context, arg sqlcgen.InsertMatchQuestionParams) error {
+	return nil
+}
+
+func (s *fakeMatchStore) GetMatchQuestionForPlayer(ctx context.Context, arg sqlcgen.GetMatchQuestionForPlayerParams) (sqlcgen.MatchQuestion, error) {
+	return sqlcgen.MatchQuestion{}, nil
+}
+
+func (s *fakeMatchStore) RevokeLeaderboardEligibility(ctx context.Context, matchID pgtype.UUID) (int64, error) {
+	return 0, nil
+}
+
+func TestFinalizeMatchRecordsHowTheMatchEnded(t *testing.T) {
+	for _, tc := range []struct {
+		reason string
+		status string
+	}{
+		{EndReasonFinished, StatusCompleted},
+		{EndReasonTimeout, StatusTimeout},
+	} {
+		t.Run(tc.reason, func(t *testing.T) {
+			ctx := context.Background()
+			matchID := uuid.New()
+			store := &fakeMatchStore{match: sqlcgen.Match{
+				MatchID:            pgtype.UUID{Bytes: matchID, Valid: true},
+				Mode:               ModeRandom1v1,
+				QuestionCount:      1,
+				PerQuestionSeconds: 10,
+				Status:             StatusActive,
+			}}
+			stateMgr := newTestStateManager(t)
+			svc := &Service{
+				stateMgr:      stateMgr,
+				matchRepo:     repository.NewMatchRepository(store),
+				scoringEngine: scoring.NewEngine(scoring.DefaultScoringConfig()),
+				logger:        zerolog.Nop(),
+			}
+			require.NoError(t, stateMgr.StoreMatchQuestions(ctx, matchID, []QuestionPackItem{{Order: 1, ID: "q1"}}))
+			player := uuid.New()
+			require.NoError(t, stateMgr.StorePlayerState(ctx, matchID, player, PlayerState{MatchID: matchID, UserID: player}))
+
+			payload, err := svc.FinalizeMatch(ctx, matchID, tc.reason)
+			require.NoError(t, err)
+			require.Len(t, payload.Results, 1)
+			assert.Equal(t, tc.status, store.match.Status)
+			assert.Equal(t, 1, store.summaries, "the match summary is loaded once")
+
+			_, err = svc.FinalizeMatch(ctx, matchID, tc.reason)
+			assert.ErrorIs(t, err, ErrMatchFinalized)
+		})
+	}
+}
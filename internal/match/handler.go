@@ -3,7 +3,9 @@ package match
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,22 +18,32 @@ import (
 	httperrors "github.com/gokatarajesh/quiz-platform/pkg/http/errors"
 )
 
+// botOfferDeadlineSeconds is how long a client should show the bot offer before hiding it.
+const botOfferDeadlineSeconds = 15
+
 // Handler manages WebSocket connections and routes match-related messages.
 type Handler struct {
 	service *Service
 	hub     *ws.Hub
 	authSvc *auth.Service
+	runner  *Runner
 	logger  zerolog.Logger
+
+	graceMu     sync.Mutex
+	graceTimers map[uuid.UUID]*time.Timer // user_id -> pending left_early after disconnect
 }
 
 // NewHandler creates a match WebSocket handler.
 func NewHandler(service *Service, hub *ws.Hub, authSvc *auth.Service, logger zerolog.Logger) *Handler {
-	return &Handler{
-		service: service,
-		hub:     hub,
-		authSvc: authSvc,
-		logger:  logger,
+	h := &Handler{
+		service:     service,
+		hub:         hub,
+		authSvc:     authSvc,
+		logger:      logger,
+		graceTimers: make(map[uuid.UUID]*time.Timer),
 	}
+	h.runner = NewRunner(service.stateMgr, hub, h.openQuestion, h.FinalizeAndBroadcastMatch, logger)
+	return h
 }
 
 // HandleConnection processes a new WebSocket connection.
@@ -43,13 +55,115 @@ func (h *Handler) HandleConnection(conn *websocket.Conn, userID uuid.UUID, usern
 	// Start write pump
 	go wsConn.WritePump()
 
+	// Re-attach to an in-progress match, if any
+	h.cancelGrace(userID)
+	if err := h.resumeMatch(context.Background(), userID); err != nil {
+		h.logger.Warn().Err(err).Str("user_id", userID.String()).Msg("failed to resume match")
+	}
+
 	// Handle incoming messages
 	wsConn.ReadPump(func(msg ws.Message) error {
 		return h.handleMessage(context.Background(), userID, username, isGuest, msg)
 	})
 
-	// Cleanup on disconnect
-	h.hub.UnregisterConnection(userID)
+	// Cleanup on disconnect; a superseded connection (reconnect) leaves match state alone
+	if h.hub.UnregisterConnection(userID, wsConn) {
+		h.startGrace(userID)
+	}
+}
+
+// resumeMatch re-joins the user to their active match and sends a match_resume
+// snapshot with the remaining questions, their answers so far and the current clock.
+func (h *Handler) resumeMatch(ctx context.Context, userID uuid.UUID) error {
+	snapshot, err := h.service.ResumeMatch(ctx, userID)
+	if err != nil || snapshot == nil {
+		return err
+	}
+
+	h.hub.JoinMatch(snapshot.Match.ID, userID)
+
+	batch := make([]ws.QuestionPayload, len(snapshot.Questions))
+	for i, q := range snapshot.Questions {
+		batch[i] = ws.QuestionPayload{
+			Order:   q.Order,
+			ID:      q.ID,
+			Prompt:  q.Prompt,
+			Options: q.Options,
+			Token:   q.Token,
+		}
+	}
+	answers := make([]ws.ResumedAnswer, len(snapshot.Answers))
+	for i, ans := range snapshot.Answers {
+		answers[i] = ws.ResumedAnswer{
+			QuestionOrder: ans.QuestionOrder,
+			Answer:        ans.Answer,
+			SubmittedAt:   ans.SubmittedAt.Format(time.RFC3339),
+		}
+	}
+
+	resume := ws.MatchResumePayload{
+		MatchID:              snapshot.Match.ID.String(),
+		Mode:                 snapshot.Match.Mode,
+		QuestionCount:        snapshot.Match.QuestionCount,
+		PerQuestionSeconds:   snapshot.Match.PerQuestionSeconds,
+		GlobalTimeoutSeconds: snapshot.Match.GlobalTimeoutSeconds,
+		StartedAt:            snapshot.StartedAt.Format(time.RFC3339),
+		QuestionOrder:        snapshot.QuestionOrder,
+		RemainingSeconds:     snapshot.RemainingSeconds,
+		Batch:                batch,
+		Answers:              answers,
+	}
+	msg := ws.Message{Type: ws.TypeMatchResume}
+	msg.Payload, _ = json.Marshal(resume)
+
+	h.logger.Info().
+		Str("match_id", snapshot.Match.ID.String()).
+		Str("user_id", userID.String()).
+		Int("question_order", snapshot.QuestionOrder).
+		Msg("player resumed match")
+
+	return h.hub.SendToUser(userID, msg)
+}
+
+// startGrace gives a disconnected player the resume grace window before marking them left_early.
+func (h *Handler) startGrace(userID uuid.UUID) {
+	matchID, err := h.service.ActiveMatch(context.Background(), userID)
+	if err != nil || matchID == uuid.Nil {
+		return
+	}
+
+	h.graceMu.Lock()
+	defer h.graceMu.Unlock()
+
+	if timer, exists := h.graceTimers[userID]; exists {
+		timer.Stop()
+	}
+	h.graceTimers[userID] = time.AfterFunc(h.service.resumeGrace, func() {
+		h.graceMu.Lock()
+		delete(h.graceTimers, userID)
+		h.graceMu.Unlock()
+
+		ctx := context.Background()
+		// The player may have reconnected to another instance
+		if h.hub.IsOnline(ctx, userID) {
+			return
+		}
+
+		if err := h.forfeit(ctx, matchID, userID, LeaveReasonDisconnected); err != nil {
+			h.logger.Warn().Err(err).Str("match_id", matchID.String()).Str("user_id", userID.String()).Msg("failed to mark player left")
+		}
+	})
+}
+
+// cancelGrace stops a pending left_early timer after the player reconnected.
+func (h *Handler) cancelGrace(userID uuid.UUID) {
+	h.graceMu.Lock()
+	defer h.graceMu.Unlock()
+
+	if timer, exists := h.graceTimers[userID]; exists {
+		timer.Stop()
+		delete(h.graceTimers, userID)
+	}
 }
 
 // handleMessage routes incoming WebSocket messages.
@@ -65,12 +179,18 @@ func (h *Handler) handleMessage(ctx context.Context, userID uuid.UUID, username
 		return h.handleJoinPrivate(ctx, userID, username, isGuest, msg.Payload)
 	case ws.TypeReadyState:
 		return h.handleReadyState(ctx, userID, msg.Payload)
+	case ws.TypeStartPrivate:
+		return h.handleStartPrivate(ctx, userID, msg.Payload)
 	case ws.TypeSubmitAnswer:
 		return h.handleSubmitAnswer(ctx, userID, msg.Payload)
 	case ws.TypeLeaveMatch:
 		return h.handleLeaveMatch(ctx, userID, msg.Payload)
 	case ws.TypeRequestProgress:
 		return h.handleRequestProgress(ctx, userID, msg.Payload)
+	case ws.TypeResumeMatch:
+		return h.resumeMatch(ctx, userID)
+	case ws.TypeReportQuestion:
+		return h.handleReportQuestion(ctx, userID, msg.Payload)
 	default:
 		return h.sendError(userID, httperrors.ErrCodeUnknownMessageType, fmt.Sprintf("Unknown message type: %s", msg.Type))
 	}
@@ -88,74 +208,126 @@ func (h *Handler) handleJoinQueue(ctx context.Context, userID uuid.UUID, usernam
 		category = "general"
 	}
 
-	// Enqueue player
-		queueToken, pair, err := h.service.queueMgr.Enqueue(ctx, queue.MatchmakingRequest{
-		UserID:            userID,
-		Username:          username,
-		IsGuest:           isGuest,
-		PreferredCategory: category,
-		BotOK:             true,
+	// Enqueue player; pairing happens in the queue matcher loop (see HandleQueuePair)
+	queueToken, err := h.service.queueMgr.Enqueue(ctx, queue.MatchmakingRequest{
+		UserID:              userID,
+		Username:            username,
+		IsGuest:             isGuest,
+		PreferredCategory:   category,
+		PreferredDifficulty: req.Difficulty,
+		QuestionCount:       req.QuestionCount,
+		Rating:              h.service.queueRating(ctx, userID, isGuest),
+		BotOK:               true,
 	})
 	if err != nil {
 		return h.sendError(userID, httperrors.ErrCodeEnqueueFailed, err.Error())
 	}
 
-	// If match found immediately
-	if pair != nil {
-		questionCount := req.QuestionCount
-		if questionCount == 0 {
-			questionCount = 10 // default
-		}
-		// Validate question count (must be 5, 10, or 15)
-		if questionCount != 5 && questionCount != 10 && questionCount != 15 {
-			questionCount = 10 // fallback to default
-		}
-		match, questions, err := h.service.CreateRandomMatch(ctx, pair, questionCount, 15, category)
-		if err != nil {
-			return h.sendError(userID, httperrors.ErrCodeMatchCreationFailed, err.Error())
-		}
-
-		// Join match in hub
-		h.hub.JoinMatch(match.ID, userID)
-		if pair.Player1.UserID != userID {
-			h.hub.JoinMatch(match.ID, pair.Player1.UserID)
-		}
-		if pair.Player2.UserID != userID {
-			h.hub.JoinMatch(match.ID, pair.Player2.UserID)
-		}
+	// Send queue update
+	update := ws.QueueUpdatePayload{
+		QueueToken:  queueToken.String(),
+		Status:      "waiting",
+		Position:    h.service.queueMgr.GetPosition(ctx, queueToken),
+		WaitSeconds: 0,
+	}
+	msg := ws.Message{Type: ws.TypeQueueUpdate}
+	msg.Payload, _ = json.Marshal(update)
+	if err := h.hub.SendToUser(userID, msg); err != nil {
+		return err
+	}
 
-		// Broadcast match found
-		matchPayload := ws.MatchFoundPayload{
-			MatchID:              match.ID.String(),
-			Mode:                 match.Mode,
-			QuestionCount:        match.QuestionCount,
-			PerQuestionSeconds:   match.PerQuestionSeconds,
-			GlobalTimeoutSeconds: match.GlobalTimeoutSeconds,
-			Players: []ws.Player{
-				{UserID: pair.Player1.UserID.String(), Username: pair.Player1.Username},
-				{UserID: pair.Player2.UserID.String(), Username: pair.Player2.Username},
-			},
-		}
+	h.scheduleBotOffer(userID, queueToken)
+	return nil
+}
 
-		msg := ws.Message{Type: ws.TypeMatchFound}
-		msg.Payload, _ = json.Marshal(matchPayload)
-		h.hub.BroadcastToMatch(match.ID, msg)
+// HandleQueuePair creates and starts a random 1v1 match for a pair produced by the queue matcher.
+func (h *Handler) HandleQueuePair(ctx context.Context, pair *queue.MatchPair) {
+	questionCount := normalizeQuestionCount(pair.QuestionCount)
+	match, _, err := h.service.CreateRandomMatch(ctx, pair, questionCount, 15, pair.Player1.PreferredCategory)
+	if err != nil {
+		h.logger.Error().Err(err).
+			Str("player1", pair.Player1.UserID.String()).
+			Str("player2", pair.Player2.UserID.String()).
+			Msg("failed to create random match")
+		_ = h.sendError(pair.Player1.UserID, httperrors.ErrCodeMatchCreationFailed, err.Error())
+		_ = h.sendError(pair.Player2.UserID, httperrors.ErrCodeMatchCreationFailed, err.Error())
+		return
+	}
 
-		// Send questions
-		h.sendQuestions(match.ID, questions)
-		return nil
+	// Join match in hub
+	h.hub.JoinMatch(match.ID, pair.Player1.UserID)
+	h.hub.JoinMatch(match.ID, pair.Player2.UserID)
+
+	// Broadcast match found
+	matchPayload := ws.MatchFoundPayload{
+		MatchID:              match.ID.String(),
+		Mode:                 match.Mode,
+		QuestionCount:        match.QuestionCount,
+		PerQuestionSeconds:   match.PerQuestionSeconds,
+		GlobalTimeoutSeconds: match.GlobalTimeoutSeconds,
+		Players: []ws.Player{
+			{UserID: pair.Player1.UserID.String(), Username: pair.Player1.Username},
+			{UserID: pair.Player2.UserID.String(), Username: pair.Player2.Username},
+		},
 	}
 
-	// Send queue update
+	msg := ws.Message{Type: ws.TypeMatchFound}
+	msg.Payload, _ = json.Marshal(matchPayload)
+	h.hub.BroadcastToMatch(match.ID, msg)
+
+	// Start the match clock; questions follow as their windows open
+	h.startMatch(ctx, match)
+}
+
+// SendQueueUpdate pushes the current queue position and wait time to a waiting player.
+func (h *Handler) SendQueueUpdate(ctx context.Context, player queue.WaitingPlayer, position int, waited time.Duration) {
 	update := ws.QueueUpdatePayload{
-		QueueToken:  queueToken.String(),
+		QueueToken:  player.QueueToken.String(),
 		Status:      "waiting",
-		Position:    h.service.queueMgr.GetPosition(queueToken),
-		WaitSeconds: 0,
+		Position:    position,
+		WaitSeconds: int(waited.Seconds()),
 	}
 	msg := ws.Message{Type: ws.TypeQueueUpdate}
 	msg.Payload, _ = json.Marshal(update)
-	return h.hub.SendToUser(userID, msg)
+	_ = h.hub.SendToUser(player.UserID, msg)
+}
+
+// SendQueueExpired tells a player their queue entry was dropped for waiting too long.
+func (h *Handler) SendQueueExpired(ctx context.Context, player queue.WaitingPlayer) {
+	expired := ws.QueueExpiredPayload{
+		QueueToken:  player.QueueToken.String(),
+		WaitSeconds: int(time.Since(player.QueuedAt).Seconds()),
+	}
+	msg := ws.Message{Type: ws.TypeQueueExpired}
+	msg.Payload, _ = json.Marshal(expired)
+	_ = h.hub.SendToUser(player.UserID, msg)
+}
+
+// scheduleBotOffer sends a bot_offer once the player has waited past the queue's bot threshold.
+func (h *Handler) scheduleBotOffer(userID uuid.UUID, queueToken uuid.UUID) {
+	time.AfterFunc(h.service.queueMgr.BotWait(), func() {
+		if !h.service.queueMgr.ShouldOfferBot(context.Background(), queueToken) {
+			return
+		}
+
+		offer := ws.BotOfferPayload{
+			QueueToken:      queueToken.String(),
+			DeadlineSeconds: botOfferDeadlineSeconds,
+		}
+		msg := ws.Message{Type: ws.TypeBotOffer}
+		msg.Payload, _ = json.Marshal(offer)
+		if err := h.hub.SendToUser(userID, msg); err != nil {
+			h.logger.Warn().Err(err).Str("user_id", userID.String()).Msg("failed to send bot offer")
+		}
+	})
+}
+
+// normalizeQuestionCount returns a supported question count (5, 10 or 15), defaulting to 10.
+func normalizeQuestionCount(questionCount int) int {
+	if questionCount != 5 && questionCount != 10 && questionCount != 15 {
+		return 10
+	}
+	return questionCount
 }
 
 func (h *Handler) handleCancelQueue(ctx context.Context, userID uuid.UUID, payload json.RawMessage) error {
@@ -173,11 +345,68 @@ func (h *Handler) handleCancelQueue(ctx context.Context, userID uuid.UUID, paylo
 }
 
 func (h *Handler) handleAcceptBotFill(ctx context.Context, userID uuid.UUID, payload json.RawMessage) error {
-	// TODO: Implement bot fill feature
-	// When a player has waited in queue for a configured duration (default 10s),
-	// offer them a bot opponent. If accepted, create a match with a bot player.
-	// Bot should have configurable difficulty and answer questions with realistic timing.
-	return h.sendError(userID, httperrors.ErrCodeFeatureNotAvailable, "Bot fill feature is not yet available")
+	var req ws.AcceptBotFillPayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return h.sendError(userID, httperrors.ErrCodeInvalidPayload, "Invalid accept_bot_fill payload")
+	}
+
+	queueToken, err := uuid.Parse(req.QueueToken)
+	if err != nil {
+		return h.sendError(userID, httperrors.ErrCodeInvalidQueueToken, "Invalid queue token")
+	}
+
+	// Declining keeps the player in the queue
+	if !req.Accept {
+		return nil
+	}
+
+	if !h.service.queueMgr.ShouldOfferBot(ctx, queueToken) {
+		return h.sendError(userID, httperrors.ErrCodeBotNotOffered, "No bot offer for this queue token")
+	}
+
+	player, err := h.service.queueMgr.Claim(ctx, queueToken, userID)
+	if err != nil {
+		return h.sendError(userID, httperrors.ErrCodeQueueTokenNotFound, err.Error())
+	}
+
+	bot, profile := h.service.BotProfile(player.PreferredDifficulty)
+	questionCount := normalizeQuestionCount(player.QuestionCount)
+
+	match, questions, err := h.service.CreateBotMatch(ctx, *player, bot, questionCount, 15, player.PreferredCategory)
+	if err != nil {
+		return h.sendError(userID, httperrors.ErrCodeMatchCreationFailed, err.Error())
+	}
+
+	h.hub.JoinMatch(match.ID, userID)
+
+	matchPayload := ws.MatchFoundPayload{
+		MatchID:              match.ID.String(),
+		Mode:                 match.Mode,
+		QuestionCount:        match.QuestionCount,
+		PerQuestionSeconds:   match.PerQuestionSeconds,
+		GlobalTimeoutSeconds: match.GlobalTimeoutSeconds,
+		Players: []ws.Player{
+			{UserID: player.UserID.String(), Username: player.Username},
+			{UserID: bot.UserID.String(), Username: bot.Username},
+		},
+	}
+	msg := ws.Message{Type: ws.TypeMatchFound}
+	msg.Payload, _ = json.Marshal(matchPayload)
+	h.hub.BroadcastToMatch(match.ID, msg)
+
+	startedAt := h.startMatch(ctx, match)
+
+	botPlayer := NewBot(h.service, bot, profile, h.logger)
+	botCtx, cancel := context.WithTimeout(context.Background(), time.Duration(match.GlobalTimeoutSeconds)*time.Second)
+	go func() {
+		defer cancel()
+		botPlayer.Play(botCtx, match, h.service.QuestionsForPlayer(match, bot.UserID, questions, startedAt), startedAt,
+			func() bool { return h.runner.Running(match.ID) },
+			func() { h.afterAnswer(context.Background(), match.ID, bot.UserID) },
+		)
+	}()
+
+	return nil
 }
 
 func (h *Handler) handleJoinPrivate(ctx context.Context, userID uuid.UUID, username string, isGuest bool, payload json.RawMessage) error {
@@ -191,58 +420,110 @@ func (h *Handler) handleJoinPrivate(ctx context.Context, userID uuid.UUID, usern
 		return h.sendError(userID, httperrors.ErrCodeJoinFailed, err.Error())
 	}
 
-	// Check if this is the first non-host player joining (trigger question generation)
-	wasWaiting := len(room.Players) == 2
-	isNonHost := userID != room.HostID
-
-	if wasWaiting && isNonHost && room.MatchID == nil {
-		// First non-host player joined - generate questions
-		players := make([]RoomPlayer, len(room.Players))
-		for i, p := range room.Players {
-			players[i] = RoomPlayer{
-				UserID:   p.UserID,
-				Username: p.Username,
-				IsGuest:  p.IsGuest,
-				IsHost:   p.IsHost,
-			}
-		}
+	// The match is created when the host starts the room (see handleStartPrivate),
+	// so larger rooms can fill up and ready-check first.
+	h.broadcastRoomUpdate(room)
+	return nil
+}
 
-		match, questions, err := h.service.CreatePrivateMatch(ctx, req.RoomCode, players, room.QuestionCount, room.PerQuestionSeconds, room.Category)
-		if err != nil {
-			return h.sendError(userID, httperrors.ErrCodeMatchCreationFailed, err.Error())
-		}
+func (h *Handler) handleReadyState(ctx context.Context, userID uuid.UUID, payload json.RawMessage) error {
+	var req ws.ReadyStatePayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return h.sendError(userID, httperrors.ErrCodeInvalidPayload, "Invalid ready_state payload")
+	}
+
+	room, err := h.service.roomMgr.SetReady(ctx, req.RoomCode, userID, req.Ready)
+	if err != nil {
+		return h.sendError(userID, httperrors.ErrCodeReadyFailed, err.Error())
+	}
+
+	h.broadcastRoomUpdate(room)
+	return nil
+}
+
+// handleStartPrivate lets the host start a room once enough players are ready.
+// The match is created for the ready players, a countdown is broadcast and the
+// question batch is only sent once the countdown has finished.
+func (h *Handler) handleStartPrivate(ctx context.Context, userID uuid.UUID, payload json.RawMessage) error {
+	var req ws.StartPrivatePayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return h.sendError(userID, httperrors.ErrCodeInvalidPayload, "Invalid start_private payload")
+	}
+
+	room, players, err := h.service.roomMgr.BeginStart(ctx, req.RoomCode, userID)
+	if err != nil {
+		return h.sendError(userID, httperrors.ErrCodeRoomStartFailed, err.Error())
+	}
 
-		// Update room with match ID
-		_, err = h.service.roomMgr.StartRoom(ctx, req.RoomCode, match.ID, room.StartCountdown)
-		if err != nil {
-			return h.sendError(userID, httperrors.ErrCodeRoomStartFailed, err.Error())
+	match, _, err := h.service.CreatePrivateMatch(ctx, req.RoomCode, players, room.QuestionCount, room.PerQuestionSeconds, room.Category, room.ScoringRule)
+	if err != nil {
+		h.service.roomMgr.AbortStart(ctx, req.RoomCode)
+		return h.sendError(userID, httperrors.ErrCodeMatchCreationFailed, err.Error())
+	}
+
+	room, err = h.service.roomMgr.StartRoom(ctx, req.RoomCode, match.ID, room.StartCountdown)
+	if err != nil {
+		// The match never started; don't leave it pending with players pointed at it
+		h.service.roomMgr.AbortStart(ctx, req.RoomCode)
+		if cancelErr := h.service.CancelMatch(ctx, match.ID, players); cancelErr != nil {
+			h.logger.Warn().Err(cancelErr).Str("match_id", match.ID.String()).Msg("failed to cancel unstarted match")
 		}
+		return h.sendError(userID, httperrors.ErrCodeRoomStartFailed, err.Error())
+	}
+
+	for _, p := range players {
+		h.hub.JoinMatch(match.ID, p.UserID)
+	}
+	h.broadcastRoomUpdate(room)
+
+	go h.runCountdown(room.RoomCode, match, room.StartCountdown)
+	return nil
+}
 
-		// Join all players to match in hub
-		for _, p := range room.Players {
-			h.hub.JoinMatch(match.ID, p.UserID)
+// runCountdown broadcasts one countdown message per second and then starts the match.
+func (h *Handler) runCountdown(roomCode string, match *Match, seconds int) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for remaining := seconds; remaining > 0; remaining-- {
+		countdown := ws.CountdownPayload{
+			MatchID: match.ID.String(),
+			Seconds: remaining,
 		}
+		msg := ws.Message{Type: ws.TypeCountdown}
+		msg.Payload, _ = json.Marshal(countdown)
+		h.hub.BroadcastToMatch(match.ID, msg)
+		<-ticker.C
+	}
 
-		// Store questions in room state (they'll be sent when match actually starts)
-		// For now, we'll send them immediately after countdown
-		// TODO: Implement countdown logic and send questions after countdown
-		h.sendQuestions(match.ID, questions)
+	ctx := context.Background()
+	if err := h.service.roomMgr.ActivateRoom(ctx, roomCode); err != nil {
+		h.logger.Warn().Err(err).Str("room_code", roomCode).Msg("failed to activate room")
 	}
+	h.startMatch(ctx, match)
+}
 
-	// Convert players
+// broadcastRoomUpdate sends the current room state to every player in the room.
+func (h *Handler) broadcastRoomUpdate(room *PrivateRoom) {
 	wsPlayers := make([]ws.Player, len(room.Players))
 	for i, p := range room.Players {
 		wsPlayers[i] = ws.Player{
 			UserID:   p.UserID.String(),
 			Username: p.Username,
+			IsHost:   p.IsHost,
+			Ready:    p.Ready,
 		}
 	}
 
 	update := ws.PrivateRoomUpdatePayload{
-		MatchID:        "",
-		RoomCode:       room.RoomCode,
-		Players:        wsPlayers,
-		SlotsRemaining: room.MaxPlayers - len(room.Players),
+		MatchID:         "",
+		RoomCode:        room.RoomCode,
+		Status:          room.Status,
+		Players:         wsPlayers,
+		SlotsRemaining:  room.MaxPlayers - len(room.Players),
+		ReadyCount:      room.ReadyCount(),
+		MinReadyPlayers: room.MinReadyPlayers,
+		CanStart:        room.Status == RoomStatusWaiting && room.CanStart(),
 	}
 	if room.MatchID != nil {
 		update.MatchID = room.MatchID.String()
@@ -250,15 +531,9 @@ func (h *Handler) handleJoinPrivate(ctx context.Context, userID uuid.UUID, usern
 
 	msg := ws.Message{Type: ws.TypePrivateRoomUpdate}
 	msg.Payload, _ = json.Marshal(update)
-	return h.hub.SendToUser(userID, msg)
-}
-
-func (h *Handler) handleReadyState(ctx context.Context, userID uuid.UUID, payload json.RawMessage) error {
-	// TODO: Implement ready state feature for private rooms
-	// Both players should be able to indicate they're ready before the match starts.
-	// Once both players are ready, start a countdown and then begin the match.
-	// This allows players to prepare before questions are sent.
-	return h.sendError(userID, httperrors.ErrCodeFeatureNotAvailable, "Ready state feature is not yet available")
+	for _, p := range room.Players {
+		_ = h.hub.SendToUser(p.UserID, msg)
+	}
 }
 
 func (h *Handler) handleSubmitAnswer(ctx context.Context, userID uuid.UUID, payload json.RawMessage) error {
@@ -273,20 +548,51 @@ func (h *Handler) handleSubmitAnswer(ctx context.Context, userID uuid.UUID, payl
 	}
 
 	submittedAt := time.Now()
-	if err := h.service.SubmitAnswer(ctx, matchID, userID, req.QuestionToken, req.Answer, submittedAt); err != nil {
-		return h.sendError(userID, httperrors.ErrCodeSubmitFailed, err.Error())
+	questionOrder, err := h.service.SubmitAnswer(ctx, matchID, userID, req.QuestionToken, req.Answer, req.OptionIndex, submittedAt)
+	if err != nil {
+		code := httperrors.ErrCodeSubmitFailed
+		switch {
+		case errors.Is(err, ErrInvalidQuestionToken):
+			code = httperrors.ErrCodeInvalidQuestionToken
+		case errors.Is(err, ErrInvalidOption):
+			code = httperrors.ErrCodeInvalidOption
+		case errors.Is(err, ErrAnswerTooLate), errors.Is(err, ErrQuestionTokenExpired):
+			code = httperrors.ErrCodeAnswerTooLate
+		case errors.Is(err, ErrQuestionNotActive):
+			code = httperrors.ErrCodeQuestionNotActive
+		}
+		return h.sendError(userID, code, err.Error())
 	}
 
 	// Send acknowledgment
 	ack := ws.AnswerAckPayload{
 		MatchID:          req.MatchID,
-		QuestionOrder:    0, // TODO: extract from token
+		QuestionOrder:    questionOrder,
 		Accepted:         true,
 		ServerReceivedAt: submittedAt.Format(time.RFC3339),
 	}
 	msg := ws.Message{Type: ws.TypeAnswerAck}
 	msg.Payload, _ = json.Marshal(ack)
-	return h.hub.SendToUser(userID, msg)
+	if err := h.hub.SendToUser(userID, msg); err != nil {
+		return err
+	}
+
+	h.afterAnswer(ctx, matchID, userID)
+	return nil
+}
+
+// afterAnswer pushes progress to the whole match and lets the runner check for completion.
+func (h *Handler) afterAnswer(ctx context.Context, matchID uuid.UUID, userID uuid.UUID) {
+	progress, err := h.service.Progress(ctx, matchID, userID)
+	if err != nil {
+		h.logger.Warn().Err(err).Str("match_id", matchID.String()).Msg("failed to load match progress")
+	} else {
+		msg := ws.Message{Type: ws.TypeProgressUpdate}
+		msg.Payload, _ = json.Marshal(progress)
+		h.hub.BroadcastToMatch(matchID, msg)
+	}
+
+	h.runner.Poke(matchID)
 }
 
 func (h *Handler) handleLeaveMatch(ctx context.Context, userID uuid.UUID, payload json.RawMessage) error {
@@ -300,18 +606,49 @@ func (h *Handler) handleLeaveMatch(ctx context.Context, userID uuid.UUID, payloa
 		return h.sendError(userID, httperrors.ErrCodeInvalidMatchID, "Invalid match ID")
 	}
 
+	if err := h.forfeit(ctx, matchID, userID, LeaveReasonQuit); err != nil {
+		return h.sendError(userID, httperrors.ErrCodeLeaveFailed, err.Error())
+	}
+	return nil
+}
+
+// forfeit records that a player left the match, tells the remaining players and
+// lets the runner finalize the match if fewer than two players are left.
+func (h *Handler) forfeit(ctx context.Context, matchID uuid.UUID, userID uuid.UUID, reason string) error {
+	state, err := h.service.MarkLeftEarly(ctx, matchID, userID, reason)
 	h.hub.LeaveMatch(matchID, userID)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return nil
+	}
+
+	left := ws.PlayerLeftPayload{
+		MatchID:  matchID.String(),
+		UserID:   userID.String(),
+		Username: state.Username,
+		Reason:   reason,
+	}
+	msg := ws.Message{Type: ws.TypePlayerLeft}
+	msg.Payload, _ = json.Marshal(left)
+	h.hub.BroadcastToMatch(matchID, msg)
+
+	h.runner.Poke(matchID)
 	return nil
 }
 
 // FinalizeAndBroadcastMatch finalizes a match and broadcasts results to all players.
 // This should be called when a match ends (all questions answered or timeout).
-func (h *Handler) FinalizeAndBroadcastMatch(ctx context.Context, matchID uuid.UUID) error {
-	payload, err := h.service.FinalizeMatch(ctx, matchID)
+func (h *Handler) FinalizeAndBroadcastMatch(ctx context.Context, matchID uuid.UUID, reason string) error {
+	payload, err := h.service.FinalizeMatch(ctx, matchID, reason)
 	if err != nil {
-		h.logger.Error().Err(err).Str("match_id", matchID.String()).Msg("failed to finalize match")
+		if !errors.Is(err, ErrMatchFinalized) {
+			h.logger.Error().Err(err).Str("match_id", matchID.String()).Msg("failed to finalize match")
+		}
 		return err
 	}
+	h.runner.Stop(matchID)
 
 	// Broadcast match complete event to all players in the match
 	msg := ws.Message{Type: ws.TypeMatchComplete}
@@ -332,11 +669,75 @@ func (h *Handler) handleRequestProgress(ctx context.Context, userID uuid.UUID, p
 		return h.sendError(userID, httperrors.ErrCodeInvalidPayload, "Invalid request_progress payload")
 	}
 
-	// TODO: fetch and send progress
-	return nil
+	matchID, err := uuid.Parse(req.MatchID)
+	if err != nil {
+		return h.sendError(userID, httperrors.ErrCodeInvalidMatchID, "Invalid match ID")
+	}
+
+	progress, err := h.service.Progress(ctx, matchID, userID)
+	if err != nil {
+		return h.sendError(userID, httperrors.ErrCodeProgressFailed, err.Error())
+	}
+
+	msg := ws.Message{Type: ws.TypeProgressUpdate}
+	msg.Payload, _ = json.Marshal(progress)
+	return h.hub.SendToUser(userID, msg)
+}
+
+func (h *Handler) handleReportQuestion(ctx context.Context, userID uuid.UUID, payload json.RawMessage) error {
+	var req ws.ReportQuestionPayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return h.sendError(userID, httperrors.ErrCodeInvalidPayload, "Invalid report_question payload")
+	}
+
+	matchID, err := uuid.Parse(req.MatchID)
+	if err != nil {
+		return h.sendError(userID, httperrors.ErrCodeInvalidMatchID, "Invalid match ID")
+	}
+
+	report, err := h.service.ReportQuestion(ctx, userID, matchID, req.QuestionToken, req.Reason, req.Text)
+	if err != nil {
+		code, _ := reportErrorCode(err)
+		return h.sendError(userID, code, err.Error())
+	}
+
+	ack := ws.ReportReceivedPayload{
+		MatchID:       req.MatchID,
+		QuestionToken: req.QuestionToken,
+		ReportID:      report.ID.String(),
+	}
+	msg := ws.Message{Type: ws.TypeReportReceived}
+	msg.Payload, _ = json.Marshal(ack)
+	return h.hub.SendToUser(userID, msg)
 }
 
-func (h *Handler) sendQuestions(matchID uuid.UUID, questions []QuestionPackItem) {
+// startMatch marks the match active and starts its clock; the runner then issues
+// each question as its window opens. Returns the start of the match clock.
+func (h *Handler) startMatch(ctx context.Context, match *Match) time.Time {
+	startedAt := time.Now()
+
+	if err := h.service.StartMatch(ctx, match.ID, startedAt); err != nil {
+		h.logger.Warn().Err(err).Str("match_id", match.ID.String()).Msg("failed to mark match active")
+	}
+
+	h.runner.Start(match, startedAt)
+	return startedAt
+}
+
+// openQuestion sends every player the question whose window just opened.
+func (h *Handler) openQuestion(ctx context.Context, match Match, startedAt time.Time, order int) {
+	openedAt := time.Now()
+	batches, err := h.service.OpenQuestion(ctx, &match, startedAt, order, openedAt)
+	if err != nil {
+		h.logger.Warn().Err(err).Str("match_id", match.ID.String()).Int("question_order", order).Msg("failed to issue question")
+	}
+	for userID, batch := range batches {
+		h.sendQuestions(&match, userID, batch, openedAt)
+	}
+}
+
+// sendQuestions sends a player a question batch carrying their own tokens.
+func (h *Handler) sendQuestions(match *Match, userID uuid.UUID, questions []QuestionPackItem, issuedAt time.Time) {
 	wsQuestions := make([]ws.QuestionPayload, len(questions))
 	for i, q := range questions {
 		wsQuestions[i] = ws.QuestionPayload{
@@ -350,15 +751,15 @@ func (h *Handler) sendQuestions(matchID uuid.UUID, questions []QuestionPackItem)
 	}
 
 	batch := ws.QuestionBatchPayload{
-		MatchID:  matchID.String(),
+		MatchID:  match.ID.String(),
 		Batch:    wsQuestions,
-		Seed:     "", // TODO: get from match
-		IssuedAt: time.Now().Format(time.RFC3339),
+		Seed:     match.SeedHash,
+		IssuedAt: issuedAt.Format(time.RFC3339),
 	}
 
 	msg := ws.Message{Type: ws.TypeQuestionBatch}
 	msg.Payload, _ = json.Marshal(batch)
-	h.hub.BroadcastToMatch(matchID, msg)
+	_ = h.hub.SendToUser(userID, msg)
 }
 
 func (h *Handler) sendError(userID uuid.UUID, code, message string) error {
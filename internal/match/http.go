@@ -2,13 +2,19 @@ package match
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 
 	"github.com/gokatarajesh/quiz-platform/internal/auth/jwt"
+	"github.com/gokatarajesh/quiz-platform/internal/match/scoring"
+	"github.com/gokatarajesh/quiz-platform/internal/moderation"
 	httperrors "github.com/gokatarajesh/quiz-platform/pkg/http/errors"
+	"github.com/gokatarajesh/quiz-platform/pkg/http/ws"
 )
 
 // HTTPHandlers provides REST endpoints for match operations.
@@ -72,7 +78,9 @@ func (h *HTTPHandlers) CreateRoom(w http.ResponseWriter, r *http.Request) {
 		MaxPlayers:         req.MaxPlayers,
 		QuestionCount:      req.QuestionCount,
 		PerQuestionSeconds: req.PerQuestionSeconds,
+		MinReadyPlayers:    req.MinReadyPlayers,
 		Category:           req.Category,
+		ScoringRule:        req.ScoringRule,
 	}
 
 	// Create room
@@ -118,7 +126,7 @@ func (h *HTTPHandlers) GetRoom(w http.ResponseWriter, r *http.Request) {
 	// Get room
 	room, err := h.service.GetRoom(r.Context(), roomCode)
 	if err != nil {
-		if err.Error() == "room not found" {
+		if errors.Is(err, ErrRoomNotFound) {
 			httperrors.RespondNotFound(w, httperrors.ErrCodeRoomNotFound, "Room not found")
 			return
 		}
@@ -133,14 +141,78 @@ func (h *HTTPHandlers) GetRoom(w http.ResponseWriter, r *http.Request) {
 	h.respondJSON(w, http.StatusOK, response)
 }
 
+// ReportQuestion handles POST /v1/matches/{match_id}/reports for reporting a
+// question after the match, with {"question_token", "reason", "text"}.
+func (h *HTTPHandlers) ReportQuestion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httperrors.RespondError(w, http.StatusMethodNotAllowed, httperrors.ErrCodeInvalidRequest, "Method not allowed")
+		return
+	}
+
+	claims, ok := r.Context().Value("claims").(*jwt.Claims)
+	if !ok || claims == nil {
+		httperrors.RespondUnauthorized(w, httperrors.ErrCodeAuthenticationRequired, "Authentication required")
+		return
+	}
+
+	matchID, err := uuid.Parse(r.PathValue("match_id"))
+	if err != nil {
+		httperrors.RespondValidationError(w, httperrors.ErrCodeInvalidMatchID, "Invalid match ID", "match_id")
+		return
+	}
+
+	var req ws.ReportQuestionPayload
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperrors.RespondBadRequest(w, httperrors.ErrCodeInvalidRequest, "Invalid JSON payload")
+		return
+	}
+	if req.QuestionToken == "" {
+		httperrors.RespondValidationError(w, httperrors.ErrCodeMissingField, "question_token is required", "question_token")
+		return
+	}
+
+	report, err := h.service.ReportQuestion(r.Context(), claims.UserID, matchID, req.QuestionToken, req.Reason, req.Text)
+	if err != nil {
+		code, status := reportErrorCode(err)
+		if status == http.StatusInternalServerError {
+			h.logger.Error().Err(err).Str("match_id", matchID.String()).Str("user_id", claims.UserID.String()).Msg("failed to report question")
+		}
+		httperrors.RespondError(w, status, code, err.Error())
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, report)
+}
+
+// reportErrorCode maps a ReportQuestion error to an error code and HTTP status.
+func reportErrorCode(err error) (string, int) {
+	switch {
+	case errors.Is(err, ErrQuestionNotInMatch):
+		return httperrors.ErrCodeQuestionNotFound, http.StatusNotFound
+	case errors.Is(err, moderation.ErrAlreadyReported):
+		return httperrors.ErrCodeAlreadyReported, http.StatusConflict
+	case errors.Is(err, moderation.ErrInvalidReport):
+		return httperrors.ErrCodeInvalidReport, http.StatusBadRequest
+	default:
+		return httperrors.ErrCodeReportFailed, http.StatusInternalServerError
+	}
+}
+
+// maxRoomPlayers caps the size of a private room.
+const maxRoomPlayers = 8
+
 // validateCreateRoomRequest validates the CreateRoomRequest payload.
 func (h *HTTPHandlers) validateCreateRoomRequest(req *CreateRoomRequest) error {
 	if req.MatchName == "" {
 		return &ValidationError{Field: "match_name", Message: "match_name is required"}
 	}
 
-	if req.MaxPlayers != 2 {
-		return &ValidationError{Field: "max_players", Message: "max_players must be 2 for 1v1 matches"}
+	if req.MaxPlayers < 2 || req.MaxPlayers > maxRoomPlayers {
+		return &ValidationError{Field: "max_players", Message: fmt.Sprintf("max_players must be between 2 and %d", maxRoomPlayers)}
+	}
+
+	if req.MinReadyPlayers != 0 && (req.MinReadyPlayers < 2 || req.MinReadyPlayers > req.MaxPlayers) {
+		return &ValidationError{Field: "min_ready_players", Message: "min_ready_players must be between 2 and max_players"}
 	}
 
 	if req.QuestionCount != 5 && req.QuestionCount != 10 && req.QuestionCount != 15 {
@@ -151,6 +223,10 @@ func (h *HTTPHandlers) validateCreateRoomRequest(req *CreateRoomRequest) error {
 		return &ValidationError{Field: "per_question_seconds", Message: "per_question_seconds must be a positive integer"}
 	}
 
+	if req.ScoringRule != "" && !scoring.ValidRule(req.ScoringRule) {
+		return &ValidationError{Field: "scoring_rule", Message: "scoring_rule must be one of " + strings.Join(scoring.RuleNames(), ", ")}
+	}
+
 	return nil
 }
 
@@ -162,6 +238,7 @@ func (h *HTTPHandlers) roomToResponse(roomCode string, room *PrivateRoom) map[st
 			"user_id":   p.UserID.String(),
 			"username":  p.Username,
 			"is_host":   p.IsHost,
+			"ready":     p.Ready,
 			"joined_at": p.JoinedAt.Format("2006-01-02T15:04:05Z07:00"),
 		}
 	}
@@ -173,6 +250,8 @@ func (h *HTTPHandlers) roomToResponse(roomCode string, room *PrivateRoom) map[st
 		"question_count":      room.QuestionCount,
 		"per_question_seconds": room.PerQuestionSeconds,
 		"category":            room.Category,
+		"min_ready_players":   room.MinReadyPlayers,
+		"scoring_rule":        room.ScoringRule,
 		"status":              room.Status,
 		"players":             players,
 		"slots_remaining":     room.MaxPlayers - len(room.Players),
@@ -0,0 +1,171 @@
+package match
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/gokatarajesh/quiz-platform/internal/anticheat"
+	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
+	"github.com/gokatarajesh/quiz-platform/internal/leaderboard"
+)
+
+// ErrMatchNotFinished is returned when invalidating a match that has not ended.
+var ErrMatchNotFinished = errors.New("match has not finished")
+
+// InvalidateMatch marks a finished match leaderboard-ineligible and takes the
+// results it recorded back off the leaderboard it went to. Results are rebuilt
+// from the stored player states, so a score re-marked by moderation since is
+// reversed at its current value. A rated match also has its rating changes
+// voided. A match that was already ineligible is left alone, apart from voiding
+// ratings an earlier invalidation did not get to. Returns the number of player
+// results reversed.
+func (s *Service) InvalidateMatch(ctx context.Context, matchID uuid.UUID) (int, error) {
+	meta, err := s.matchRepo.GetSummary(ctx, matchID)
+	if err != nil {
+		return 0, fmt.Errorf("load match: %w", err)
+	}
+	if meta.Status != StatusCompleted && meta.Status != StatusTimeout {
+		return 0, ErrMatchNotFinished
+	}
+
+	revoked, err := s.matchRepo.RevokeEligibility(ctx, matchID)
+	if err != nil {
+		return 0, fmt.Errorf("revoke eligibility: %w", err)
+	}
+	if revoked {
+		s.logger.Warn().Str("match_id", matchID.String()).Msg("match leaderboard eligibility revoked")
+	}
+	// Voided once whatever happened before: the rating history rows record it
+	if meta.Mode == ModeRandom1v1 {
+		if err := s.voidRatings(ctx, matchID, int(meta.QuestionCount)); err != nil {
+			return 0, err
+		}
+	}
+	// Bot matches never reached a leaderboard
+	if !revoked || s.leaderboard == nil || meta.Mode == ModeBotFill {
+		return 0, nil
+	}
+	roomCode := metadataRoomCode(meta.Metadata)
+	if meta.Mode == ModePrivateRoom && roomCode == "" {
+		return 0, nil
+	}
+
+	rows, err := s.matchRepo.ListPlayerStates(ctx, pgtype.UUID{Bytes: matchID, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("load player states: %w", err)
+	}
+
+	reversed := 0
+	for _, req := range recordedResults(matchID, rows, int(meta.QuestionCount)) {
+		// Taken out of the daily, weekly and monthly periods the match counted in
+		if meta.CompletedAt.Valid {
+			req.RecordedAt = meta.CompletedAt.Time
+		}
+		if meta.Mode == ModePrivateRoom {
+			err = s.leaderboard.ReversePrivateRoomResult(ctx, roomCode, req)
+		} else {
+			err = s.leaderboard.ReverseResult(ctx, req)
+		}
+		if err != nil {
+			return reversed, fmt.Errorf("reverse result for %s: %w", req.UserID, err)
+		}
+		reversed++
+	}
+	return reversed, nil
+}
+
+// recordedResults rebuilds the leaderboard results FinalizeMatch recorded for a
+// match from its stored player states.
+func recordedResults(matchID uuid.UUID, rows []sqlcgen.PlayerMatchState, questionCount int) []leaderboard.RecordRequest {
+	states := make([]PlayerState, 0, len(rows))
+	for _, row := range rows {
+		if !row.FinalScore.Valid {
+			continue
+		}
+		score := int(row.FinalScore.Int32)
+		state := PlayerState{UserID: uuid.UUID(row.UserID.Bytes), IsGuest: row.IsGuest, FinalScore: &score}
+		if row.LeftAt.Valid {
+			leftAt := row.LeftAt.Time
+			state.LeftAt = &leftAt
+		}
+		_ = json.Unmarshal(row.Answers, &state.Answers)
+		states = append(states, state)
+	}
+	winners := decideWinners(states)
+
+	var reqs []leaderboard.RecordRequest
+	for _, state := range states {
+		if state.IsGuest || IsBotUser(state.UserID) {
+			continue
+		}
+		score := *state.FinalScore
+		if state.LeftAt != nil {
+			score = 0
+		}
+		correct := 0
+		for _, ans := range state.Answers {
+			if ans.IsCorrect {
+				correct++
+			}
+		}
+		reqs = append(reqs, leaderboard.RecordRequest{
+			UserID:        state.UserID,
+			Score:         score,
+			CorrectCount:  correct,
+			QuestionCount: questionCount,
+			Won:           winners[state.UserID],
+			MatchID:       matchID,
+			Eligible:      true,
+		})
+	}
+	return reqs
+}
+
+// metadataRoomCode returns the private room code stored in a match's metadata.
+func metadataRoomCode(metadata []byte) string {
+	if len(metadata) == 0 {
+		return ""
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(metadata, &fields); err != nil {
+		return ""
+	}
+	roomCode, _ := fields["room_code"].(string)
+	return roomCode
+}
+
+// inspectForCheats hands a finished match's human players to the anti-cheat
+// detectors. It runs in the background so finalizing is not held up.
+func (s *Service) inspectForCheats(matchID uuid.UUID, mode string, eligible bool, states []PlayerState) {
+	if s.cheats == nil {
+		return
+	}
+	m := anticheat.Match{
+		ID:                  matchID,
+		Matchmade:           mode == ModeRandom1v1,
+		LeaderboardEligible: eligible,
+	}
+	for _, state := range states {
+		if state.IsBot {
+			continue
+		}
+		m.Players = append(m.Players, anticheat.Player{UserID: state.UserID, Answers: toScoringAnswers(state.Answers)})
+	}
+	if len(m.Players) == 0 {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if _, err := s.cheats.Inspect(ctx, m); err != nil {
+			s.logger.Warn().Err(err).Str("match_id", matchID.String()).Msg("anti-cheat inspection failed")
+		}
+	}()
+}
@@ -0,0 +1,62 @@
+package match
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidOption is returned for an option index outside the question's options.
+var ErrInvalidOption = errors.New("option index out of range")
+
+// shuffleOptions returns options in the order a player is shown them. The order is
+// derived from the match seed and question order (and the player when perPlayer is
+// set), so the same batch is reproduced on reconnect and when answers are checked.
+// The seed is keyed with the server secret: clients see the seed, and must not be
+// able to rebuild the permutation from it.
+func shuffleOptions(secret []byte, options []string, seedHash string, order int, userID uuid.UUID, perPlayer bool) []string {
+	key := fmt.Sprintf("%s:%d", seedHash, order)
+	if perPlayer {
+		key += ":" + userID.String()
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(key))
+	sum := mac.Sum(nil)
+	rng := rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(sum[:8]))))
+
+	shuffled := append([]string(nil), options...)
+	rng.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return shuffled
+}
+
+// resolveAnswer returns the option a submission selects. optionIndex refers to the
+// options as shown to the player. Text answers from older clients match an option
+// ignoring case and extra whitespace, and are kept as typed when none matches.
+func resolveAnswer(shown []string, optionIndex *int, text string) (string, error) {
+	if optionIndex != nil {
+		if *optionIndex < 0 || *optionIndex >= len(shown) {
+			return "", ErrInvalidOption
+		}
+		return shown[*optionIndex], nil
+	}
+
+	normalized := normalizeAnswer(text)
+	for _, opt := range shown {
+		if normalizeAnswer(opt) == normalized {
+			return opt, nil
+		}
+	}
+	return strings.TrimSpace(text), nil
+}
+
+func normalizeAnswer(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
@@ -0,0 +1,59 @@
+package match
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShuffleOptionsIsSeeded(t *testing.T) {
+	options := []string{"Jupiter", "Mars", "Venus", "Earth", "Saturn", "Mercury"}
+	alice, bob := uuid.New(), uuid.New()
+	secret := []byte("test-secret")
+
+	shown := shuffleOptions(secret, options, "seed-1", 1, alice, false)
+	assert.ElementsMatch(t, options, shown)
+	assert.Equal(t, []string{"Jupiter", "Mars", "Venus", "Earth", "Saturn", "Mercury"}, options)
+
+	// Same seed and order: same permutation, and shared by every player unless per player
+	assert.Equal(t, shown, shuffleOptions(secret, options, "seed-1", 1, alice, false))
+	assert.Equal(t, shown, shuffleOptions(secret, options, "seed-1", 1, bob, false))
+
+	orders := map[string]bool{}
+	for order := 1; order <= 10; order++ {
+		orders[shuffleOptions(secret, options, "seed-1", order, alice, false)[0]] = true
+	}
+	assert.Greater(t, len(orders), 1, "different questions should not share one layout")
+	assert.Equal(t, shuffleOptions(secret, options, "seed-1", 1, alice, true), shuffleOptions(secret, options, "seed-1", 1, alice, true))
+
+	// The seed is public; without the server secret the layout can't be rebuilt
+	layouts := map[string]bool{}
+	for i := range 10 {
+		layouts[shuffleOptions([]byte(fmt.Sprintf("other-%d", i)), options, "seed-1", 1, alice, false)[0]] = true
+	}
+	assert.Greater(t, len(layouts), 1, "the layout should depend on the secret")
+}
+
+func TestResolveAnswer(t *testing.T) {
+	shown := []string{"Mars", "New York City", "Jupiter"}
+
+	idx := 2
+	answer, err := resolveAnswer(shown, &idx, "")
+	require.NoError(t, err)
+	assert.Equal(t, "Jupiter", answer)
+
+	idx = 3
+	_, err = resolveAnswer(shown, &idx, "")
+	assert.ErrorIs(t, err, ErrInvalidOption)
+
+	answer, err = resolveAnswer(shown, nil, "  new  york city ")
+	require.NoError(t, err)
+	assert.Equal(t, "New York City", answer)
+
+	answer, err = resolveAnswer(shown, nil, " Pluto ")
+	require.NoError(t, err)
+	assert.Equal(t, "Pluto", answer)
+}
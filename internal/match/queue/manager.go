@@ -2,8 +2,9 @@ package queue
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,13 +12,101 @@ import (
 	"github.com/rs/zerolog"
 )
 
-// Manager handles random 1v1 matchmaking queue with Redis-backed persistence.
+// Redis layout:
+//
+//	queue:entries              hash  queue_token -> WaitingPlayer JSON
+//	queue:waiting:{category}   zset  queue_token scored by enqueue time (ms), FIFO order
+//	queue:categories           set   categories that currently have waiting players
+//	queue:user:{user_id}       string the user's one waiting queue_token
+//	queue:matcher:lock         string held by the instance running the current matcher tick
+//
+// The scripts below derive queue:waiting and queue:user keys from the stored
+// entries, so they keep the formats in sync with waitingKey and userKey.
+const (
+	entriesKey     = "queue:entries"
+	categoriesKey  = "queue:categories"
+	matcherLockKey = "queue:matcher:lock"
+
+	// pairScanWindow bounds how many waiting players are considered per pairing.
+	pairScanWindow = 50
+	// pairClaimAttempts caps retries when a chosen player leaves before being claimed.
+	pairClaimAttempts = 3
+	// maxQueueAge drops entries of players who vanished without cancelling.
+	maxQueueAge = 5 * time.Minute
+)
+
+// ErrTokenNotFound is returned when a queue token is unknown (or belongs to another user).
+var ErrTokenNotFound = errors.New("queue token not found")
+
+// enqueueScript adds an entry and makes it the user's only one: an earlier entry
+// of the same user (a double click, a second tab) is removed first. Returns the
+// replaced queue token, if any.
+var enqueueScript = redis.NewScript(`
+local old = redis.call("GET", KEYS[4])
+if old then
+	local raw = redis.call("HGET", KEYS[1], old)
+	if raw then
+		redis.call("HDEL", KEYS[1], old)
+		redis.call("ZREM", "queue:waiting:" .. cjson.decode(raw)["PreferredCategory"], old)
+	end
+end
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[2])
+redis.call("ZADD", KEYS[2], ARGV[3], ARGV[1])
+redis.call("SADD", KEYS[3], ARGV[4])
+redis.call("SET", KEYS[4], ARGV[1], "PX", ARGV[5])
+return old or false
+`)
+
+// claimPairScript atomically removes two chosen players from the queue, but only
+// if both are still waiting.
+var claimPairScript = redis.NewScript(`
+if redis.call("HEXISTS", KEYS[2], ARGV[1]) == 0 or redis.call("HEXISTS", KEYS[2], ARGV[2]) == 0 then
+	return 0
+end
+for i = 1, 2 do
+	local userKey = "queue:user:" .. cjson.decode(redis.call("HGET", KEYS[2], ARGV[i]))["UserID"]
+	if redis.call("GET", userKey) == ARGV[i] then
+		redis.call("DEL", userKey)
+	end
+end
+redis.call("ZREM", KEYS[1], ARGV[1], ARGV[2])
+redis.call("HDEL", KEYS[2], ARGV[1], ARGV[2])
+return 1
+`)
+
+// removeScript removes a single entry, optionally only if it belongs to ARGV[2].
+var removeScript = redis.NewScript(`
+local raw = redis.call("HGET", KEYS[1], ARGV[1])
+if not raw then
+	return false
+end
+local userID = cjson.decode(raw)["UserID"]
+if ARGV[2] ~= "" and userID ~= ARGV[2] then
+	return false
+end
+redis.call("HDEL", KEYS[1], ARGV[1])
+redis.call("ZREM", KEYS[2], ARGV[1])
+if redis.call("GET", "queue:user:" .. userID) == ARGV[1] then
+	redis.call("DEL", "queue:user:" .. userID)
+end
+return raw
+`)
+
+// forgetCategoryScript drops a category from the index only if its queue is empty.
+var forgetCategoryScript = redis.NewScript(`
+if redis.call("ZCARD", KEYS[2]) == 0 then
+	redis.call("SREM", KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+// Manager handles the random 1v1 matchmaking queue. All state lives in Redis so
+// players connected to different instances can be paired with each other.
 type Manager struct {
 	redis      *redis.Client
 	logger     zerolog.Logger
-	mu         sync.RWMutex
-	waiting    map[uuid.UUID]*WaitingPlayer
 	botWaitSec int // seconds before offering bot (default 10)
+	bands      Bands
 }
 
 // WaitingPlayer represents a queued player.
@@ -27,114 +116,312 @@ type WaitingPlayer struct {
 	IsGuest             bool
 	PreferredCategory   string
 	PreferredDifficulty string
+	QuestionCount       int
+	Rating              int // skill rating at enqueue time (guests get the starting rating)
 	BotOK               bool
 	QueuedAt            time.Time
 	QueueToken          uuid.UUID
 }
 
-// NewManager creates a matchmaking queue manager.
-func NewManager(redis *redis.Client, logger zerolog.Logger, botWaitSeconds int) *Manager {
+// NewManager creates a matchmaking queue manager. Zero band fields take the
+// DefaultBands values.
+func NewManager(redis *redis.Client, logger zerolog.Logger, botWaitSeconds int, bands Bands) *Manager {
 	if botWaitSeconds <= 0 {
 		botWaitSeconds = 10
 	}
+	defaults := DefaultBands()
+	if bands.Base <= 0 {
+		bands.Base = defaults.Base
+	}
+	if bands.PerSecond <= 0 {
+		bands.PerSecond = defaults.PerSecond
+	}
+	if bands.Max <= 0 {
+		bands.Max = defaults.Max
+	}
 	return &Manager{
 		redis:      redis,
 		logger:     logger,
-		waiting:    make(map[uuid.UUID]*WaitingPlayer),
 		botWaitSec: botWaitSeconds,
+		bands:      bands,
 	}
 }
 
-// Enqueue adds a player to the queue and attempts immediate matchmaking.
-// Returns queue token and whether a match was found immediately.
-func (m *Manager) Enqueue(ctx context.Context, req MatchmakingRequest) (uuid.UUID, *MatchPair, error) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
+// Enqueue adds a player to the queue, replacing any entry they already have so
+// they can only be paired once. Pairing happens in the Matcher loop.
+func (m *Manager) Enqueue(ctx context.Context, req MatchmakingRequest) (uuid.UUID, error) {
 	queueToken := uuid.New()
-	player := &WaitingPlayer{
+	player := WaitingPlayer{
 		UserID:              req.UserID,
 		Username:            req.Username,
 		IsGuest:             req.IsGuest,
-		PreferredCategory:   req.PreferredCategory,
+		PreferredCategory:   normalizeCategory(req.PreferredCategory),
 		PreferredDifficulty: req.PreferredDifficulty,
+		QuestionCount:       req.QuestionCount,
+		Rating:              req.Rating,
 		BotOK:               req.BotOK,
 		QueuedAt:            time.Now(),
 		QueueToken:          queueToken,
 	}
 
-	// Try immediate match
-	for otherToken, other := range m.waiting {
-		if other.UserID == req.UserID {
-			continue // skip self
-		}
-		if m.isCompatible(player, other) {
-			delete(m.waiting, otherToken)
-			pair := &MatchPair{
-				Player1: *player,
-				Player2: *other,
-			}
-			return queueToken, pair, nil
-		}
+	data, err := json.Marshal(player)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("marshal queue entry: %w", err)
+	}
+
+	// The user key outlives any entry Prune would keep
+	keys := []string{entriesKey, waitingKey(player.PreferredCategory), categoriesKey, userKey(req.UserID)}
+	replaced, err := enqueueScript.Run(ctx, m.redis, keys,
+		queueToken.String(), data, player.QueuedAt.UnixMilli(), player.PreferredCategory, (2 * maxQueueAge).Milliseconds()).Text()
+	if err != nil && err != redis.Nil {
+		return uuid.Nil, fmt.Errorf("enqueue: %w", err)
 	}
 
-	// No match found, add to queue
-	m.waiting[queueToken] = player
 	m.logger.Info().
 		Str("queue_token", queueToken.String()).
 		Str("user_id", req.UserID.String()).
+		Str("category", player.PreferredCategory).
+		Str("replaced_token", replaced).
 		Msg("player enqueued")
 
-	return queueToken, nil, nil
+	return queueToken, nil
 }
 
 // Dequeue removes a player from the queue.
 func (m *Manager) Dequeue(ctx context.Context, queueToken uuid.UUID) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	if _, exists := m.waiting[queueToken]; !exists {
-		return fmt.Errorf("queue token not found")
+	if _, err := m.remove(ctx, queueToken, uuid.Nil); err != nil {
+		return err
 	}
-
-	delete(m.waiting, queueToken)
 	m.logger.Info().Str("queue_token", queueToken.String()).Msg("player dequeued")
 	return nil
 }
 
-// GetPosition returns queue position (0 = front, -1 if not found).
-func (m *Manager) GetPosition(queueToken uuid.UUID) int {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+// GetPosition returns the FIFO queue position within the player's category
+// (0 = front, -1 if not found).
+func (m *Manager) GetPosition(ctx context.Context, queueToken uuid.UUID) int {
+	player, err := m.get(ctx, queueToken)
+	if err != nil {
+		return -1
+	}
 
-	pos := 0
-	for token := range m.waiting {
-		if token == queueToken {
-			return pos
-		}
-		pos++
+	rank, err := m.redis.ZRank(ctx, waitingKey(player.PreferredCategory), queueToken.String()).Result()
+	if err != nil {
+		return -1
 	}
-	return -1
+	return int(rank)
 }
 
 // ShouldOfferBot checks if a player has waited long enough for bot offer.
-func (m *Manager) ShouldOfferBot(queueToken uuid.UUID) bool {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-
-	player, exists := m.waiting[queueToken]
-	if !exists {
+func (m *Manager) ShouldOfferBot(ctx context.Context, queueToken uuid.UUID) bool {
+	player, err := m.get(ctx, queueToken)
+	if err != nil {
 		return false
 	}
 
 	waitDuration := time.Since(player.QueuedAt)
-	return waitDuration >= time.Duration(m.botWaitSec)*time.Second && player.BotOK
+	return waitDuration >= m.BotWait() && player.BotOK
+}
+
+// Claim removes a user's waiting entry from the queue and returns it, so it can be
+// placed into a match outside the normal pairing flow (e.g. a bot match).
+func (m *Manager) Claim(ctx context.Context, queueToken uuid.UUID, userID uuid.UUID) (*WaitingPlayer, error) {
+	player, err := m.remove(ctx, queueToken, userID)
+	if err != nil {
+		return nil, err
+	}
+	m.logger.Info().Str("queue_token", queueToken.String()).Msg("player claimed from queue")
+	return player, nil
+}
+
+// BotWait returns how long a player waits before being offered a bot.
+func (m *Manager) BotWait() time.Duration {
+	return time.Duration(m.botWaitSec) * time.Second
+}
+
+// Categories returns the categories that currently have waiting players.
+func (m *Manager) Categories(ctx context.Context) ([]string, error) {
+	categories, err := m.redis.SMembers(ctx, categoriesKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("list categories: %w", err)
+	}
+	return categories, nil
+}
+
+// PairNext removes and returns the longest-waiting player of a category together
+// with their closest-rated compatible opponent (see choosePair). Returns nil when
+// no two waiting players are compatible yet.
+func (m *Manager) PairNext(ctx context.Context, category string) (*MatchPair, error) {
+	for attempt := 0; attempt < pairClaimAttempts; attempt++ {
+		waiting, err := m.Waiting(ctx, category)
+		if err != nil {
+			return nil, err
+		}
+		if len(waiting) > pairScanWindow {
+			waiting = waiting[:pairScanWindow]
+		}
+
+		pair := choosePair(waiting, time.Now(), m.bands)
+		if pair == nil {
+			return nil, nil
+		}
+
+		claimed, err := claimPairScript.Run(ctx, m.redis, []string{waitingKey(category), entriesKey},
+			pair.Player1.QueueToken.String(), pair.Player2.QueueToken.String()).Int()
+		if err != nil {
+			return nil, fmt.Errorf("pair players: %w", err)
+		}
+		if claimed == 0 {
+			// One of them cancelled or was claimed for a bot match meanwhile
+			continue
+		}
+
+		m.logger.Info().
+			Str("category", category).
+			Str("player1", pair.Player1.UserID.String()).
+			Str("player2", pair.Player2.UserID.String()).
+			Str("reason", pair.Reason).
+			Int("rating_gap", pair.RatingGap).
+			Msg("players paired")
+
+		return pair, nil
+	}
+	return nil, nil
+}
+
+// Waiting returns the players still waiting in a category, in FIFO order.
+func (m *Manager) Waiting(ctx context.Context, category string) ([]WaitingPlayer, error) {
+	tokens, err := m.redis.ZRange(ctx, waitingKey(category), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("list waiting: %w", err)
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	values, err := m.redis.HMGet(ctx, entriesKey, tokens...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("load queue entries: %w", err)
+	}
+
+	players := make([]WaitingPlayer, 0, len(values))
+	for _, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var player WaitingPlayer
+		if err := json.Unmarshal([]byte(raw), &player); err != nil {
+			m.logger.Warn().Err(err).Msg("skip corrupted queue entry")
+			continue
+		}
+		players = append(players, player)
+	}
+	return players, nil
+}
+
+// Prune drops entries older than maxQueueAge and forgets the category once it is
+// empty. Returns the players whose entries were dropped, so they can be told.
+func (m *Manager) Prune(ctx context.Context, category string) ([]WaitingPlayer, error) {
+	key := waitingKey(category)
+	cutoff := fmt.Sprintf("%d", time.Now().Add(-maxQueueAge).UnixMilli())
+
+	stale, err := m.redis.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: "-inf", Max: cutoff}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("list stale entries: %w", err)
+	}
+
+	var expired []WaitingPlayer
+	for _, token := range stale {
+		raw, err := removeScript.Run(ctx, m.redis, []string{entriesKey, key}, token, "").Text()
+		if err == redis.Nil {
+			// Paired or cancelled meanwhile; drop a dangling token all the same
+			m.redis.ZRem(ctx, key, token)
+			continue
+		}
+		if err != nil {
+			return expired, fmt.Errorf("drop stale entry: %w", err)
+		}
+		var player WaitingPlayer
+		if err := json.Unmarshal([]byte(raw), &player); err != nil {
+			m.logger.Warn().Err(err).Str("queue_token", token).Msg("dropped corrupted queue entry")
+			continue
+		}
+		expired = append(expired, player)
+	}
+	if len(expired) > 0 {
+		m.logger.Info().Str("category", category).Int("count", len(expired)).Msg("stale queue entries dropped")
+	}
+
+	return expired, forgetCategoryScript.Run(ctx, m.redis, []string{categoriesKey, key}, category).Err()
+}
+
+// acquireMatcherLock lets a single instance run a matcher tick. The lock is never
+// released explicitly; it expires before the next tick.
+func (m *Manager) acquireMatcherLock(ctx context.Context, ttl time.Duration) (bool, error) {
+	return m.redis.SetNX(ctx, matcherLockKey, uuid.NewString(), ttl).Result()
+}
+
+// get loads a queue entry.
+func (m *Manager) get(ctx context.Context, queueToken uuid.UUID) (*WaitingPlayer, error) {
+	raw, err := m.redis.HGet(ctx, entriesKey, queueToken.String()).Bytes()
+	if err == redis.Nil {
+		return nil, ErrTokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get queue entry: %w", err)
+	}
+
+	var player WaitingPlayer
+	if err := json.Unmarshal(raw, &player); err != nil {
+		return nil, fmt.Errorf("unmarshal queue entry: %w", err)
+	}
+	return &player, nil
+}
+
+// remove atomically deletes an entry. When userID is not uuid.Nil the entry is
+// only removed if it belongs to that user.
+func (m *Manager) remove(ctx context.Context, queueToken uuid.UUID, userID uuid.UUID) (*WaitingPlayer, error) {
+	player, err := m.get(ctx, queueToken)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := ""
+	if userID != uuid.Nil {
+		owner = userID.String()
+	}
+
+	raw, err := removeScript.Run(ctx, m.redis, []string{entriesKey, waitingKey(player.PreferredCategory)}, queueToken.String(), owner).Text()
+	if err == redis.Nil {
+		return nil, ErrTokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("remove queue entry: %w", err)
+	}
+
+	var removed WaitingPlayer
+	if err := json.Unmarshal([]byte(raw), &removed); err != nil {
+		return nil, fmt.Errorf("unmarshal queue entry: %w", err)
+	}
+	return &removed, nil
+}
+
+func waitingKey(category string) string {
+	return fmt.Sprintf("queue:waiting:%s", category)
+}
+
+func userKey(userID uuid.UUID) string {
+	return fmt.Sprintf("queue:user:%s", userID.String())
 }
 
 // MatchPair represents a matched pair of players.
 type MatchPair struct {
-	Player1 WaitingPlayer
-	Player2 WaitingPlayer
+	Player1       WaitingPlayer
+	Player2       WaitingPlayer
+	Reason        string // why they were paired (ReasonSkillMatch or ReasonWidenedSearch)
+	RatingGap     int
+	QuestionCount int    // agreed question count (0 = neither asked for one)
+	Difficulty    string // agreed difficulty ("" = neither asked for one)
 }
 
 // MatchmakingRequest mirrors the match package type for queue isolation.
@@ -144,6 +431,8 @@ type MatchmakingRequest struct {
 	IsGuest             bool
 	PreferredCategory   string
 	PreferredDifficulty string
+	QuestionCount       int
+	Rating              int
 	BotOK               bool
 }
 
@@ -154,16 +443,3 @@ func normalizeCategory(category string) string {
 	}
 	return category
 }
-
-func (m *Manager) isCompatible(p1, p2 *WaitingPlayer) bool {
-	// Match players with the same category/topic
-	category1 := normalizeCategory(p1.PreferredCategory)
-	category2 := normalizeCategory(p2.PreferredCategory)
-	
-	if category1 != category2 {
-		return false
-	}
-	
-	// Additional compatibility checks can be added here (bot OK, difficulty, etc.)
-	return true
-}
@@ -0,0 +1,197 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestManager returns a manager backed by an in-memory Redis, which also runs
+// the Lua scripts.
+func newTestManager(t *testing.T) (*Manager, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	return newManagerOn(t, mr), mr
+}
+
+// newManagerOn returns another instance's manager on the same Redis.
+func newManagerOn(t *testing.T, mr *miniredis.Miniredis) *Manager {
+	t.Helper()
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewManager(client, zerolog.Nop(), 0, Bands{})
+}
+
+func enqueue(t *testing.T, m *Manager, rating int) (uuid.UUID, MatchmakingRequest) {
+	t.Helper()
+	req := MatchmakingRequest{UserID: uuid.New(), Username: "player", Rating: rating}
+	token, err := m.Enqueue(context.Background(), req)
+	require.NoError(t, err)
+	// Entries are ordered by enqueue time in milliseconds
+	time.Sleep(2 * time.Millisecond)
+	return token, req
+}
+
+func TestEnqueueAndDequeue(t *testing.T) {
+	m, mr := newTestManager(t)
+	ctx := context.Background()
+
+	first, firstReq := enqueue(t, m, 1500)
+	second, _ := enqueue(t, m, 1500)
+
+	categories, err := m.Categories(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"general"}, categories)
+	assert.Equal(t, 0, m.GetPosition(ctx, first))
+	assert.Equal(t, 1, m.GetPosition(ctx, second))
+
+	waiting, err := m.Waiting(ctx, "general")
+	require.NoError(t, err)
+	require.Len(t, waiting, 2)
+	assert.Equal(t, firstReq.UserID, waiting[0].UserID)
+	assert.Equal(t, first, waiting[0].QueueToken)
+
+	require.NoError(t, m.Dequeue(ctx, first))
+	assert.Equal(t, -1, m.GetPosition(ctx, first))
+	assert.Equal(t, 0, m.GetPosition(ctx, second))
+	assert.Len(t, mustHKeys(t, mr), 1)
+
+	assert.ErrorIs(t, m.Dequeue(ctx, first), ErrTokenNotFound)
+}
+
+func TestClaimOnlyTakesTheOwnersEntry(t *testing.T) {
+	m, mr := newTestManager(t)
+	ctx := context.Background()
+	token, req := enqueue(t, m, 1500)
+
+	_, err := m.Claim(ctx, token, uuid.New())
+	assert.ErrorIs(t, err, ErrTokenNotFound)
+	assert.Equal(t, 0, m.GetPosition(ctx, token), "someone else's claim leaves the entry waiting")
+
+	player, err := m.Claim(ctx, token, req.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, req.UserID, player.UserID)
+	assert.Equal(t, token, player.QueueToken)
+
+	// Gone from both the waiting list and the entries
+	assert.Equal(t, -1, m.GetPosition(ctx, token))
+	assert.False(t, mr.Exists(waitingKey("general")))
+	assert.Empty(t, mustHKeys(t, mr))
+
+	_, err = m.Claim(ctx, token, req.UserID)
+	assert.ErrorIs(t, err, ErrTokenNotFound)
+}
+
+func TestPairNextRemovesBothPlayers(t *testing.T) {
+	m, mr := newTestManager(t)
+	ctx := context.Background()
+	first, _ := enqueue(t, m, 1500)
+	second, _ := enqueue(t, m, 1520)
+
+	pair, err := m.PairNext(ctx, "general")
+	require.NoError(t, err)
+	require.NotNil(t, pair)
+	assert.Equal(t, first, pair.Player1.QueueToken)
+	assert.Equal(t, second, pair.Player2.QueueToken)
+	assert.Equal(t, 20, pair.RatingGap)
+
+	waiting, err := m.Waiting(ctx, "general")
+	require.NoError(t, err)
+	assert.Empty(t, waiting)
+	assert.Empty(t, mustHKeys(t, mr))
+	assert.False(t, mr.Exists(userKey(pair.Player1.UserID)))
+	assert.False(t, mr.Exists(userKey(pair.Player2.UserID)))
+
+	pair, err = m.PairNext(ctx, "general")
+	require.NoError(t, err)
+	assert.Nil(t, pair)
+}
+
+func TestEnqueueReplacesTheUsersEarlierEntry(t *testing.T) {
+	m, mr := newTestManager(t)
+	ctx := context.Background()
+	req := MatchmakingRequest{UserID: uuid.New(), Username: "twice", Rating: 1500}
+
+	first, err := m.Enqueue(ctx, req)
+	require.NoError(t, err)
+	// A second tab on another instance, in another category
+	req.PreferredCategory = "science"
+	second, err := newManagerOn(t, mr).Enqueue(ctx, req)
+	require.NoError(t, err)
+
+	assert.Equal(t, -1, m.GetPosition(ctx, first))
+	assert.Equal(t, 0, m.GetPosition(ctx, second))
+	assert.Equal(t, []string{second.String()}, mustHKeys(t, mr))
+	general, err := m.Waiting(ctx, "general")
+	require.NoError(t, err)
+	assert.Empty(t, general)
+
+	// An opponent can only be paired with the one entry left
+	enqueue(t, m, 1500)
+	pair, err := m.PairNext(ctx, "general")
+	require.NoError(t, err)
+	assert.Nil(t, pair)
+
+	require.NoError(t, m.Dequeue(ctx, second))
+	assert.False(t, mr.Exists(userKey(req.UserID)))
+}
+
+func TestClaimPairScriptNeedsBothPlayersWaiting(t *testing.T) {
+	m, _ := newTestManager(t)
+	ctx := context.Background()
+	first, firstReq := enqueue(t, m, 1500)
+	second, _ := enqueue(t, m, 1500)
+
+	// The first player was taken into a bot match after being chosen
+	_, err := m.Claim(ctx, first, firstReq.UserID)
+	require.NoError(t, err)
+
+	claimed, err := claimPairScript.Run(ctx, m.redis, []string{waitingKey("general"), entriesKey},
+		first.String(), second.String()).Int()
+	require.NoError(t, err)
+	assert.Equal(t, 0, claimed)
+	assert.Equal(t, 0, m.GetPosition(ctx, second), "the other player keeps waiting")
+}
+
+func TestPruneDropsStaleEntriesAndForgetsEmptyCategory(t *testing.T) {
+	m, mr := newTestManager(t)
+	ctx := context.Background()
+	token, req := enqueue(t, m, 1500)
+
+	expired, err := m.Prune(ctx, "general")
+	require.NoError(t, err)
+	assert.Empty(t, expired)
+	assert.Equal(t, 0, m.GetPosition(ctx, token), "fresh entries are kept")
+
+	stale := float64(time.Now().Add(-maxQueueAge - time.Minute).UnixMilli())
+	_, err = mr.ZAdd(waitingKey("general"), stale, token.String())
+	require.NoError(t, err)
+
+	expired, err = m.Prune(ctx, "general")
+	require.NoError(t, err)
+	require.Len(t, expired, 1, "the dropped player is reported so they can be told")
+	assert.Equal(t, req.UserID, expired[0].UserID)
+	assert.Equal(t, -1, m.GetPosition(ctx, token))
+	assert.Empty(t, mustHKeys(t, mr))
+	assert.False(t, mr.Exists(userKey(req.UserID)))
+	categories, err := m.Categories(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, categories)
+}
+
+func mustHKeys(t *testing.T, mr *miniredis.Miniredis) []string {
+	t.Helper()
+	if !mr.Exists(entriesKey) {
+		return nil
+	}
+	keys, err := mr.HKeys(entriesKey)
+	require.NoError(t, err)
+	return keys
+}
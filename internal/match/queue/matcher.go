@@ -0,0 +1,114 @@
+package queue
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// PairFunc creates a match for a freshly paired couple of players.
+type PairFunc func(ctx context.Context, pair *MatchPair)
+
+// WaitingFunc reports a player that is still waiting, with their FIFO position
+// (0 = front) and how long they have waited.
+type WaitingFunc func(ctx context.Context, player WaitingPlayer, position int, waited time.Duration)
+
+// ExpiredFunc reports a player whose entry was dropped for waiting too long.
+type ExpiredFunc func(ctx context.Context, player WaitingPlayer)
+
+// Matcher is the background loop that pairs queued players across instances.
+// Every tick one instance (holding queue:matcher:lock) drains each category,
+// hands pairs to onPair and reports the remaining players to onWaiting, and
+// those dropped for waiting too long to onExpired.
+type Matcher struct {
+	queue     *Manager
+	onPair    PairFunc
+	onWaiting WaitingFunc
+	onExpired ExpiredFunc
+	interval  time.Duration
+	logger    zerolog.Logger
+}
+
+// NewMatcher creates a matcher loop for the queue.
+func NewMatcher(queue *Manager, onPair PairFunc, onWaiting WaitingFunc, onExpired ExpiredFunc, logger zerolog.Logger) *Matcher {
+	return &Matcher{
+		queue:     queue,
+		onPair:    onPair,
+		onWaiting: onWaiting,
+		onExpired: onExpired,
+		interval:  time.Second,
+		logger:    logger.With().Str("component", "queue_matcher").Logger(),
+	}
+}
+
+// Run ticks until the context is cancelled.
+func (m *Matcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			m.tick(ctx)
+		}
+	}
+}
+
+func (m *Matcher) tick(ctx context.Context) {
+	// Expire slightly before the next tick so exactly one instance wins each round.
+	acquired, err := m.queue.acquireMatcherLock(ctx, m.interval*9/10)
+	if err != nil {
+		m.logger.Warn().Err(err).Msg("failed to acquire matcher lock")
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	categories, err := m.queue.Categories(ctx)
+	if err != nil {
+		m.logger.Warn().Err(err).Msg("failed to list queue categories")
+		return
+	}
+
+	for _, category := range categories {
+		expired, err := m.queue.Prune(ctx, category)
+		if err != nil {
+			m.logger.Warn().Err(err).Str("category", category).Msg("failed to prune queue")
+		}
+		if m.onExpired != nil {
+			for _, player := range expired {
+				m.onExpired(ctx, player)
+			}
+		}
+
+		for {
+			pair, err := m.queue.PairNext(ctx, category)
+			if err != nil {
+				m.logger.Warn().Err(err).Str("category", category).Msg("failed to pair players")
+				break
+			}
+			if pair == nil {
+				break
+			}
+			// Match creation fetches questions; don't hold up the rest of the tick.
+			go m.onPair(context.Background(), pair)
+		}
+
+		if m.onWaiting == nil {
+			continue
+		}
+		waiting, err := m.queue.Waiting(ctx, category)
+		if err != nil {
+			m.logger.Warn().Err(err).Str("category", category).Msg("failed to list waiting players")
+			continue
+		}
+		now := time.Now()
+		for position, player := range waiting {
+			m.onWaiting(ctx, player, position, now.Sub(player.QueuedAt))
+		}
+	}
+}
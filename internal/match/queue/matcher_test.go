@@ -0,0 +1,138 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcherTickPairsAndReportsWaiting(t *testing.T) {
+	m, _ := newTestManager(t)
+	first, _ := enqueue(t, m, 1500)
+	second, _ := enqueue(t, m, 1510)
+	outsider, _ := enqueue(t, m, 2400)
+
+	pairs := make(chan *MatchPair, 2)
+	var waiting []uuid.UUID
+	matcher := NewMatcher(m,
+		func(ctx context.Context, pair *MatchPair) { pairs <- pair },
+		func(ctx context.Context, player WaitingPlayer, position int, waited time.Duration) {
+			assert.Equal(t, len(waiting), position)
+			waiting = append(waiting, player.QueueToken)
+		},
+		nil, zerolog.Nop())
+
+	matcher.tick(context.Background())
+
+	select {
+	case pair := <-pairs:
+		assert.Equal(t, first, pair.Player1.QueueToken)
+		assert.Equal(t, second, pair.Player2.QueueToken)
+	case <-time.After(time.Second):
+		t.Fatal("no pair handed over")
+	}
+	assert.Equal(t, []uuid.UUID{outsider}, waiting)
+}
+
+func TestMatchersClaimEachEntryOnce(t *testing.T) {
+	m, mr := newTestManager(t)
+	enqueue(t, m, 1500)
+	enqueue(t, m, 1500)
+
+	// Every instance runs its own matcher against the shared queue
+	var mu sync.Mutex
+	var paired []*MatchPair
+	onPair := func(ctx context.Context, pair *MatchPair) {
+		mu.Lock()
+		defer mu.Unlock()
+		paired = append(paired, pair)
+	}
+	matchers := []*Matcher{
+		NewMatcher(m, onPair, nil, nil, zerolog.Nop()),
+		NewMatcher(newManagerOn(t, mr), onPair, nil, nil, zerolog.Nop()),
+		NewMatcher(newManagerOn(t, mr), onPair, nil, nil, zerolog.Nop()),
+	}
+
+	var wg sync.WaitGroup
+	for _, matcher := range matchers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			matcher.tick(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(paired) == 1
+	}, time.Second, 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	mu.Lock()
+	assert.Len(t, paired, 1)
+	mu.Unlock()
+}
+
+func TestConcurrentPairingClaimsEachEntryOnce(t *testing.T) {
+	m, mr := newTestManager(t)
+	for range 6 {
+		enqueue(t, m, 1500)
+	}
+
+	// Pairing without the matcher lock, as when a tick overruns the lock's expiry
+	var wg sync.WaitGroup
+	pairs := make(chan *MatchPair, 32)
+	for range 8 {
+		manager := newManagerOn(t, mr)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				pair, err := manager.PairNext(context.Background(), "general")
+				if !assert.NoError(t, err) || pair == nil {
+					return
+				}
+				pairs <- pair
+			}
+		}()
+	}
+	wg.Wait()
+	close(pairs)
+
+	seen := map[uuid.UUID]bool{}
+	count := 0
+	for pair := range pairs {
+		for _, player := range []WaitingPlayer{pair.Player1, pair.Player2} {
+			assert.False(t, seen[player.QueueToken], "player paired twice")
+			seen[player.QueueToken] = true
+		}
+		count++
+	}
+	assert.Equal(t, 3, count)
+	assert.Empty(t, mustHKeys(t, mr))
+}
+
+func TestMatcherTellsExpiredPlayers(t *testing.T) {
+	m, mr := newTestManager(t)
+	token, req := enqueue(t, m, 1500)
+	stale := float64(time.Now().Add(-maxQueueAge - time.Minute).UnixMilli())
+	_, err := mr.ZAdd(waitingKey("general"), stale, token.String())
+	require.NoError(t, err)
+
+	var expired []WaitingPlayer
+	matcher := NewMatcher(m, nil, nil, func(ctx context.Context, player WaitingPlayer) {
+		expired = append(expired, player)
+	}, zerolog.Nop())
+	matcher.tick(context.Background())
+
+	require.Len(t, expired, 1)
+	assert.Equal(t, req.UserID, expired[0].UserID)
+	assert.Equal(t, token, expired[0].QueueToken)
+}
@@ -0,0 +1,125 @@
+package queue
+
+import "time"
+
+// Pairing reasons recorded in match metadata.
+const (
+	ReasonSkillMatch    = "skill_match"    // rating gap within the starting band
+	ReasonWidenedSearch = "widened_search" // rating gap only allowed after waiting
+)
+
+// Bands controls how far apart in rating two players may be paired. The allowed
+// gap starts at Base and widens by PerSecond for every second the longer-waiting
+// of the two has queued, up to Max.
+type Bands struct {
+	Base      int
+	PerSecond int
+	Max       int
+}
+
+// DefaultBands returns the production rating bands.
+func DefaultBands() Bands {
+	return Bands{Base: 100, PerSecond: 20, Max: 800}
+}
+
+// allowedGap returns the rating gap accepted after waiting for waited.
+func (b Bands) allowedGap(waited time.Duration) int {
+	gap := b.Base + int(waited.Seconds())*b.PerSecond
+	if gap > b.Max {
+		gap = b.Max
+	}
+	return gap
+}
+
+// choosePair picks the longest-waiting player that has a compatible opponent and
+// pairs them with the closest-rated one (the longer-waiting on ties). waiting must
+// be in FIFO order. Returns nil when nobody can be paired yet.
+func choosePair(waiting []WaitingPlayer, now time.Time, bands Bands) *MatchPair {
+	for i, first := range waiting {
+		best := -1
+		bestGap := 0
+		for j := i + 1; j < len(waiting); j++ {
+			gap, ok := compatible(first, waiting[j], now, bands)
+			if ok && (best < 0 || gap < bestGap) {
+				best, bestGap = j, gap
+			}
+		}
+		if best < 0 {
+			continue
+		}
+
+		second := waiting[best]
+		reason := ReasonSkillMatch
+		if bestGap > bands.Base {
+			reason = ReasonWidenedSearch
+		}
+		count, _ := agreedCount(first.QuestionCount, second.QuestionCount)
+		difficulty, _ := agreedDifficulty(first.PreferredDifficulty, second.PreferredDifficulty)
+		return &MatchPair{
+			Player1:       first,
+			Player2:       second,
+			Reason:        reason,
+			RatingGap:     bestGap,
+			QuestionCount: count,
+			Difficulty:    difficulty,
+		}
+	}
+	return nil
+}
+
+// compatible reports whether a and b can play each other now, and their rating gap.
+// Players without a question count or difficulty preference accept any.
+func compatible(a, b WaitingPlayer, now time.Time, bands Bands) (int, bool) {
+	if a.UserID == b.UserID {
+		return 0, false
+	}
+	if _, ok := agreedCount(a.QuestionCount, b.QuestionCount); !ok {
+		return 0, false
+	}
+	if _, ok := agreedDifficulty(a.PreferredDifficulty, b.PreferredDifficulty); !ok {
+		return 0, false
+	}
+
+	gap := a.Rating - b.Rating
+	if gap < 0 {
+		gap = -gap
+	}
+	waited := now.Sub(a.QueuedAt)
+	if other := now.Sub(b.QueuedAt); other > waited {
+		waited = other
+	}
+	return gap, gap <= bands.allowedGap(waited)
+}
+
+// agreedCount returns the question count both players accept. Counts other than
+// 5, 10 or 15 mean no preference, as does 0 in the result.
+func agreedCount(a, b int) (int, bool) {
+	a, b = countPreference(a), countPreference(b)
+	switch {
+	case a == 0:
+		return b, true
+	case b == 0 || a == b:
+		return a, true
+	default:
+		return 0, false
+	}
+}
+
+func countPreference(count int) int {
+	if count != 5 && count != 10 && count != 15 {
+		return 0
+	}
+	return count
+}
+
+// agreedDifficulty returns the difficulty both players accept ("" = any).
+func agreedDifficulty(a, b string) (string, bool) {
+	switch {
+	case a == "":
+		return b, true
+	case b == "" || a == b:
+		return a, true
+	default:
+		return "", false
+	}
+}
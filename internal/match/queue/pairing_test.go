@@ -0,0 +1,77 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waiter(rating int, waited time.Duration, now time.Time) WaitingPlayer {
+	return WaitingPlayer{UserID: uuid.New(), Rating: rating, QueuedAt: now.Add(-waited), QueueToken: uuid.New()}
+}
+
+func TestChoosePairPrefersClosestRating(t *testing.T) {
+	now := time.Now()
+	bands := DefaultBands()
+	oldest := waiter(1500, 3*time.Second, now)
+	far := waiter(1580, 2*time.Second, now)
+	near := waiter(1530, time.Second, now)
+
+	pair := choosePair([]WaitingPlayer{oldest, far, near}, now, bands)
+	require.NotNil(t, pair)
+	assert.Equal(t, oldest.UserID, pair.Player1.UserID)
+	assert.Equal(t, near.UserID, pair.Player2.UserID)
+	assert.Equal(t, 30, pair.RatingGap)
+	assert.Equal(t, ReasonSkillMatch, pair.Reason)
+}
+
+func TestChoosePairWidensWithWaiting(t *testing.T) {
+	now := time.Now()
+	bands := Bands{Base: 100, PerSecond: 20, Max: 800}
+	newcomer := waiter(1400, 0, now)
+	expert := waiter(1900, 0, now)
+
+	// 500 apart: out of reach until the longer wait has widened the band to 500
+	assert.Nil(t, choosePair([]WaitingPlayer{expert, newcomer}, now, bands))
+	assert.Nil(t, choosePair([]WaitingPlayer{expert, newcomer}, now.Add(19*time.Second), bands))
+
+	pair := choosePair([]WaitingPlayer{expert, newcomer}, now.Add(20*time.Second), bands)
+	require.NotNil(t, pair)
+	assert.Equal(t, 500, pair.RatingGap)
+	assert.Equal(t, ReasonWidenedSearch, pair.Reason)
+
+	// The band stops widening at Max
+	legend := waiter(2400, 0, now)
+	assert.Nil(t, choosePair([]WaitingPlayer{legend, newcomer}, now.Add(time.Hour), bands))
+}
+
+func TestChoosePairHonorsPreferences(t *testing.T) {
+	now := time.Now()
+	bands := DefaultBands()
+	a := waiter(1500, time.Second, now)
+	a.QuestionCount = 5
+	b := waiter(1500, time.Second, now)
+	b.QuestionCount = 15
+	assert.Nil(t, choosePair([]WaitingPlayer{a, b}, now, bands))
+
+	// No preference accepts the other player's count
+	b.QuestionCount = 0
+	pair := choosePair([]WaitingPlayer{a, b}, now, bands)
+	require.NotNil(t, pair)
+	assert.Equal(t, 5, pair.QuestionCount)
+
+	a.PreferredDifficulty, b.PreferredDifficulty = "hard", "easy"
+	assert.Nil(t, choosePair([]WaitingPlayer{a, b}, now, bands))
+	b.PreferredDifficulty = ""
+	pair = choosePair([]WaitingPlayer{a, b}, now, bands)
+	require.NotNil(t, pair)
+	assert.Equal(t, "hard", pair.Difficulty)
+
+	// Never with yourself
+	twin := a
+	twin.QueueToken = uuid.New()
+	assert.Nil(t, choosePair([]WaitingPlayer{a, twin}, now, bands))
+}
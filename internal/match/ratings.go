@@ -0,0 +1,112 @@
+package match
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/gokatarajesh/quiz-platform/internal/leaderboard"
+	"github.com/gokatarajesh/quiz-platform/internal/rating"
+	"github.com/gokatarajesh/quiz-platform/pkg/http/ws"
+)
+
+// rateMatch updates both players' skill ratings after a leaderboard-eligible
+// random 1v1 and moves them on the rating board. results are the leaderboard
+// results already recorded for the match. Returns each player's rating change;
+// failures are logged and leave the match unrated.
+func (s *Service) rateMatch(ctx context.Context, matchID uuid.UUID, states []PlayerState, winners map[uuid.UUID]bool, results []leaderboard.RecordRequest) map[uuid.UUID]*ws.RatingChange {
+	var players []uuid.UUID
+	for _, state := range states {
+		if !state.IsGuest && !state.IsBot {
+			players = append(players, state.UserID)
+		}
+	}
+	if len(players) != 2 {
+		return nil
+	}
+
+	changes, err := s.ratings.RecordMatch(ctx, matchID, players[0], players[1], outcome(winners, players[0], players[1]))
+	if err != nil {
+		s.logger.Warn().Err(err).Str("match_id", matchID.String()).Msg("failed to rate match")
+		return nil
+	}
+
+	rated := make(map[uuid.UUID]*ws.RatingChange, len(changes))
+	for _, c := range changes {
+		rated[c.UserID] = &ws.RatingChange{
+			Before: rating.Display(c.Before),
+			After:  rating.Display(c.After),
+			Delta:  c.Delta(),
+		}
+	}
+
+	if s.leaderboard != nil {
+		for _, req := range results {
+			change, ok := rated[req.UserID]
+			if !ok {
+				continue
+			}
+			if err := s.leaderboard.RecordRating(ctx, req, change.After); err != nil {
+				s.logger.Warn().Err(err).Str("user_id", req.UserID.String()).Msg("failed to record rating leaderboard result")
+			}
+		}
+	}
+	return rated
+}
+
+// voidRatings takes an invalidated match's rating changes back off both players
+// and moves them back on the rating board.
+func (s *Service) voidRatings(ctx context.Context, matchID uuid.UUID, questionCount int) error {
+	if s.ratings == nil {
+		return nil
+	}
+	restored, err := s.ratings.VoidMatch(ctx, matchID)
+	if err != nil || len(restored) == 0 || s.leaderboard == nil {
+		return err
+	}
+
+	rows, err := s.matchRepo.ListPlayerStates(ctx, pgtype.UUID{Bytes: matchID, Valid: true})
+	if err != nil {
+		return fmt.Errorf("load player states: %w", err)
+	}
+	for _, req := range recordedResults(matchID, rows, questionCount) {
+		r, ok := restored[req.UserID]
+		if !ok {
+			continue
+		}
+		if err := s.leaderboard.ReverseRating(ctx, req, rating.Display(r)); err != nil {
+			// The stored rating is already restored; the board catches up on the next game
+			s.logger.Warn().Err(err).Str("user_id", req.UserID.String()).Msg("failed to reverse rating leaderboard result")
+		}
+	}
+	return nil
+}
+
+// queueRating returns the rating a player is matched on. Guests, and players whose
+// rating cannot be loaded, are matched as new players.
+func (s *Service) queueRating(ctx context.Context, userID uuid.UUID, isGuest bool) int {
+	if isGuest || s.ratings == nil {
+		return rating.Display(rating.Default())
+	}
+	r, err := s.ratings.Get(ctx, userID)
+	if err != nil {
+		s.logger.Warn().Err(err).Str("user_id", userID.String()).Msg("failed to load rating for matchmaking")
+		return rating.Display(rating.Default())
+	}
+	return rating.Display(r)
+}
+
+// outcome scores a two-player match from a's side. Both winning is a tie; both
+// losing means both left early, which is scored as a draw.
+func outcome(winners map[uuid.UUID]bool, a, b uuid.UUID) float64 {
+	switch {
+	case winners[a] == winners[b]:
+		return rating.Draw
+	case winners[a]:
+		return rating.Win
+	default:
+		return rating.Loss
+	}
+}
@@ -2,24 +2,39 @@ package match
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
-	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
+
+	"github.com/gokatarajesh/quiz-platform/internal/match/scoring"
 )
 
 // RoomManager handles private room creation, joining, and lifecycle.
+// Rooms live in Redis (room:{code}) so every API replica sees the same state;
+// all mutations run as optimistic WATCH/MULTI transactions.
 type RoomManager struct {
 	redis  *redis.Client
 	logger zerolog.Logger
-	mu     sync.RWMutex
-	rooms  map[string]*PrivateRoom
 }
 
+// ErrRoomNotFound is returned when a room code does not exist (or has expired).
+var ErrRoomNotFound = errors.New("room not found")
+
+const (
+	// roomTTL bounds how long an idle room lives; every update refreshes it.
+	roomTTL = 2 * time.Hour
+	// roomUpdateRetries caps optimistic transaction retries under contention.
+	roomUpdateRetries = 10
+	// roomCodeAttempts caps code generation retries on collision.
+	roomCodeAttempts = 20
+)
+
 // PrivateRoom represents a private match room.
 type PrivateRoom struct {
 	RoomCode           string
@@ -30,6 +45,8 @@ type PrivateRoom struct {
 	QuestionCount      int
 	PerQuestionSeconds int
 	Category           string // e.g., "general", "science", "history"
+	MinReadyPlayers    int    // host may start once this many are ready (0 = everyone present)
+	ScoringRule        string // how answers are scored, stored in the match metadata
 	Players            []RoomPlayer
 	Status             string // "waiting", "starting", "active"
 	CreatedAt          time.Time
@@ -42,9 +59,45 @@ type RoomPlayer struct {
 	Username string
 	IsGuest  bool
 	IsHost   bool
+	Ready    bool
 	JoinedAt time.Time
 }
 
+// ReadyCount returns how many players in the room are ready.
+func (room *PrivateRoom) ReadyCount() int {
+	count := 0
+	for _, p := range room.Players {
+		if p.Ready {
+			count++
+		}
+	}
+	return count
+}
+
+// CanStart reports whether the host may start the room: everyone present is ready
+// (at least two players), or the configured minimum number of players is ready.
+func (room *PrivateRoom) CanStart() bool {
+	ready := room.ReadyCount()
+	if ready < 2 {
+		return false
+	}
+	if ready == len(room.Players) {
+		return true
+	}
+	return room.MinReadyPlayers > 0 && ready >= room.MinReadyPlayers
+}
+
+// ReadyPlayers returns the players that are ready.
+func (room *PrivateRoom) ReadyPlayers() []RoomPlayer {
+	players := make([]RoomPlayer, 0, len(room.Players))
+	for _, p := range room.Players {
+		if p.Ready {
+			players = append(players, p)
+		}
+	}
+	return players
+}
+
 const (
 	RoomStatusWaiting  = "waiting"
 	RoomStatusStarting = "starting"
@@ -56,38 +109,42 @@ func NewRoomManager(redis *redis.Client, logger zerolog.Logger) *RoomManager {
 	return &RoomManager{
 		redis:  redis,
 		logger: logger,
-		rooms:  make(map[string]*PrivateRoom),
 	}
 }
 
-// CreateRoom generates a unique 6-character code and initializes a room.
+// CreateRoom generates a unique 6-digit code and initializes a room.
 // Guests cannot create rooms.
 func (r *RoomManager) CreateRoom(ctx context.Context, req PrivateRoomRequest) (string, *PrivateRoom, error) {
 	// Block guests from creating rooms
 	if req.IsGuest {
 		return "", nil, fmt.Errorf("guests cannot create private rooms")
 	}
-	
-	code := r.generateRoomCode()
+
 	// Default category to "general" if not provided
 	category := req.Category
 	if category == "" {
 		category = "general"
 	}
+	scoringRule := req.ScoringRule
+	if scoringRule == "" {
+		scoringRule = scoring.RuleClassic
+	}
 
 	room := &PrivateRoom{
-		RoomCode:           code,
 		HostID:             req.HostID,
 		MatchName:          req.MatchName,
 		MaxPlayers:         req.MaxPlayers,
 		QuestionCount:      req.QuestionCount,
 		PerQuestionSeconds: req.PerQuestionSeconds,
 		Category:           category,
+		MinReadyPlayers:    req.MinReadyPlayers,
+		ScoringRule:        scoringRule,
 		Players: []RoomPlayer{
 			{
 				UserID:   req.HostID,
 				Username: req.Username,
 				IsHost:   true,
+				Ready:    true, // the host signals readiness by starting the room
 				JoinedAt: time.Now(),
 			},
 		},
@@ -96,127 +153,264 @@ func (r *RoomManager) CreateRoom(ctx context.Context, req PrivateRoomRequest) (s
 		StartCountdown: 5, // default
 	}
 
-	r.mu.Lock()
-	r.rooms[code] = room
-	r.mu.Unlock()
+	// SETNX claims the code atomically, so two replicas can never hand out the same room.
+	for attempt := 0; attempt < roomCodeAttempts; attempt++ {
+		room.RoomCode = generateRoomCode()
+		data, err := json.Marshal(room)
+		if err != nil {
+			return "", nil, fmt.Errorf("marshal room: %w", err)
+		}
 
-	// Persist to Redis for multi-instance support
-	// For MVP, we'll use in-memory; Redis persistence can be added later
-	_ = r.redis
+		created, err := r.redis.SetNX(ctx, roomKey(room.RoomCode), data, roomTTL).Result()
+		if err != nil {
+			return "", nil, fmt.Errorf("store room: %w", err)
+		}
+		if !created {
+			continue
+		}
 
-	r.logger.Info().
-		Str("room_code", code).
-		Str("host_id", req.HostID.String()).
-		Msg("private room created")
+		r.logger.Info().
+			Str("room_code", room.RoomCode).
+			Str("host_id", req.HostID.String()).
+			Msg("private room created")
 
-	return code, room, nil
+		return room.RoomCode, room, nil
+	}
+
+	return "", nil, fmt.Errorf("could not allocate a room code")
 }
 
 // JoinRoom adds a player to an existing room.
 func (r *RoomManager) JoinRoom(ctx context.Context, roomCode string, userID uuid.UUID, username string, isGuest bool) (*PrivateRoom, error) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	room, err := r.update(ctx, roomCode, func(room *PrivateRoom) error {
+		return room.join(userID, username, isGuest)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	room, exists := r.rooms[roomCode]
-	if !exists {
-		return nil, fmt.Errorf("room not found")
+	r.logger.Info().
+		Str("room_code", roomCode).
+		Str("user_id", userID.String()).
+		Int("player_count", len(room.Players)).
+		Msg("player joined room")
+
+	return room, nil
+}
+
+// GetRoom retrieves room by code.
+func (r *RoomManager) GetRoom(ctx context.Context, roomCode string) (*PrivateRoom, error) {
+	return loadRoom(ctx, r.redis, roomCode)
+}
+
+// SetReady toggles a player's ready flag while the room is waiting.
+func (r *RoomManager) SetReady(ctx context.Context, roomCode string, userID uuid.UUID, ready bool) (*PrivateRoom, error) {
+	return r.update(ctx, roomCode, func(room *PrivateRoom) error {
+		return room.setReady(userID, ready)
+	})
+}
+
+// BeginStart validates the host's start request and moves the room to starting.
+// Returns the ready players that will take part in the match.
+func (r *RoomManager) BeginStart(ctx context.Context, roomCode string, userID uuid.UUID) (*PrivateRoom, []RoomPlayer, error) {
+	room, err := r.update(ctx, roomCode, func(room *PrivateRoom) error {
+		return room.beginStart(userID)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return room, room.ReadyPlayers(), nil
+}
+
+// AbortStart returns a starting room to waiting (e.g. when match creation fails).
+func (r *RoomManager) AbortStart(ctx context.Context, roomCode string) {
+	_, err := r.update(ctx, roomCode, func(room *PrivateRoom) error {
+		if room.Status == RoomStatusStarting && room.MatchID == nil {
+			room.Status = RoomStatusWaiting
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Warn().Err(err).Str("room_code", roomCode).Msg("failed to abort room start")
+	}
+}
+
+// StartRoom attaches the match to a starting room and fixes the countdown length.
+func (r *RoomManager) StartRoom(ctx context.Context, roomCode string, matchID uuid.UUID, countdownSeconds int) (*PrivateRoom, error) {
+	room, err := r.update(ctx, roomCode, func(room *PrivateRoom) error {
+		if room.Status != RoomStatusStarting {
+			return fmt.Errorf("room cannot be started")
+		}
+
+		room.MatchID = &matchID
+		if countdownSeconds > 0 {
+			room.StartCountdown = countdownSeconds
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	r.logger.Info().
+		Str("room_code", roomCode).
+		Str("match_id", matchID.String()).
+		Int("countdown", room.StartCountdown).
+		Msg("room starting")
+
+	return room, nil
+}
+
+// ActivateRoom marks a room active once its countdown has finished.
+func (r *RoomManager) ActivateRoom(ctx context.Context, roomCode string) error {
+	_, err := r.update(ctx, roomCode, func(room *PrivateRoom) error {
+		room.Status = RoomStatusActive
+		return nil
+	})
+	return err
+}
+
+// update applies fn to the stored room inside a WATCH/MULTI transaction, retrying
+// when another instance modified the room concurrently. The TTL is refreshed on write.
+func (r *RoomManager) update(ctx context.Context, roomCode string, fn func(room *PrivateRoom) error) (*PrivateRoom, error) {
+	key := roomKey(roomCode)
+
+	var updated *PrivateRoom
+	txf := func(tx *redis.Tx) error {
+		room, err := loadRoom(ctx, tx, roomCode)
+		if err != nil {
+			return err
+		}
+		if err := fn(room); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(room)
+		if err != nil {
+			return fmt.Errorf("marshal room: %w", err)
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, roomTTL)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		updated = room
+		return nil
+	}
+
+	for attempt := 0; attempt < roomUpdateRetries; attempt++ {
+		err := r.redis.Watch(ctx, txf, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return updated, nil
+	}
+
+	return nil, fmt.Errorf("room update contended, try again")
+}
+
+// loadRoom reads and decodes a room.
+func loadRoom(ctx context.Context, c redis.Cmdable, roomCode string) (*PrivateRoom, error) {
+	data, err := c.Get(ctx, roomKey(roomCode)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrRoomNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get room: %w", err)
+	}
+
+	var room PrivateRoom
+	if err := json.Unmarshal(data, &room); err != nil {
+		return nil, fmt.Errorf("unmarshal room: %w", err)
+	}
+	return &room, nil
+}
+
+func roomKey(roomCode string) string {
+	return fmt.Sprintf("room:%s", roomCode)
+}
+
+// join adds a player, enforcing status, capacity and duplicate checks.
+func (room *PrivateRoom) join(userID uuid.UUID, username string, isGuest bool) error {
 	if room.Status != RoomStatusWaiting {
-		return nil, fmt.Errorf("room not accepting players")
+		return fmt.Errorf("room not accepting players")
 	}
 
 	if len(room.Players) >= room.MaxPlayers {
-		return nil, fmt.Errorf("room full")
+		return fmt.Errorf("room full")
+	}
+
+	// Prevent host from joining their own room again
+	if userID == room.HostID {
+		return fmt.Errorf("host cannot join their own room again")
 	}
 
 	// Check if already joined (prevent self-matching/duplicate joins)
 	for _, p := range room.Players {
 		if p.UserID == userID {
-			return nil, fmt.Errorf("user already in room") // prevent duplicate joins
+			return fmt.Errorf("user already in room")
 		}
 	}
 
-	// Prevent host from joining their own room again
-	if userID == room.HostID {
-		return nil, fmt.Errorf("host cannot join their own room again")
-	}
-
 	room.Players = append(room.Players, RoomPlayer{
 		UserID:   userID,
 		Username: username,
 		IsGuest:  isGuest,
 		JoinedAt: time.Now(),
 	})
-
-	r.logger.Info().
-		Str("room_code", roomCode).
-		Str("user_id", userID.String()).
-		Int("player_count", len(room.Players)).
-		Msg("player joined room")
-
-	return room, nil
+	return nil
 }
 
-// GetRoom retrieves room by code.
-func (r *RoomManager) GetRoom(roomCode string) (*PrivateRoom, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+// setReady toggles a player's ready flag while the room is waiting.
+func (room *PrivateRoom) setReady(userID uuid.UUID, ready bool) error {
+	if room.Status != RoomStatusWaiting {
+		return fmt.Errorf("room not accepting ready changes")
+	}
 
-	room, exists := r.rooms[roomCode]
-	if !exists {
-		return nil, fmt.Errorf("room not found")
+	for i := range room.Players {
+		if room.Players[i].UserID == userID {
+			room.Players[i].Ready = ready
+			return nil
+		}
 	}
-	return room, nil
+	return fmt.Errorf("user not in room")
 }
 
-// StartRoom initiates countdown and transitions room to starting state.
-func (r *RoomManager) StartRoom(ctx context.Context, roomCode string, matchID uuid.UUID, countdownSeconds int) (*PrivateRoom, error) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	room, exists := r.rooms[roomCode]
-	if !exists {
-		return nil, fmt.Errorf("room not found")
+// beginStart checks the host's start request and moves the room to starting.
+func (room *PrivateRoom) beginStart(userID uuid.UUID) error {
+	if userID != room.HostID {
+		return fmt.Errorf("only the host can start the room")
 	}
 
 	if room.Status != RoomStatusWaiting {
-		return nil, fmt.Errorf("room cannot be started")
+		return fmt.Errorf("room cannot be started")
 	}
 
-	if len(room.Players) < 2 {
-		return nil, fmt.Errorf("need at least 2 players")
+	// Starting the room implies the host is ready.
+	for i := range room.Players {
+		if room.Players[i].IsHost {
+			room.Players[i].Ready = true
+		}
 	}
 
-	room.MatchID = &matchID
-	room.Status = RoomStatusStarting
-	if countdownSeconds > 0 {
-		room.StartCountdown = countdownSeconds
+	if !room.CanStart() {
+		return fmt.Errorf("not enough players ready")
 	}
 
-	r.logger.Info().
-		Str("room_code", roomCode).
-		Str("match_id", matchID.String()).
-		Int("countdown", room.StartCountdown).
-		Msg("room starting")
-
-	return room, nil
+	room.Status = RoomStatusStarting
+	return nil
 }
 
-// generateRoomCode creates a 6-digit numeric code (000000-999999).
-func (r *RoomManager) generateRoomCode() string {
-	for {
-		// Generate random number between 100000 and 999999
-		// Using 100000-999999 to ensure 6 digits (avoid leading zeros)
-		num := 100000 + rand.Intn(900000)
-		code := fmt.Sprintf("%06d", num)
-
-		// Ensure uniqueness
-		r.mu.RLock()
-		_, exists := r.rooms[code]
-		r.mu.RUnlock()
-		if !exists {
-			return code
-		}
-	}
+// generateRoomCode creates a 6-digit numeric code (100000-999999).
+// Uniqueness is enforced by CreateRoom's SETNX.
+func generateRoomCode() string {
+	// Using 100000-999999 to ensure 6 digits (avoid leading zeros)
+	num := 100000 + rand.Intn(900000)
+	return fmt.Sprintf("%06d", num)
 }
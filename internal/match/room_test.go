@@ -0,0 +1,69 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRoom(hostID uuid.UUID, maxPlayers, minReady int) *PrivateRoom {
+	return &PrivateRoom{
+		RoomCode:        "123456",
+		HostID:          hostID,
+		MaxPlayers:      maxPlayers,
+		MinReadyPlayers: minReady,
+		Status:          RoomStatusWaiting,
+		Players:         []RoomPlayer{{UserID: hostID, IsHost: true, Ready: true}},
+	}
+}
+
+func TestRoomReadyLifecycle(t *testing.T) {
+	hostID := uuid.New()
+	room := newTestRoom(hostID, 4, 2)
+	assert.False(t, room.CanStart(), "host alone cannot start")
+
+	guestA, guestB := uuid.New(), uuid.New()
+	require.NoError(t, room.join(guestA, "a", true))
+	require.NoError(t, room.join(guestB, "b", true))
+
+	assert.Error(t, room.beginStart(hostID), "nobody but the host is ready")
+
+	require.NoError(t, room.setReady(guestA, true))
+	assert.True(t, room.CanStart(), "minimum of two ready players reached")
+
+	assert.Error(t, room.beginStart(guestA), "only the host can start")
+
+	require.NoError(t, room.beginStart(hostID))
+	assert.Equal(t, RoomStatusStarting, room.Status)
+	assert.Len(t, room.ReadyPlayers(), 2)
+
+	assert.Error(t, room.setReady(guestB, true), "ready changes are closed once starting")
+}
+
+func TestRoomJoinRules(t *testing.T) {
+	hostID := uuid.New()
+	room := newTestRoom(hostID, 2, 0)
+
+	assert.Error(t, room.join(hostID, "host", false), "host cannot rejoin")
+
+	guest := uuid.New()
+	require.NoError(t, room.join(guest, "guest", true))
+	assert.Error(t, room.join(guest, "guest", true), "duplicate join")
+	assert.Error(t, room.join(uuid.New(), "late", true), "room full")
+}
+
+func TestRoomCanStartWhenEveryoneReady(t *testing.T) {
+	room := &PrivateRoom{
+		Players: []RoomPlayer{
+			{IsHost: true, Ready: true},
+			{Ready: true},
+			{Ready: false},
+		},
+	}
+	assert.False(t, room.CanStart())
+
+	room.Players[2].Ready = true
+	assert.True(t, room.CanStart())
+}
@@ -0,0 +1,282 @@
+package match
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	ws "github.com/gokatarajesh/quiz-platform/pkg/http/ws"
+)
+
+// Reasons a match ends, passed to FinalizeFunc.
+const (
+	EndReasonFinished = "match_finished" // every player answered, or a forfeit
+	EndReasonTimeout  = "match_timeout"  // GlobalTimeoutSeconds ran out
+)
+
+// FinalizeFunc finalizes a match and broadcasts the results to its players.
+type FinalizeFunc func(ctx context.Context, matchID uuid.UUID, reason string) error
+
+// OpenFunc delivers a question to the players of a match as its window opens.
+type OpenFunc func(ctx context.Context, match Match, startedAt time.Time, order int)
+
+// Runner drives the server-side clock of active matches.
+// It emits question_tick every second, opens each question when its window
+// starts (every PerQuestionSeconds), finalizes early once every player answered every
+// question or all but one player left, and force-finalizes when
+// GlobalTimeoutSeconds runs out.
+type Runner struct {
+	stateMgr *StateManager
+	hub      *ws.Hub
+	open     OpenFunc
+	finalize FinalizeFunc
+	interval time.Duration
+	logger   zerolog.Logger
+
+	mu     sync.Mutex
+	clocks map[uuid.UUID]*matchClock
+}
+
+// matchClock is the per-match timing state owned by a Runner goroutine.
+type matchClock struct {
+	match     Match
+	startedAt time.Time
+	poke      chan struct{}
+	cancel    context.CancelFunc
+}
+
+// NewRunner creates a match runner. open is invoked once per question as its
+// window starts; finalize is invoked exactly once per match when the match ends
+// (all answered or timeout).
+func NewRunner(stateMgr *StateManager, hub *ws.Hub, open OpenFunc, finalize FinalizeFunc, logger zerolog.Logger) *Runner {
+	return &Runner{
+		stateMgr: stateMgr,
+		hub:      hub,
+		open:     open,
+		finalize: finalize,
+		interval: time.Second,
+		logger:   logger.With().Str("component", "match_runner").Logger(),
+		clocks:   make(map[uuid.UUID]*matchClock),
+	}
+}
+
+// Start begins the clock for a match. startedAt should be the time the
+// question_batch was issued. Starting an already running match is a no-op.
+func (r *Runner) Start(match *Match, startedAt time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.clocks[match.ID]; exists {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	clock := &matchClock{
+		match:     *match,
+		startedAt: startedAt,
+		poke:      make(chan struct{}, 1),
+		cancel:    cancel,
+	}
+	r.clocks[match.ID] = clock
+
+	go r.run(ctx, clock)
+
+	r.logger.Info().
+		Str("match_id", match.ID.String()).
+		Int("question_count", match.QuestionCount).
+		Int("per_question_seconds", match.PerQuestionSeconds).
+		Int("global_timeout_seconds", match.GlobalTimeoutSeconds).
+		Msg("match clock started")
+}
+
+// Poke asks the runner to re-check completion of a match (e.g. after an accepted answer).
+func (r *Runner) Poke(matchID uuid.UUID) {
+	r.mu.Lock()
+	clock, exists := r.clocks[matchID]
+	r.mu.Unlock()
+
+	if !exists {
+		return
+	}
+	select {
+	case clock.poke <- struct{}{}:
+	default:
+	}
+}
+
+// Stop cancels the clock of a match without finalizing it.
+func (r *Runner) Stop(matchID uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if clock, exists := r.clocks[matchID]; exists {
+		clock.cancel()
+		delete(r.clocks, matchID)
+	}
+}
+
+// Running reports whether a clock is active for the match.
+func (r *Runner) Running(matchID uuid.UUID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.clocks[matchID]
+	return exists
+}
+
+func (r *Runner) run(ctx context.Context, clock *matchClock) {
+	defer r.Stop(clock.match.ID)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	deadline := clock.startedAt.Add(time.Duration(clock.match.GlobalTimeoutSeconds) * time.Second)
+
+	// Questions are only sent once their window opens, so none can be read early
+	opened := 0
+	next := time.NewTimer(0)
+	defer next.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-next.C:
+			order, _ := questionClock(clock.match, clock.startedAt, now)
+			if order > opened {
+				opened = order
+				if r.open != nil {
+					r.open(ctx, clock.match, clock.startedAt, order)
+				}
+			}
+			if order != 0 {
+				per := time.Duration(clock.match.PerQuestionSeconds) * time.Second
+				next.Reset(time.Until(clock.startedAt.Add(time.Duration(order) * per)))
+			}
+		case <-clock.poke:
+			if r.finished(ctx, clock.match) {
+				r.complete(clock.match.ID, EndReasonFinished)
+				return
+			}
+		case now := <-ticker.C:
+			if !now.Before(deadline) {
+				r.timeout(clock.match.ID)
+				return
+			}
+			r.sendTick(clock, now)
+			if r.finished(ctx, clock.match) {
+				r.complete(clock.match.ID, EndReasonFinished)
+				return
+			}
+		}
+	}
+}
+
+// sendTick broadcasts the active question and its remaining seconds.
+// Nothing is sent once the last question's window has elapsed.
+func (r *Runner) sendTick(clock *matchClock, now time.Time) {
+	order, remaining := questionClock(clock.match, clock.startedAt, now)
+	if order == 0 {
+		return
+	}
+
+	tick := ws.QuestionTickPayload{
+		MatchID:          clock.match.ID.String(),
+		QuestionOrder:    order,
+		RemainingSeconds: remaining,
+	}
+	msg := ws.Message{Type: ws.TypeQuestionTick}
+	msg.Payload, _ = json.Marshal(tick)
+	r.hub.BroadcastToMatch(clock.match.ID, msg)
+}
+
+// questionClock returns the active question order (1-based) and the whole seconds
+// left on it. It returns order 0 when every question window has elapsed.
+func questionClock(match Match, startedAt, now time.Time) (order int, remainingSeconds int) {
+	if match.PerQuestionSeconds <= 0 {
+		return 0, 0
+	}
+	per := time.Duration(match.PerQuestionSeconds) * time.Second
+	elapsed := now.Sub(startedAt)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	order = int(elapsed/per) + 1
+	if order > match.QuestionCount {
+		return 0, 0
+	}
+
+	remaining := per - elapsed%per
+	remainingSeconds = int((remaining + time.Second - 1) / time.Second)
+	return order, remainingSeconds
+}
+
+// finished reports whether the match can end early: every remaining player
+// has answered every question, or too few players remain to keep playing.
+func (r *Runner) finished(ctx context.Context, match Match) bool {
+	states, err := r.stateMgr.GetAllPlayerStates(ctx, match.ID)
+	if err != nil {
+		r.logger.Warn().Err(err).Str("match_id", match.ID.String()).Msg("failed to load player states")
+		return false
+	}
+	return matchOver(states, match.QuestionCount)
+}
+
+// matchOver is the completion rule behind finished. A match with at least two
+// players is over once at most one of them is still playing (a forfeit).
+func matchOver(states []PlayerState, questionCount int) bool {
+	if len(states) == 0 {
+		return false
+	}
+
+	remaining, allAnswered := 0, true
+	for _, state := range states {
+		if state.LeftAt != nil {
+			continue
+		}
+		remaining++
+		if len(state.Answers) < questionCount {
+			allAnswered = false
+		}
+	}
+	if len(states) >= 2 && remaining < 2 {
+		return true
+	}
+	return allAnswered
+}
+
+func (r *Runner) timeout(matchID uuid.UUID) {
+	payload := ws.MatchTimeoutPayload{
+		MatchID: matchID.String(),
+		Reason:  "global_timeout",
+	}
+	msg := ws.Message{Type: ws.TypeMatchTimeout}
+	msg.Payload, _ = json.Marshal(payload)
+	r.hub.BroadcastToMatch(matchID, msg)
+
+	r.logger.Info().Str("match_id", matchID.String()).Msg("match timed out")
+	r.complete(matchID, EndReasonTimeout)
+}
+
+// complete finalizes the match, retrying briefly when the match lock is contended.
+func (r *Runner) complete(matchID uuid.UUID, reason string) {
+	if r.finalize == nil {
+		return
+	}
+
+	var err error
+	for attempt := 0; attempt < 5; attempt++ {
+		err = r.finalize(context.Background(), matchID, reason)
+		if err == nil || errors.Is(err, ErrMatchFinalized) {
+			return
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	r.logger.Error().Err(err).Str("match_id", matchID.String()).Msg("failed to finalize match")
+}
@@ -0,0 +1,98 @@
+package match
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ws "github.com/gokatarajesh/quiz-platform/pkg/http/ws"
+)
+
+// newTestStateManager returns a state manager backed by an in-memory Redis.
+func newTestStateManager(t *testing.T) *StateManager {
+	t.Helper()
+	client := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewStateManager(client, zerolog.Nop())
+}
+
+func TestQuestionClock(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := Match{QuestionCount: 3, PerQuestionSeconds: 10}
+
+	cases := []struct {
+		name      string
+		elapsed   time.Duration
+		order     int
+		remaining int
+	}{
+		{"start", 0, 1, 10},
+		{"mid first question", 3500 * time.Millisecond, 1, 7},
+		{"second question", 10 * time.Second, 2, 10},
+		{"last second of last question", 29 * time.Second, 3, 1},
+		{"all questions elapsed", 30 * time.Second, 0, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			order, remaining := questionClock(m, start, start.Add(tc.elapsed))
+			assert.Equal(t, tc.order, order)
+			assert.Equal(t, tc.remaining, remaining)
+		})
+	}
+}
+
+func TestMatchOverOnForfeit(t *testing.T) {
+	now := time.Now()
+	answered := []AnswerRecord{{QuestionOrder: 1}, {QuestionOrder: 2}}
+
+	playing := []PlayerState{{}, {Answers: answered}}
+	assert.False(t, matchOver(playing, 2))
+
+	forfeited := []PlayerState{{LeftAt: &now}, {}}
+	assert.True(t, matchOver(forfeited, 2))
+
+	group := []PlayerState{{LeftAt: &now}, {Answers: answered}, {}}
+	assert.False(t, matchOver(group, 2))
+
+	group[2].Answers = answered
+	assert.True(t, matchOver(group, 2))
+}
+
+func TestRunnerOpensQuestionsAsTheirWindowsStart(t *testing.T) {
+	stateMgr := newTestStateManager(t)
+	match := Match{ID: uuid.New(), QuestionCount: 3, PerQuestionSeconds: 1, GlobalTimeoutSeconds: 10}
+	require.NoError(t, stateMgr.StorePlayerState(context.Background(), match.ID, uuid.New(), PlayerState{}))
+
+	type opening struct {
+		order int
+		at    time.Duration
+	}
+	openings := make(chan opening, 3)
+	startedAt := time.Now()
+	open := func(ctx context.Context, m Match, started time.Time, order int) {
+		assert.Equal(t, startedAt, started)
+		openings <- opening{order, time.Since(startedAt)}
+	}
+	runner := NewRunner(stateMgr, ws.NewHub(zerolog.Nop()), open, nil, zerolog.Nop())
+	runner.Start(&match, startedAt)
+	defer runner.Stop(match.ID)
+
+	for order := 1; order <= 3; order++ {
+		select {
+		case got := <-openings:
+			assert.Equal(t, order, got.order)
+			assert.GreaterOrEqual(t, got.at, time.Duration(order-1)*time.Second, "question %d opened early", order)
+			assert.Less(t, got.at, time.Duration(order-1)*time.Second+500*time.Millisecond, "question %d opened late", order)
+		case <-time.After(3 * time.Second):
+			t.Fatalf("question %d never opened", order)
+		}
+	}
+}
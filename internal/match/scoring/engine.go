@@ -63,18 +63,29 @@ func (e *Engine) CalculateScore(
 	}
 
 	// Streak bonus: percentage of base, capped
-	if currentStreak > 0 {
-		streakMultiplier := float64(currentStreak) * e.config.StreakBonusPercent
-		if streakMultiplier > e.config.MaxStreakBonus {
-			streakMultiplier = e.config.MaxStreakBonus
-		}
-		streakBonus := int(float64(e.config.BaseScore) * streakMultiplier)
-		score += streakBonus
-	}
+	score += int(float64(e.config.BaseScore) * e.StreakBonus(currentStreak))
 
 	return score
 }
 
+// Name identifies the classic rule.
+func (e *Engine) Name() string {
+	return RuleClassic
+}
+
+// StreakBonus returns the share of the base score earned for a streak of
+// consecutive correct answers, capped at MaxStreakBonus.
+func (e *Engine) StreakBonus(streak int) float64 {
+	if streak <= 0 {
+		return 0
+	}
+	multiplier := float64(streak) * e.config.StreakBonusPercent
+	if multiplier > e.config.MaxStreakBonus {
+		multiplier = e.config.MaxStreakBonus
+	}
+	return multiplier
+}
+
 // AnswerRecord represents a single answer for scoring (duplicated here to avoid import cycle).
 type AnswerRecord struct {
 	QuestionOrder int       `json:"question_order"`
@@ -83,65 +94,25 @@ type AnswerRecord struct {
 	SubmittedAt   time.Time `json:"submitted_at"`
 	IsCorrect     bool      `json:"is_correct"`
 	ScoreEarned   int       `json:"score_earned"`
+	LatencyMs     int       `json:"latency_ms"` // time from the question becoming active to the answer
 }
 
-// ComputeFinalScore aggregates all answers and returns total + accuracy + streak bonus percentage.
+// AnswerScore scores answers[i] under the classic rule from its recorded latency.
+// The streak is the run of correct answers immediately before it, so an answer
+// scores the same when it is submitted and when the match is finalized.
+func (e *Engine) AnswerScore(answers []AnswerRecord, i int, perQuestionTimeout time.Duration) int {
+	timeRemaining := perQuestionTimeout - time.Duration(answers[i].LatencyMs)*time.Millisecond
+	if timeRemaining < 0 {
+		timeRemaining = 0
+	}
+
+	return e.CalculateScore(answers[i].IsCorrect, timeRemaining, perQuestionTimeout, streakBefore(answers, i))
+}
+
+// ComputeFinalScore aggregates all answers under the classic rule and returns total + accuracy + streak bonus percentage.
 func (e *Engine) ComputeFinalScore(
 	answers []AnswerRecord,
 	perQuestionTimeout time.Duration,
 ) (totalScore int, accuracy float64, streakBonusPct float64) {
-	if len(answers) == 0 {
-		return 0, 0.0, 0.0
-	}
-
-	correctCount := 0
-	currentStreak := 0
-	maxStreak := 0
-
-	for i, ans := range answers {
-		if ans.IsCorrect {
-			correctCount++
-			currentStreak++
-			if currentStreak > maxStreak {
-				maxStreak = currentStreak
-			}
-		} else {
-			currentStreak = 0
-		}
-
-		// Recalculate with actual streak at submission time
-		// For simplicity, we use the streak up to this question
-		streakAtAnswer := 0
-		for j := i - 1; j >= 0; j-- {
-			if answers[j].IsCorrect {
-				streakAtAnswer++
-			} else {
-				break
-			}
-		}
-		if ans.IsCorrect {
-			streakAtAnswer++
-		}
-
-		timeRemaining := perQuestionTimeout - time.Since(ans.SubmittedAt)
-		if timeRemaining < 0 {
-			timeRemaining = 0
-		}
-
-		score := e.CalculateScore(ans.IsCorrect, timeRemaining, perQuestionTimeout, streakAtAnswer)
-		totalScore += score
-	}
-
-	accuracy = float64(correctCount) / float64(len(answers))
-
-	// Calculate final streak bonus percentage applied
-	if maxStreak > 0 {
-		streakMultiplier := float64(maxStreak) * e.config.StreakBonusPercent
-		if streakMultiplier > e.config.MaxStreakBonus {
-			streakMultiplier = e.config.MaxStreakBonus
-		}
-		streakBonusPct = streakMultiplier
-	}
-
-	return totalScore, accuracy, streakBonusPct
+	return FinalScore(e, answers, perQuestionTimeout)
 }
@@ -0,0 +1,189 @@
+package scoring
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+)
+
+// Scoring rule names, as chosen by room hosts and stored in match metadata.
+const (
+	RuleClassic         = "classic"          // base + linear time bonus + capped streak
+	RuleAccuracyOnly    = "accuracy_only"    // base points for a correct answer, speed and streaks ignored
+	RuleExponentialTime = "exponential_time" // like classic, but the time bonus decays exponentially
+	RuleNegativeMarking = "negative_marking" // like classic, but a wrong answer costs points
+)
+
+// MetadataKey is the match metadata field holding the match's scoring rule.
+const MetadataKey = "scoring_rule"
+
+const (
+	// timeDecayRate shapes the exponential time bonus: a quarter of the window in,
+	// about half the bonus is left.
+	timeDecayRate = 3.0
+	// wrongAnswerPenalty is the share of the base score a wrong answer costs under
+	// negative marking. Unanswered questions cost nothing.
+	wrongAnswerPenalty = 0.25
+)
+
+// ErrUnknownRule is returned for a scoring rule name that does not exist.
+var ErrUnknownRule = errors.New("unknown scoring rule")
+
+// ScoringRule decides how many points each answer in a match earns.
+type ScoringRule interface {
+	// Name identifies the rule in match metadata.
+	Name() string
+	// AnswerScore scores answers[i]. Only answers before i may affect it, so an
+	// answer scores the same when submitted and when the match is finalized.
+	AnswerScore(answers []AnswerRecord, i int, perQuestionTimeout time.Duration) int
+	// StreakBonus is the share of the base score a streak of correct answers adds.
+	StreakBonus(streak int) float64
+}
+
+// RuleNames lists every rule a match can be played under.
+func RuleNames() []string {
+	return []string{RuleClassic, RuleAccuracyOnly, RuleExponentialTime, RuleNegativeMarking}
+}
+
+// ValidRule reports whether name is a known rule.
+func ValidRule(name string) bool {
+	for _, rule := range RuleNames() {
+		if rule == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Rule returns the named rule built on the engine's constants. An empty name is
+// the classic rule.
+func (e *Engine) Rule(name string) (ScoringRule, error) {
+	switch name {
+	case "", RuleClassic:
+		return e, nil
+	case RuleAccuracyOnly:
+		return accuracyOnly{config: e.config}, nil
+	case RuleExponentialTime:
+		return exponentialTime{e}, nil
+	case RuleNegativeMarking:
+		return negativeMarking{e}, nil
+	default:
+		return nil, ErrUnknownRule
+	}
+}
+
+// RuleFromMetadata returns the rule name stored in a match's metadata. Matches
+// created before rules were selectable were scored with the classic rule.
+func RuleFromMetadata(metadata []byte) string {
+	var fields struct {
+		ScoringRule string `json:"scoring_rule"`
+	}
+	if len(metadata) > 0 {
+		_ = json.Unmarshal(metadata, &fields)
+	}
+	if fields.ScoringRule == "" {
+		return RuleClassic
+	}
+	return fields.ScoringRule
+}
+
+// FinalScore totals a player's answers under rule and returns total + accuracy +
+// the streak bonus share of their longest streak. The total never drops below zero.
+func FinalScore(rule ScoringRule, answers []AnswerRecord, perQuestionTimeout time.Duration) (totalScore int, accuracy float64, streakBonusPct float64) {
+	if len(answers) == 0 {
+		return 0, 0.0, 0.0
+	}
+
+	correctCount := 0
+	currentStreak := 0
+	maxStreak := 0
+
+	for i, ans := range answers {
+		if ans.IsCorrect {
+			correctCount++
+			currentStreak++
+			if currentStreak > maxStreak {
+				maxStreak = currentStreak
+			}
+		} else {
+			currentStreak = 0
+		}
+
+		totalScore += rule.AnswerScore(answers, i, perQuestionTimeout)
+	}
+	if totalScore < 0 {
+		totalScore = 0
+	}
+
+	accuracy = float64(correctCount) / float64(len(answers))
+	streakBonusPct = rule.StreakBonus(maxStreak)
+
+	return totalScore, accuracy, streakBonusPct
+}
+
+// streakBefore counts the correct answers immediately before answers[i].
+func streakBefore(answers []AnswerRecord, i int) int {
+	streak := 0
+	for j := i - 1; j >= 0 && answers[j].IsCorrect; j-- {
+		streak++
+	}
+	return streak
+}
+
+// accuracyOnly awards the base score for every correct answer and nothing else.
+type accuracyOnly struct {
+	config ScoringConfig
+}
+
+func (r accuracyOnly) Name() string { return RuleAccuracyOnly }
+
+func (r accuracyOnly) AnswerScore(answers []AnswerRecord, i int, perQuestionTimeout time.Duration) int {
+	if !answers[i].IsCorrect {
+		return 0
+	}
+	return r.config.BaseScore
+}
+
+func (r accuracyOnly) StreakBonus(streak int) float64 { return 0 }
+
+// exponentialTime scores like the classic rule, except the time bonus falls off
+// exponentially: fast answers earn most of it and it reaches zero at the timeout.
+type exponentialTime struct {
+	*Engine
+}
+
+func (r exponentialTime) Name() string { return RuleExponentialTime }
+
+func (r exponentialTime) AnswerScore(answers []AnswerRecord, i int, perQuestionTimeout time.Duration) int {
+	if !answers[i].IsCorrect {
+		return 0
+	}
+
+	score := r.config.BaseScore
+	if perQuestionTimeout > 0 {
+		elapsed := float64(time.Duration(answers[i].LatencyMs)*time.Millisecond) / float64(perQuestionTimeout)
+		elapsed = math.Min(math.Max(elapsed, 0), 1)
+		// Normalized so the bonus is the full amount at 0 and exactly zero at the timeout
+		floor := math.Exp(-timeDecayRate)
+		share := (math.Exp(-timeDecayRate*elapsed) - floor) / (1 - floor)
+		score += int(float64(r.config.MaxTimeBonus) * share)
+	}
+	score += int(float64(r.config.BaseScore) * r.StreakBonus(streakBefore(answers, i)))
+	return score
+}
+
+// negativeMarking scores like the classic rule, but a submitted wrong answer costs
+// a share of the base score, so guessing no longer pays.
+type negativeMarking struct {
+	*Engine
+}
+
+func (r negativeMarking) Name() string { return RuleNegativeMarking }
+
+func (r negativeMarking) AnswerScore(answers []AnswerRecord, i int, perQuestionTimeout time.Duration) int {
+	if !answers[i].IsCorrect && answers[i].Answer != "" {
+		return -int(float64(r.config.BaseScore) * wrongAnswerPenalty)
+	}
+	return r.Engine.AnswerScore(answers, i, perQuestionTimeout)
+}
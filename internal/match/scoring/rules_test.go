@@ -0,0 +1,90 @@
+package scoring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const per = 10 * time.Second
+
+func rule(t *testing.T, name string) ScoringRule {
+	t.Helper()
+	r, err := NewEngine(DefaultScoringConfig()).Rule(name)
+	require.NoError(t, err)
+	require.Equal(t, name, r.Name())
+	return r
+}
+
+func TestRuleLookup(t *testing.T) {
+	engine := NewEngine(DefaultScoringConfig())
+	for _, name := range RuleNames() {
+		assert.True(t, ValidRule(name))
+		r, err := engine.Rule(name)
+		require.NoError(t, err)
+		assert.Equal(t, name, r.Name())
+	}
+
+	r, err := engine.Rule("")
+	require.NoError(t, err)
+	assert.Equal(t, RuleClassic, r.Name())
+
+	_, err = engine.Rule("golf")
+	assert.ErrorIs(t, err, ErrUnknownRule)
+	assert.False(t, ValidRule("golf"))
+}
+
+func TestRuleFromMetadata(t *testing.T) {
+	assert.Equal(t, RuleClassic, RuleFromMetadata(nil))
+	assert.Equal(t, RuleClassic, RuleFromMetadata([]byte(`{"room_code":"482913"}`)))
+	assert.Equal(t, RuleNegativeMarking, RuleFromMetadata([]byte(`{"room_code":"482913","scoring_rule":"negative_marking"}`)))
+}
+
+func TestAccuracyOnlyIgnoresSpeedAndStreaks(t *testing.T) {
+	answers := []AnswerRecord{
+		{QuestionOrder: 1, Answer: "Mars", IsCorrect: true, LatencyMs: 300},
+		{QuestionOrder: 2, Answer: "Venus", IsCorrect: true, LatencyMs: 9000},
+		{QuestionOrder: 3, Answer: "Pluto", IsCorrect: false, LatencyMs: 2000},
+	}
+	total, accuracy, streak := FinalScore(rule(t, RuleAccuracyOnly), answers, per)
+	assert.Equal(t, 200, total)
+	assert.InDelta(t, 2.0/3.0, accuracy, 1e-9)
+	assert.Zero(t, streak)
+}
+
+func TestExponentialTimeDecaysFasterThanClassic(t *testing.T) {
+	exp := rule(t, RuleExponentialTime)
+	classic := rule(t, RuleClassic)
+	at := func(latencyMs int) []AnswerRecord {
+		return []AnswerRecord{{QuestionOrder: 1, Answer: "Mars", IsCorrect: true, LatencyMs: latencyMs}}
+	}
+
+	assert.Equal(t, 150, exp.AnswerScore(at(0), 0, per))
+	assert.Equal(t, 100, exp.AnswerScore(at(10000), 0, per))
+	// Halfway through, the linear bonus is half gone but the exponential one mostly is
+	assert.Equal(t, 125, classic.AnswerScore(at(5000), 0, per))
+	assert.Equal(t, 109, exp.AnswerScore(at(5000), 0, per))
+	assert.Zero(t, exp.AnswerScore([]AnswerRecord{{QuestionOrder: 1, Answer: "Pluto", LatencyMs: 100}}, 0, per))
+}
+
+func TestNegativeMarkingPenalizesWrongAnswers(t *testing.T) {
+	neg := rule(t, RuleNegativeMarking)
+	answers := []AnswerRecord{
+		{QuestionOrder: 1, Answer: "Pluto", IsCorrect: false, LatencyMs: 1000},
+		{QuestionOrder: 2, Answer: "", IsCorrect: false},
+		{QuestionOrder: 3, Answer: "Mars", IsCorrect: true, LatencyMs: 0},
+	}
+	assert.Equal(t, -25, neg.AnswerScore(answers, 0, per))
+	// Skipping a question costs nothing
+	assert.Zero(t, neg.AnswerScore(answers, 1, per))
+	assert.Equal(t, 150, neg.AnswerScore(answers, 2, per))
+
+	total, _, _ := FinalScore(neg, answers, per)
+	assert.Equal(t, 125, total)
+
+	// A match of wrong guesses bottoms out at zero
+	total, _, _ = FinalScore(neg, answers[:1], per)
+	assert.Zero(t, total)
+}
@@ -2,26 +2,46 @@ package match
 
 import (
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/rs/zerolog"
 
+	"github.com/gokatarajesh/quiz-platform/internal/anticheat"
 	"github.com/gokatarajesh/quiz-platform/internal/db/repository"
 	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
 	"github.com/gokatarajesh/quiz-platform/internal/leaderboard"
 	"github.com/gokatarajesh/quiz-platform/internal/match/queue"
 	"github.com/gokatarajesh/quiz-platform/internal/match/scoring"
+	"github.com/gokatarajesh/quiz-platform/internal/moderation"
 	"github.com/gokatarajesh/quiz-platform/internal/question"
+	"github.com/gokatarajesh/quiz-platform/internal/rating"
 	"github.com/gokatarajesh/quiz-platform/pkg/http/ws"
 )
 
+// ErrMatchFinalized is returned when finalizing a match that has already ended.
+var ErrMatchFinalized = errors.New("match already finalized")
+
+// ErrQuestionNotInMatch is returned when a question token does not belong to a match the player was in.
+var ErrQuestionNotInMatch = errors.New("question not found in match")
+
+// ErrQuestionNotActive is returned for an answer to a question whose window has not opened.
+var ErrQuestionNotActive = errors.New("question not active yet")
+
+// ErrAnswerTooLate is returned for an answer received after the question's deadline.
+var ErrAnswerTooLate = errors.New("answer received after question deadline")
+
+// answerGrace absorbs network delay on answers sent just before the deadline.
+// Such answers are accepted with no time bonus.
+const answerGrace = time.Second
+
 // Service orchestrates match lifecycle, scoring, and state transitions.
 type Service struct {
 	matchRepo     *repository.MatchRepository
@@ -30,8 +50,14 @@ type Service struct {
 	queueMgr      *queue.Manager
 	roomMgr       *RoomManager
 	leaderboard   *leaderboard.Service
+	reports       *moderation.Service
+	cheats        *anticheat.Service
+	ratings       *rating.Service
 	scoringEngine *scoring.Engine
+	botProfiles   map[string]BotProfile
+	resumeGrace   time.Duration
 	hmacKey       []byte
+	playerShuffle bool
 	logger        zerolog.Logger
 }
 
@@ -39,6 +65,10 @@ type Service struct {
 type ServiceOptions struct {
 	HMACSecret    []byte
 	ScoringConfig scoring.ScoringConfig
+	BotProfiles   map[string]BotProfile // per difficulty; missing levels use DefaultBotProfiles
+	ResumeGrace   time.Duration         // how long a disconnected player may reconnect before left_early (default 30s)
+	// ShufflePerPlayer gives every player their own option order instead of one per match.
+	ShufflePerPlayer bool
 }
 
 // NewService creates a match service with all dependencies.
@@ -49,6 +79,9 @@ func NewService(
 	queueMgr *queue.Manager,
 	roomMgr *RoomManager,
 	leaderboardSvc *leaderboard.Service,
+	reports *moderation.Service,
+	cheats *anticheat.Service,
+	ratings *rating.Service,
 	opts ServiceOptions,
 	logger zerolog.Logger,
 ) *Service {
@@ -57,6 +90,16 @@ func NewService(
 		scoringCfg = scoring.DefaultScoringConfig()
 	}
 
+	botProfiles := DefaultBotProfiles()
+	for level, profile := range opts.BotProfiles {
+		botProfiles[level] = profile
+	}
+
+	resumeGrace := opts.ResumeGrace
+	if resumeGrace <= 0 {
+		resumeGrace = 30 * time.Second
+	}
+
 	return &Service{
 		matchRepo:     matchRepo,
 		questionSvc:   questionSvc,
@@ -64,8 +107,14 @@ func NewService(
 		queueMgr:      queueMgr,
 		roomMgr:       roomMgr,
 		leaderboard:   leaderboardSvc,
+		reports:       reports,
+		cheats:        cheats,
+		ratings:       ratings,
 		scoringEngine: scoring.NewEngine(scoringCfg),
+		botProfiles:   botProfiles,
+		resumeGrace:   resumeGrace,
 		hmacKey:       opts.HMACSecret,
+		playerShuffle: opts.ShufflePerPlayer,
 		logger:        logger,
 	}
 }
@@ -101,8 +150,59 @@ func getFixedDifficultyDistribution(questionCount int) map[string]int {
 	}
 }
 
-// CreateRandomMatch creates a 1v1 match from a matched pair.
+// difficultyDistribution returns the pack's difficulty mix: every question at the
+// given difficulty when one is set, otherwise the fixed mix for the question count.
+func difficultyDistribution(questionCount int, difficulty string) map[string]int {
+	switch difficulty {
+	case question.DifficultyEasy, question.DifficultyMedium, question.DifficultyHard:
+		return map[string]int{difficulty: questionCount}
+	default:
+		return getFixedDifficultyDistribution(questionCount)
+	}
+}
+
+// pairMetadata records why the matcher paired two players and what they agreed
+// on. Returns nil for pairs that did not come from the matcher.
+func pairMetadata(pair *queue.MatchPair, questionCount int) []byte {
+	if pair.Reason == "" {
+		return nil
+	}
+	metadata, _ := json.Marshal(map[string]interface{}{
+		"pairing_reason": pair.Reason,
+		"rating_gap":     pair.RatingGap,
+		"question_count": questionCount,
+		"difficulty":     pair.Difficulty,
+	})
+	return metadata
+}
+
+// CreateRandomMatch creates a 1v1 match from a matched pair. The pack uses the
+// pair's agreed difficulty, or the fixed mix when neither player asked for one.
 func (s *Service) CreateRandomMatch(ctx context.Context, pair *queue.MatchPair, questionCount int, perQuestionSec int, category string) (*Match, []QuestionPackItem, error) {
+	return s.createPairMatch(ctx, ModeRandom1v1, pair, questionCount, perQuestionSec, category)
+}
+
+// CreateBotMatch creates a bot_fill match between a queued player and a bot.
+// Bot matches are never leaderboard eligible.
+func (s *Service) CreateBotMatch(ctx context.Context, player queue.WaitingPlayer, bot BotIdentity, questionCount int, perQuestionSec int, category string) (*Match, []QuestionPackItem, error) {
+	pair := &queue.MatchPair{
+		Player1: player,
+		Player2: queue.WaitingPlayer{
+			UserID:   bot.UserID,
+			Username: bot.Username,
+		},
+	}
+	return s.createPairMatch(ctx, ModeBotFill, pair, questionCount, perQuestionSec, category)
+}
+
+// BotProfile returns the configured bot identity and tuning for a difficulty level.
+func (s *Service) BotProfile(difficulty string) (BotIdentity, BotProfile) {
+	level := normalizeBotDifficulty(difficulty)
+	return botIdentities[level], s.botProfiles[level]
+}
+
+// createPairMatch creates a two-player match (random 1v1 or bot fill).
+func (s *Service) createPairMatch(ctx context.Context, mode string, pair *queue.MatchPair, questionCount int, perQuestionSec int, category string) (*Match, []QuestionPackItem, error) {
 	matchID := uuid.New()
 	seedHash := fmt.Sprintf("%s-%d", matchID.String(), time.Now().Unix())
 
@@ -110,34 +210,30 @@ func (s *Service) CreateRandomMatch(ctx context.Context, pair *queue.MatchPair,
 	globalTimeout := (questionCount * perQuestionSec) + 20 // padding
 
 	// Create match record
-	pgMatchID := pgtype.UUID{}
-	if err := pgMatchID.Scan(matchID); err != nil {
-		return nil, nil, fmt.Errorf("scan uuid: %w", err)
-	}
+	pgMatchID := pgtype.UUID{Bytes: matchID, Valid: true}
 
-	pgPlayer1ID := pgtype.UUID{}
-	if err := pgPlayer1ID.Scan(pair.Player1.UserID); err != nil {
-		return nil, nil, fmt.Errorf("scan uuid: %w", err)
-	}
+	pgPlayer1ID := pgtype.UUID{Bytes: pair.Player1.UserID, Valid: true}
 
 	createParams := sqlcgen.CreateMatchParams{
-		Mode:                 ModeRandom1v1,
+		Mode:                 mode,
 		QuestionCount:        int16(questionCount),
 		PerQuestionSeconds:   int16(perQuestionSec),
 		GlobalTimeoutSeconds: int16(globalTimeout),
 		SeedHash:             seedHash,
-		LeaderboardEligible:  !pair.Player1.IsGuest && !pair.Player2.IsGuest, // only if both registered
+		LeaderboardEligible:  mode == ModeRandom1v1 && !pair.Player1.IsGuest && !pair.Player2.IsGuest, // only if both registered
 		Status:               StatusPending,
 		CreatedBy:            pgPlayer1ID,
 	}
+	// Record why the matcher paired these two
+	createParams.Metadata = pairMetadata(pair, questionCount)
 
 	_, err := s.matchRepo.Create(ctx, createParams)
 	if err != nil {
 		return nil, nil, fmt.Errorf("create match: %w", err)
 	}
 
-	// Get fixed difficulty distribution based on question count
-	diffCounts := getFixedDifficultyDistribution(questionCount)
+	// All questions at the agreed difficulty, or the fixed mix for the question count
+	diffCounts := difficultyDistribution(questionCount, pair.Difficulty)
 
 	// Get both player IDs for fair uniqueness checking
 	player1ID := pair.Player1.UserID
@@ -155,7 +251,7 @@ func (s *Service) CreateRandomMatch(ctx context.Context, pair *queue.MatchPair,
 		Seed:               seedHash,
 		PerQuestionSeconds: perQuestionSec,
 		UserIDs:            []*uuid.UUID{&player1ID, &player2ID}, // Pass both players for fair checking
-		MatchMode:          ModeRandom1v1,
+		MatchMode:          mode,
 	}
 
 	packResp, err := s.questionSvc.FetchPack(ctx, packReq)
@@ -163,16 +259,14 @@ func (s *Service) CreateRandomMatch(ctx context.Context, pair *queue.MatchPair,
 		return nil, nil, fmt.Errorf("fetch questions: %w", err)
 	}
 
-	// Convert to pack items with HMAC tokens
+	// Convert to pack items; tokens are signed per player when the batch is issued
 	packItems := make([]QuestionPackItem, len(packResp.Questions))
 	for i, q := range packResp.Questions {
-		token := s.signQuestionToken(q.ID, q.Answer)
 		packItems[i] = QuestionPackItem{
 			Order:         i + 1,
 			ID:            q.ID,
 			Prompt:        q.Prompt,
 			Options:       q.Options,
-			Token:         token,
 			CorrectAnswer: q.Answer,
 		}
 	}
@@ -181,27 +275,27 @@ func (s *Service) CreateRandomMatch(ctx context.Context, pair *queue.MatchPair,
 	if err := s.stateMgr.StoreMatchQuestions(ctx, matchID, packItems); err != nil {
 		s.logger.Warn().Err(err).Msg("failed to cache questions")
 	}
+	s.recordMatchQuestions(ctx, pgMatchID, packItems)
 
 	// Save question IDs to user history for 1v1 matches (for cross-match uniqueness)
 	questionIDs := make([]string, len(packItems))
 	for i, item := range packItems {
 		questionIDs[i] = item.ID
 	}
-	// Save for both players
-	if err := s.questionSvc.AddUserQuestionHistory(ctx, pair.Player1.UserID, questionIDs); err != nil {
-		s.logger.Warn().Err(err).Str("user_id", pair.Player1.UserID.String()).Msg("failed to save question history")
-	}
-	if err := s.questionSvc.AddUserQuestionHistory(ctx, pair.Player2.UserID, questionIDs); err != nil {
-		s.logger.Warn().Err(err).Str("user_id", pair.Player2.UserID.String()).Msg("failed to save question history")
+	// Save for both players (bots keep no history)
+	for _, player := range []queue.WaitingPlayer{pair.Player1, pair.Player2} {
+		if IsBotUser(player.UserID) {
+			continue
+		}
+		if err := s.questionSvc.AddUserQuestionHistory(ctx, player.UserID, questionIDs); err != nil {
+			s.logger.Warn().Err(err).Str("user_id", player.UserID.String()).Msg("failed to save question history")
+		}
 	}
 
 	// Initialize player states
 	now := time.Now()
 	for _, player := range []queue.WaitingPlayer{pair.Player1, pair.Player2} {
-		pgUserID := pgtype.UUID{}
-		if err := pgUserID.Scan(player.UserID); err != nil {
-			continue
-		}
+		pgUserID := pgtype.UUID{Bytes: player.UserID, Valid: true}
 
 		state := PlayerState{
 			MatchID:     matchID,
@@ -210,12 +304,18 @@ func (s *Service) CreateRandomMatch(ctx context.Context, pair *queue.MatchPair,
 			Username:    player.Username,
 			JoinedAt:    now,
 			Status:      PlayerStatusQueued,
+			IsBot:       IsBotUser(player.UserID),
 			Answers:     []AnswerRecord{},
 		}
 
 		if err := s.stateMgr.StorePlayerState(ctx, matchID, player.UserID, state); err != nil {
 			s.logger.Warn().Err(err).Str("user_id", player.UserID.String()).Msg("failed to store initial state")
 		}
+		if !state.IsBot {
+			if err := s.stateMgr.SetActiveMatch(ctx, player.UserID, matchID); err != nil {
+				s.logger.Warn().Err(err).Str("user_id", player.UserID.String()).Msg("failed to index active match")
+			}
+		}
 
 		// Also persist to DB
 		playerParams := sqlcgen.CreatePlayerMatchStateParams{
@@ -231,7 +331,7 @@ func (s *Service) CreateRandomMatch(ctx context.Context, pair *queue.MatchPair,
 
 	match := &Match{
 		ID:                   matchID,
-		Mode:                 ModeRandom1v1,
+		Mode:                 mode,
 		QuestionCount:        questionCount,
 		PerQuestionSeconds:   perQuestionSec,
 		GlobalTimeoutSeconds: globalTimeout,
@@ -246,7 +346,7 @@ func (s *Service) CreateRandomMatch(ctx context.Context, pair *queue.MatchPair,
 }
 
 // CreatePrivateMatch creates a match from a private room.
-func (s *Service) CreatePrivateMatch(ctx context.Context, roomCode string, players []RoomPlayer, questionCount int, perQuestionSec int, category string) (*Match, []QuestionPackItem, error) {
+func (s *Service) CreatePrivateMatch(ctx context.Context, roomCode string, players []RoomPlayer, questionCount int, perQuestionSec int, category string, scoringRule string) (*Match, []QuestionPackItem, error) {
 	matchID := uuid.New()
 	seedHash := fmt.Sprintf("%s-%d", matchID.String(), time.Now().Unix())
 
@@ -254,21 +354,21 @@ func (s *Service) CreatePrivateMatch(ctx context.Context, roomCode string, playe
 	globalTimeout := (questionCount * perQuestionSec) + 20 // padding
 
 	// Create match record with room code in metadata
-	pgMatchID := pgtype.UUID{}
-	if err := pgMatchID.Scan(matchID); err != nil {
-		return nil, nil, fmt.Errorf("scan uuid: %w", err)
-	}
+	pgMatchID := pgtype.UUID{Bytes: matchID, Valid: true}
 
 	pgHostID := pgtype.UUID{}
 	if len(players) > 0 {
-		if err := pgHostID.Scan(players[0].UserID); err != nil {
-			return nil, nil, fmt.Errorf("scan host uuid: %w", err)
-		}
+		pgHostID = pgtype.UUID{Bytes: players[0].UserID, Valid: true}
 	}
 
-	// Store room code in metadata
+	// Store room code and scoring rule in metadata so finalizing and any
+	// re-scoring use the rule the host picked
+	if scoringRule == "" {
+		scoringRule = scoring.RuleClassic
+	}
 	metadata := map[string]interface{}{
-		"room_code": roomCode,
+		"room_code":         roomCode,
+		scoring.MetadataKey: scoringRule,
 	}
 	metadataJSON, _ := json.Marshal(metadata)
 
@@ -313,16 +413,14 @@ func (s *Service) CreatePrivateMatch(ctx context.Context, roomCode string, playe
 		return nil, nil, fmt.Errorf("fetch questions: %w", err)
 	}
 
-	// Convert to pack items with HMAC tokens
+	// Convert to pack items; tokens are signed per player when the batch is issued
 	packItems := make([]QuestionPackItem, len(packResp.Questions))
 	for i, q := range packResp.Questions {
-		token := s.signQuestionToken(q.ID, q.Answer)
 		packItems[i] = QuestionPackItem{
 			Order:         i + 1,
 			ID:            q.ID,
 			Prompt:        q.Prompt,
 			Options:       q.Options,
-			Token:         token,
 			CorrectAnswer: q.Answer,
 		}
 	}
@@ -331,14 +429,12 @@ func (s *Service) CreatePrivateMatch(ctx context.Context, roomCode string, playe
 	if err := s.stateMgr.StoreMatchQuestions(ctx, matchID, packItems); err != nil {
 		s.logger.Warn().Err(err).Msg("failed to cache questions")
 	}
+	s.recordMatchQuestions(ctx, pgMatchID, packItems)
 
 	// Initialize player states
 	now := time.Now()
 	for _, player := range players {
-		pgUserID := pgtype.UUID{}
-		if err := pgUserID.Scan(player.UserID); err != nil {
-			continue
-		}
+		pgUserID := pgtype.UUID{Bytes: player.UserID, Valid: true}
 
 		state := PlayerState{
 			MatchID:     matchID,
@@ -353,6 +449,11 @@ func (s *Service) CreatePrivateMatch(ctx context.Context, roomCode string, playe
 		if err := s.stateMgr.StorePlayerState(ctx, matchID, player.UserID, state); err != nil {
 			s.logger.Warn().Err(err).Str("user_id", player.UserID.String()).Msg("failed to store initial state")
 		}
+		if !state.IsBot {
+			if err := s.stateMgr.SetActiveMatch(ctx, player.UserID, matchID); err != nil {
+				s.logger.Warn().Err(err).Str("user_id", player.UserID.String()).Msg("failed to index active match")
+			}
+		}
 
 		// Also persist to DB
 		playerParams := sqlcgen.CreatePlayerMatchStateParams{
@@ -383,72 +484,82 @@ func (s *Service) CreatePrivateMatch(ctx context.Context, roomCode string, playe
 }
 
 // SubmitAnswer processes a player's answer with server-side validation and scoring.
-func (s *Service) SubmitAnswer(ctx context.Context, matchID uuid.UUID, userID uuid.UUID, questionToken string, answer string, submittedAt time.Time) error {
+// optionIndex selects an option in the order the player was shown; when nil, the
+// answer text is matched against the options instead. It returns the order of the
+// answered question, taken from the token.
+func (s *Service) SubmitAnswer(ctx context.Context, matchID uuid.UUID, userID uuid.UUID, questionToken string, answer string, optionIndex *int, submittedAt time.Time) (int, error) {
+	// The signature is checked before any state is read
+	token, err := s.verifyQuestionToken(questionToken, matchID, userID)
+	if err != nil {
+		return 0, err
+	}
+	if submittedAt.After(token.ExpiresAt) {
+		return 0, ErrQuestionTokenExpired
+	}
+	questionOrder := token.Order
+
 	unlock, err := s.stateMgr.LockMatch(ctx, matchID)
 	if err != nil {
-		return fmt.Errorf("acquire lock: %w", err)
+		return 0, fmt.Errorf("acquire lock: %w", err)
 	}
 	defer unlock()
 
 	// Get player state
 	state, err := s.stateMgr.GetPlayerState(ctx, matchID, userID)
 	if err != nil || state == nil {
-		return fmt.Errorf("player state not found")
+		return 0, fmt.Errorf("player state not found")
 	}
 
 	// Get match questions
 	questions, err := s.stateMgr.GetMatchQuestions(ctx, matchID)
 	if err != nil || len(questions) == 0 {
-		return fmt.Errorf("questions not found")
+		return 0, fmt.Errorf("questions not found")
 	}
 
-	// Find question by token and validate
 	var targetQuestion *QuestionPackItem
-	var questionOrder int
-	for _, q := range questions {
-		if q.Token == questionToken {
-			targetQuestion = &q
-			questionOrder = q.Order
+	for i := range questions {
+		if questions[i].Order == questionOrder {
+			targetQuestion = &questions[i]
 			break
 		}
 	}
-
 	if targetQuestion == nil {
-		return fmt.Errorf("invalid question token")
+		return 0, ErrInvalidQuestionToken
 	}
 
 	// Check if already answered
 	for _, ans := range state.Answers {
 		if ans.QuestionOrder == questionOrder {
-			return fmt.Errorf("question already answered")
+			return 0, fmt.Errorf("question already answered")
 		}
 	}
 
-	// Validate answer
-	isCorrect := answer == targetQuestion.CorrectAnswer
-
-	// Get match config for per-question timeout
+	// Get match config for per-question timeout and the option seed
 	perQuestionTimeout := 15 * time.Second // default fallback
+	var seedHash string
+	var rule scoring.ScoringRule = s.scoringEngine
 	if meta, err := s.matchRepo.GetSummary(ctx, matchID); err == nil {
 		perQuestionTimeout = time.Duration(meta.PerQuestionSeconds) * time.Second
+		seedHash = meta.SeedHash
+		rule = s.scoringRule(matchID, meta.Metadata)
 	}
 
-	// Count current streak
-	streak := 0
-	for i := len(state.Answers) - 1; i >= 0; i-- {
-		if state.Answers[i].IsCorrect {
-			streak++
-		} else {
-			break
-		}
+	// Validate answer against the options as this player saw them
+	shown := shuffleOptions(s.hmacKey, targetQuestion.Options, seedHash, questionOrder, userID, s.playerShuffle)
+	answer, err = resolveAnswer(shown, optionIndex, answer)
+	if err != nil {
+		return 0, err
 	}
+	isCorrect := answer == targetQuestion.CorrectAnswer
 
-	timeRemaining := perQuestionTimeout - time.Since(submittedAt)
-	if timeRemaining < 0 {
-		timeRemaining = 0
+	issuedAt, ok := state.QuestionIssuedAt[questionOrder]
+	if !ok {
+		return 0, ErrQuestionNotActive
+	}
+	latency, err := answerLatency(issuedAt, submittedAt, perQuestionTimeout)
+	if err != nil {
+		return 0, err
 	}
-
-	score := s.scoringEngine.CalculateScore(isCorrect, timeRemaining, perQuestionTimeout, streak)
 
 	// Record answer
 	answerRecord := AnswerRecord{
@@ -457,14 +568,19 @@ func (s *Service) SubmitAnswer(ctx context.Context, matchID uuid.UUID, userID uu
 		Answer:        answer,
 		SubmittedAt:   submittedAt,
 		IsCorrect:     isCorrect,
-		ScoreEarned:   score,
+		LatencyMs:     int(latency / time.Millisecond),
 	}
 
+	// Score exactly as FinalizeMatch will
+	scoringAnswers := toScoringAnswers(append(state.Answers, answerRecord))
+	score := rule.AnswerScore(scoringAnswers, len(scoringAnswers)-1, perQuestionTimeout)
+	answerRecord.ScoreEarned = score
+
 	state.Answers = append(state.Answers, answerRecord)
 
 	// Update state
 	if err := s.stateMgr.StorePlayerState(ctx, matchID, userID, *state); err != nil {
-		return fmt.Errorf("store state: %w", err)
+		return 0, fmt.Errorf("store state: %w", err)
 	}
 
 	s.logger.Info().
@@ -473,53 +589,466 @@ func (s *Service) SubmitAnswer(ctx context.Context, matchID uuid.UUID, userID uu
 		Int("question_order", questionOrder).
 		Bool("correct", isCorrect).
 		Int("score", score).
+		Int("latency_ms", answerRecord.LatencyMs).
 		Msg("answer submitted")
 
+	return questionOrder, nil
+}
+
+// answerLatency returns how long after issuedAt an answer arrived. Answers before
+// the question became active are rejected, as are answers past the deadline plus
+// answerGrace. Latency is capped at the question window.
+func answerLatency(issuedAt, submittedAt time.Time, perQuestionTimeout time.Duration) (time.Duration, error) {
+	latency := submittedAt.Sub(issuedAt)
+	if latency < 0 {
+		return 0, ErrQuestionNotActive
+	}
+	if latency > perQuestionTimeout+answerGrace {
+		return 0, ErrAnswerTooLate
+	}
+	if latency > perQuestionTimeout {
+		latency = perQuestionTimeout
+	}
+	return latency, nil
+}
+
+// questionSchedule returns when each question becomes active: question N opens
+// (N-1) windows after the match clock starts.
+func questionSchedule(questions []QuestionPackItem, startedAt time.Time, perQuestionTimeout time.Duration) map[int]time.Time {
+	schedule := make(map[int]time.Time, len(questions))
+	for _, q := range questions {
+		schedule[q.Order] = startedAt.Add(time.Duration(q.Order-1) * perQuestionTimeout)
+	}
+	return schedule
+}
+
+func toScoringAnswers(answers []AnswerRecord) []scoring.AnswerRecord {
+	out := make([]scoring.AnswerRecord, len(answers))
+	for i, ans := range answers {
+		out[i] = scoring.AnswerRecord{
+			QuestionOrder: ans.QuestionOrder,
+			QuestionToken: ans.QuestionToken,
+			Answer:        ans.Answer,
+			SubmittedAt:   ans.SubmittedAt,
+			IsCorrect:     ans.IsCorrect,
+			ScoreEarned:   ans.ScoreEarned,
+			LatencyMs:     ans.LatencyMs,
+		}
+	}
+	return out
+}
+
+// scoringRule resolves the rule stored in a match's metadata. An unknown name
+// falls back to the classic rule rather than failing the match.
+func (s *Service) scoringRule(matchID uuid.UUID, metadata []byte) scoring.ScoringRule {
+	name := scoring.RuleFromMetadata(metadata)
+	rule, err := s.scoringEngine.Rule(name)
+	if err != nil {
+		s.logger.Warn().Str("match_id", matchID.String()).Str("scoring_rule", name).Msg("unknown scoring rule, using classic")
+		return s.scoringEngine
+	}
+	return rule
+}
+
+// CancelMatch cancels a match that was created but never started, and releases
+// its players so they are not resumed into it.
+func (s *Service) CancelMatch(ctx context.Context, matchID uuid.UUID, players []RoomPlayer) error {
+	pgMatchID := pgtype.UUID{Bytes: matchID, Valid: true}
+
+	updateParams := sqlcgen.UpdateMatchStatusParams{
+		MatchID:     pgMatchID,
+		Status:      StatusCancelled,
+		CompletedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+	if err := s.matchRepo.UpdateStatus(ctx, updateParams); err != nil {
+		return fmt.Errorf("update match status: %w", err)
+	}
+
+	for _, player := range players {
+		if err := s.stateMgr.ClearActiveMatch(ctx, player.UserID, matchID); err != nil {
+			s.logger.Warn().Err(err).Str("user_id", player.UserID.String()).Msg("failed to clear active match")
+		}
+	}
 	return nil
 }
 
-// signQuestionToken creates HMAC-signed token for anti-cheat.
-func (s *Service) signQuestionToken(questionID, correctAnswer string) string {
-	if len(s.hmacKey) == 0 {
-		return questionID
+// StartMatch marks a match and its players active as its clock starts.
+func (s *Service) StartMatch(ctx context.Context, matchID uuid.UUID, startedAt time.Time) error {
+	pgMatchID := pgtype.UUID{Bytes: matchID, Valid: true}
+
+	updateParams := sqlcgen.UpdateMatchStatusParams{
+		MatchID:   pgMatchID,
+		Status:    StatusActive,
+		StartedAt: pgtype.Timestamptz{Time: startedAt, Valid: true},
 	}
-	payload := fmt.Sprintf("%s:%s", questionID, correctAnswer)
-	mac := hmac.New(sha256.New, s.hmacKey)
-	mac.Write([]byte(payload))
-	return hex.EncodeToString(mac.Sum(nil))
+	if err := s.matchRepo.UpdateStatus(ctx, updateParams); err != nil {
+		return fmt.Errorf("update match status: %w", err)
+	}
+
+	unlock, err := s.stateMgr.LockMatch(ctx, matchID)
+	if err != nil {
+		return fmt.Errorf("acquire lock: %w", err)
+	}
+	defer unlock()
+
+	states, err := s.stateMgr.GetAllPlayerStates(ctx, matchID)
+	if err != nil {
+		return fmt.Errorf("get states: %w", err)
+	}
+
+	// Record when each question is due to open so answers are timed from the server
+	// clock; OpenQuestion replaces these with the actual delivery times
+	questions, err := s.stateMgr.GetMatchQuestions(ctx, matchID)
+	if err != nil {
+		return fmt.Errorf("get questions: %w", err)
+	}
+	perQuestionTimeout := 15 * time.Second // default fallback
+	if meta, err := s.matchRepo.GetSummary(ctx, matchID); err == nil {
+		perQuestionTimeout = time.Duration(meta.PerQuestionSeconds) * time.Second
+	}
+	schedule := questionSchedule(questions, startedAt, perQuestionTimeout)
+
+	for _, state := range states {
+		state.Status = PlayerStatusActive
+		state.QuestionIssuedAt = schedule
+		if err := s.stateMgr.StorePlayerState(ctx, matchID, state.UserID, state); err != nil {
+			s.logger.Warn().Err(err).Str("user_id", state.UserID.String()).Msg("failed to activate player state")
+		}
+	}
+	return nil
+}
+
+// OpenQuestion returns each human player's copy of the question with the given
+// order, signed with their own token for the clock started at startedAt, and
+// records openedAt as the time it was issued to them so answers are timed from
+// delivery. Bots are issued theirs directly through QuestionsForPlayer.
+func (s *Service) OpenQuestion(ctx context.Context, match *Match, startedAt time.Time, order int, openedAt time.Time) (map[uuid.UUID][]QuestionPackItem, error) {
+	questions, err := s.stateMgr.GetMatchQuestions(ctx, match.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get questions: %w", err)
+	}
+	var open []QuestionPackItem
+	for _, q := range questions {
+		if q.Order == order {
+			open = append(open, q)
+		}
+	}
+	if len(open) == 0 {
+		return nil, nil
+	}
+
+	// Answers hold the lock only briefly; without it the scheduled open time stands
+	var unlock func() error
+	for attempt := 0; attempt < 5; attempt++ {
+		if unlock, err = s.stateMgr.LockMatch(ctx, match.ID); err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if unlock != nil {
+		defer unlock()
+	} else {
+		s.logger.Warn().Err(err).Str("match_id", match.ID.String()).Int("question_order", order).Msg("failed to record question issue time")
+	}
+
+	states, err := s.stateMgr.GetAllPlayerStates(ctx, match.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get states: %w", err)
+	}
+
+	batches := make(map[uuid.UUID][]QuestionPackItem, len(states))
+	for _, state := range states {
+		if state.IsBot || state.LeftAt != nil {
+			continue
+		}
+		if unlock != nil {
+			if state.QuestionIssuedAt == nil {
+				state.QuestionIssuedAt = make(map[int]time.Time)
+			}
+			state.QuestionIssuedAt[order] = openedAt
+			if err := s.stateMgr.StorePlayerState(ctx, match.ID, state.UserID, state); err != nil {
+				s.logger.Warn().Err(err).Str("user_id", state.UserID.String()).Msg("failed to record question issue time")
+			}
+		}
+		batches[state.UserID] = s.QuestionsForPlayer(match, state.UserID, open, startedAt)
+	}
+	return batches, nil
 }
 
-// FinalizeMatch computes final scores and updates DB.
-// Returns match complete payload for WebSocket broadcast.
-func (s *Service) FinalizeMatch(ctx context.Context, matchID uuid.UUID) (*ws.MatchCompletePayload, error) {
+// Progress returns every player's answered/pending counts for a match without
+// revealing correctness. The requesting user must be a player in the match.
+func (s *Service) Progress(ctx context.Context, matchID uuid.UUID, userID uuid.UUID) (*ws.ProgressUpdatePayload, error) {
+	states, err := s.stateMgr.GetAllPlayerStates(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("get states: %w", err)
+	}
+
+	isPlayer := false
+	for _, state := range states {
+		if state.UserID == userID {
+			isPlayer = true
+			break
+		}
+	}
+	if !isPlayer {
+		return nil, fmt.Errorf("player not in match")
+	}
+
+	questions, err := s.stateMgr.GetMatchQuestions(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("get questions: %w", err)
+	}
+
+	payload := buildProgress(matchID, states, len(questions))
+	return &payload, nil
+}
+
+// buildProgress summarizes player states in a stable (join order) order.
+func buildProgress(matchID uuid.UUID, states []PlayerState, questionCount int) ws.ProgressUpdatePayload {
+	sort.Slice(states, func(i, j int) bool {
+		if states[i].JoinedAt.Equal(states[j].JoinedAt) {
+			return states[i].UserID.String() < states[j].UserID.String()
+		}
+		return states[i].JoinedAt.Before(states[j].JoinedAt)
+	})
+
+	players := make([]ws.PlayerProgress, len(states))
+	for i, state := range states {
+		answered := len(state.Answers)
+		pending := questionCount - answered
+		if pending < 0 {
+			pending = 0
+		}
+		players[i] = ws.PlayerProgress{
+			UserID:   state.UserID.String(),
+			Answered: answered,
+			Pending:  pending,
+			Status:   state.Status,
+		}
+	}
+
+	return ws.ProgressUpdatePayload{
+		MatchID: matchID.String(),
+		Players: players,
+	}
+}
+
+// ResumeMatch returns the snapshot a reconnecting player needs to continue their
+// active match, or nil if the user has no match in progress.
+func (s *Service) ResumeMatch(ctx context.Context, userID uuid.UUID) (*ResumeSnapshot, error) {
+	matchID, err := s.stateMgr.GetActiveMatch(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if matchID == uuid.Nil {
+		return nil, nil
+	}
+
+	meta, err := s.matchRepo.GetSummary(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("get match: %w", err)
+	}
+	if meta.Status == StatusCompleted || meta.Status == StatusTimeout || meta.Status == StatusCancelled {
+		_ = s.stateMgr.ClearActiveMatch(ctx, userID, matchID)
+		return nil, nil
+	}
+	if meta.Status != StatusActive || !meta.StartedAt.Valid {
+		// Still counting down; the question batch will arrive normally.
+		return nil, nil
+	}
+
+	state, err := s.stateMgr.GetPlayerState(ctx, matchID, userID)
+	if err != nil || state == nil {
+		return nil, fmt.Errorf("player state not found")
+	}
+	if state.LeftAt != nil {
+		return nil, nil
+	}
+
+	questions, err := s.stateMgr.GetMatchQuestions(ctx, matchID)
+	if err != nil || len(questions) == 0 {
+		return nil, fmt.Errorf("questions not found")
+	}
+
+	match := Match{
+		ID:                   matchID,
+		Mode:                 meta.Mode,
+		QuestionCount:        int(meta.QuestionCount),
+		PerQuestionSeconds:   int(meta.PerQuestionSeconds),
+		GlobalTimeoutSeconds: int(meta.GlobalTimeoutSeconds),
+		SeedHash:             meta.SeedHash,
+		LeaderboardEligible:  meta.LeaderboardEligible,
+		Status:               meta.Status,
+	}
+	startedAt := meta.StartedAt.Time
+	order, remaining := questionClock(match, startedAt, time.Now())
+
+	answered := make(map[int]bool, len(state.Answers))
+	for _, ans := range state.Answers {
+		answered[ans.QuestionOrder] = true
+	}
+	pending := make([]QuestionPackItem, 0, len(questions))
+	for _, q := range questions {
+		// Only the open question: later ones have not been issued yet
+		if answered[q.Order] || order == 0 || q.Order != order {
+			continue
+		}
+		pending = append(pending, q)
+	}
+
+	return &ResumeSnapshot{
+		Match:            match,
+		StartedAt:        startedAt,
+		QuestionOrder:    order,
+		RemainingSeconds: remaining,
+		Questions:        s.QuestionsForPlayer(&match, userID, pending, startedAt),
+		Answers:          state.Answers,
+	}, nil
+}
+
+// ActiveMatch returns the match the user is currently playing (uuid.Nil if none).
+func (s *Service) ActiveMatch(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	return s.stateMgr.GetActiveMatch(ctx, userID)
+}
+
+// MarkLeftEarly flags an active player as having left the match for the given reason
+// (LeaveReasonQuit or LeaveReasonDisconnected). It returns the updated state, or nil
+// if the player had already left or the match is not active.
+func (s *Service) MarkLeftEarly(ctx context.Context, matchID uuid.UUID, userID uuid.UUID, reason string) (*PlayerState, error) {
 	unlock, err := s.stateMgr.LockMatch(ctx, matchID)
 	if err != nil {
 		return nil, fmt.Errorf("acquire lock: %w", err)
 	}
 	defer unlock()
 
-	var leaderboardEligible bool
-	var isPrivateRoom bool
-	var roomCode string
-	if s.leaderboard != nil {
-		if meta, err := s.matchRepo.GetSummary(ctx, matchID); err != nil {
-			s.logger.Warn().Err(err).Str("match_id", matchID.String()).Msg("failed to load match summary for leaderboard")
-		} else {
-			leaderboardEligible = meta.LeaderboardEligible
-			isPrivateRoom = meta.Mode == ModePrivateRoom
-			
-			// Extract room code from metadata if private room
-			if isPrivateRoom && len(meta.Metadata) > 0 {
-				var metadata map[string]interface{}
-				if err := json.Unmarshal(meta.Metadata, &metadata); err == nil {
-					if rc, ok := metadata["room_code"].(string); ok {
-						roomCode = rc
-					}
+	state, err := s.stateMgr.GetPlayerState(ctx, matchID, userID)
+	if err != nil || state == nil {
+		return nil, fmt.Errorf("player state not found")
+	}
+	if state.LeftAt != nil || (state.Status != PlayerStatusActive && state.Status != PlayerStatusQueued) {
+		return nil, nil
+	}
+
+	now := time.Now()
+	state.LeftAt = &now
+	state.LeaveReason = reason
+	state.Status = PlayerStatusLeftEarly
+	if err := s.stateMgr.StorePlayerState(ctx, matchID, userID, *state); err != nil {
+		return nil, fmt.Errorf("store state: %w", err)
+	}
+	if err := s.stateMgr.ClearActiveMatch(ctx, userID, matchID); err != nil {
+		s.logger.Warn().Err(err).Str("user_id", userID.String()).Msg("failed to clear active match")
+	}
+
+	s.logger.Info().
+		Str("match_id", matchID.String()).
+		Str("user_id", userID.String()).
+		Str("reason", reason).
+		Msg("player left match early")
+
+	return state, nil
+}
+
+// recordMatchQuestions persists the issued questions so tokens can still be resolved
+// (for reports and re-scoring) after the Redis state expires.
+func (s *Service) recordMatchQuestions(ctx context.Context, matchID pgtype.UUID, items []QuestionPackItem) {
+	for _, item := range items {
+		questionID, err := uuid.Parse(item.ID)
+		if err != nil {
+			continue
+		}
+		err = s.matchRepo.RecordQuestion(ctx, sqlcgen.InsertMatchQuestionParams{
+			MatchID:       matchID,
+			QuestionOrder: int16(item.Order),
+			QuestionID:    pgtype.UUID{Bytes: questionID, Valid: true},
+		})
+		if err != nil {
+			s.logger.Warn().Err(err).Str("question_id", item.ID).Msg("failed to record match question")
+		}
+	}
+}
+
+// ReportQuestion files a player's report against a question they were shown in a match.
+// The token must have been issued to the player for that match; it may have expired.
+// Its question is resolved from the live match state, or from the stored match
+// questions once the match is over.
+func (s *Service) ReportQuestion(ctx context.Context, userID uuid.UUID, matchID uuid.UUID, questionToken string, reason string, details string) (*moderation.Report, error) {
+	if s.reports == nil {
+		return nil, fmt.Errorf("question reports are not enabled")
+	}
+
+	questionID, err := s.resolveQuestionToken(ctx, userID, matchID, questionToken)
+	if err != nil {
+		return nil, err
+	}
+	return s.reports.ReportQuestion(ctx, userID, matchID, questionID, reason, details)
+}
+
+func (s *Service) resolveQuestionToken(ctx context.Context, userID uuid.UUID, matchID uuid.UUID, questionToken string) (uuid.UUID, error) {
+	token, err := s.verifyQuestionToken(questionToken, matchID, userID)
+	if err != nil {
+		return uuid.Nil, ErrQuestionNotInMatch
+	}
+
+	if state, err := s.stateMgr.GetPlayerState(ctx, matchID, userID); err == nil && state != nil {
+		if questions, err := s.stateMgr.GetMatchQuestions(ctx, matchID); err == nil {
+			for _, q := range questions {
+				if q.Order != token.Order {
+					continue
 				}
+				questionID, err := uuid.Parse(q.ID)
+				if err != nil {
+					return uuid.Nil, ErrQuestionNotInMatch
+				}
+				return questionID, nil
 			}
 		}
 	}
 
+	row, err := s.matchRepo.FindQuestionForPlayer(ctx, sqlcgen.GetMatchQuestionForPlayerParams{
+		UserID:        pgtype.UUID{Bytes: userID, Valid: true},
+		MatchID:       pgtype.UUID{Bytes: matchID, Valid: true},
+		QuestionOrder: int16(token.Order),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, ErrQuestionNotInMatch
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("resolve question token: %w", err)
+	}
+	return uuid.UUID(row.QuestionID.Bytes), nil
+}
+
+// FinalizeMatch computes final scores and updates DB. reason is why the match
+// ended (EndReasonFinished or EndReasonTimeout); a timed-out match is stored as
+// StatusTimeout. Returns match complete payload for WebSocket broadcast.
+func (s *Service) FinalizeMatch(ctx context.Context, matchID uuid.UUID, reason string) (*ws.MatchCompletePayload, error) {
+	unlock, err := s.stateMgr.LockMatch(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("acquire lock: %w", err)
+	}
+	defer unlock()
+
+	meta, err := s.matchRepo.GetSummary(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("load match summary: %w", err)
+	}
+	if meta.Status == StatusCompleted || meta.Status == StatusTimeout {
+		return nil, ErrMatchFinalized
+	}
+
+	// Bot matches never count toward any leaderboard
+	countsForLeaderboard := meta.LeaderboardEligible && meta.Mode != ModeBotFill
+	leaderboardEligible := s.leaderboard != nil && countsForLeaderboard
+	matchMode := meta.Mode
+	isPrivateRoom := matchMode == ModePrivateRoom
+	var roomCode string
+	if isPrivateRoom {
+		roomCode = metadataRoomCode(meta.Metadata)
+	}
+	perQuestionTimeout := time.Duration(meta.PerQuestionSeconds) * time.Second
+	rule := s.scoringRule(matchID, meta.Metadata)
+
 	// Get all player states
 	states, err := s.stateMgr.GetAllPlayerStates(ctx, matchID)
 	if err != nil {
@@ -532,17 +1061,13 @@ func (s *Service) FinalizeMatch(ctx context.Context, matchID uuid.UUID) (*ws.Mat
 		return nil, fmt.Errorf("get questions: %w", err)
 	}
 
-	// Get per-question timeout from match config
-	perQuestionTimeout := 15 * time.Second // default fallback
-	if meta, err := s.matchRepo.GetSummary(ctx, matchID); err == nil {
-		perQuestionTimeout = time.Duration(meta.PerQuestionSeconds) * time.Second
-	}
 	totalQuestions := len(questions)
 
 	var leaderboardReqs []leaderboard.RecordRequest
 
-	// Finalize each player
-	for _, state := range states {
+	// Finalize each player (by index so the payload below sees the final scores)
+	for i := range states {
+		state := &states[i]
 		// Mark unanswered questions as incorrect
 		answeredOrders := make(map[int]bool)
 		for _, ans := range state.Answers {
@@ -554,7 +1079,6 @@ func (s *Service) FinalizeMatch(ctx context.Context, matchID uuid.UUID) (*ws.Mat
 			if !answeredOrders[q.Order] {
 				state.Answers = append(state.Answers, AnswerRecord{
 					QuestionOrder: q.Order,
-					QuestionToken: q.Token,
 					Answer:        "",
 					SubmittedAt:   time.Now(),
 					IsCorrect:     false,
@@ -563,21 +1087,10 @@ func (s *Service) FinalizeMatch(ctx context.Context, matchID uuid.UUID) (*ws.Mat
 			}
 		}
 
-		// Convert answers to scoring format
-		scoringAnswers := make([]scoring.AnswerRecord, len(state.Answers))
-		for idx, ans := range state.Answers {
-			scoringAnswers[idx] = scoring.AnswerRecord{
-				QuestionOrder: ans.QuestionOrder,
-				QuestionToken: ans.QuestionToken,
-				Answer:        ans.Answer,
-				SubmittedAt:   ans.SubmittedAt,
-				IsCorrect:     ans.IsCorrect,
-				ScoreEarned:   ans.ScoreEarned,
-			}
-		}
+		scoringAnswers := toScoringAnswers(state.Answers)
 
 		// Compute final score
-		totalScore, accuracy, streakBonus := s.scoringEngine.ComputeFinalScore(scoringAnswers, perQuestionTimeout)
+		totalScore, accuracy, streakBonus := scoring.FinalScore(rule, scoringAnswers, perQuestionTimeout)
 		correctCount := 0
 		for _, ans := range state.Answers {
 			if ans.IsCorrect {
@@ -596,18 +1109,17 @@ func (s *Service) FinalizeMatch(ctx context.Context, matchID uuid.UUID) (*ws.Mat
 		}
 
 		// Update DB
-		pgMatchID := pgtype.UUID{}
-		pgMatchID.Scan(matchID)
-		pgUserID := pgtype.UUID{}
-		pgUserID.Scan(state.UserID)
+		pgMatchID := pgtype.UUID{Bytes: matchID, Valid: true}
+		pgUserID := pgtype.UUID{Bytes: state.UserID, Valid: true}
 
 		// Convert to pgtype
 		pgFinalScore := pgtype.Int4{}
 		pgFinalScore.Scan(totalScore)
+		// Numeric only scans from strings, not float64
 		pgAccuracy := pgtype.Numeric{}
-		pgAccuracy.Scan(accuracy)
+		pgAccuracy.Scan(strconv.FormatFloat(accuracy, 'f', 2, 64))
 		pgStreakBonus := pgtype.Numeric{}
-		pgStreakBonus.Scan(streakBonus)
+		pgStreakBonus.Scan(strconv.FormatFloat(streakBonus, 'f', 2, 64))
 
 		// Serialize answers
 		answersJSON, _ := json.Marshal(state.Answers)
@@ -621,21 +1133,33 @@ func (s *Service) FinalizeMatch(ctx context.Context, matchID uuid.UUID) (*ws.Mat
 			Status:         state.Status,
 			Answers:        answersJSON,
 		}
+		if state.LeftAt != nil {
+			updateParams.LeftAt = pgtype.Timestamptz{Time: *state.LeftAt, Valid: true}
+			updateParams.LeaveReason = pgtype.Text{String: state.LeaveReason, Valid: state.LeaveReason != ""}
+		}
 
 		if err := s.matchRepo.FinalizePlayerState(ctx, updateParams); err != nil {
 			s.logger.Warn().Err(err).Str("user_id", state.UserID.String()).Msg("failed to finalize player state")
 		}
 
 		// Update Redis state
-		if err := s.stateMgr.StorePlayerState(ctx, matchID, state.UserID, state); err != nil {
+		if err := s.stateMgr.StorePlayerState(ctx, matchID, state.UserID, *state); err != nil {
 			s.logger.Warn().Err(err).Msg("failed to update final state")
 		}
+		if err := s.stateMgr.ClearActiveMatch(ctx, state.UserID, matchID); err != nil {
+			s.logger.Warn().Err(err).Str("user_id", state.UserID.String()).Msg("failed to clear active match")
+		}
 
-		if leaderboardEligible && s.leaderboard != nil && !state.IsGuest {
+		if leaderboardEligible && s.leaderboard != nil && !state.IsGuest && !state.IsBot {
+			// A forfeit earns nothing on the leaderboard, whatever was scored before leaving
+			leaderboardScore := totalScore
+			if state.LeftAt != nil {
+				leaderboardScore = 0
+			}
 			leaderboardReqs = append(leaderboardReqs, leaderboard.RecordRequest{
 				UserID:        state.UserID,
 				Username:      state.Username,
-				Score:         totalScore,
+				Score:         leaderboardScore,
 				CorrectCount:  correctCount,
 				QuestionCount: totalQuestions,
 				MatchID:       matchID,
@@ -645,25 +1169,28 @@ func (s *Service) FinalizeMatch(ctx context.Context, matchID uuid.UUID) (*ws.Mat
 	}
 
 	// Update match status
-	pgMatchID := pgtype.UUID{}
-	pgMatchID.Scan(matchID)
+	status := StatusCompleted
+	if reason == EndReasonTimeout {
+		status = StatusTimeout
+	}
+	pgMatchID := pgtype.UUID{Bytes: matchID, Valid: true}
 	updateParams := sqlcgen.UpdateMatchStatusParams{
-		MatchID: pgMatchID,
-		Status:  StatusCompleted,
+		MatchID:     pgMatchID,
+		Status:      status,
+		CompletedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
 	}
 	if err := s.matchRepo.UpdateStatus(ctx, updateParams); err != nil {
 		return nil, fmt.Errorf("update match status: %w", err)
 	}
 
+	winners := decideWinners(states)
+	s.inspectForCheats(matchID, matchMode, countsForLeaderboard, states)
+
+	// Board the results went to: a room code, "" for the main board, nil for none
+	var recordedBoard *string
 	if leaderboardEligible && s.leaderboard != nil && len(leaderboardReqs) > 0 {
-		highest := leaderboardReqs[0].Score
-		for _, req := range leaderboardReqs[1:] {
-			if req.Score > highest {
-				highest = req.Score
-			}
-		}
 		for i := range leaderboardReqs {
-			leaderboardReqs[i].Won = leaderboardReqs[i].Score == highest
+			leaderboardReqs[i].Won = winners[leaderboardReqs[i].UserID]
 			
 			// Route to appropriate leaderboard based on match mode
 			if isPrivateRoom && roomCode != "" {
@@ -674,17 +1201,26 @@ func (s *Service) FinalizeMatch(ctx context.Context, matchID uuid.UUID) (*ws.Mat
 						Str("room_code", roomCode).
 						Msg("failed to record private room leaderboard result")
 				}
-			} else if meta, err := s.matchRepo.GetSummary(ctx, matchID); err == nil && meta.Mode == ModeRandom1v1 {
+				recordedBoard = &roomCode
+			} else if matchMode == ModeRandom1v1 {
 				// Main leaderboard (only for random 1v1)
 				if err := s.leaderboard.RecordResult(ctx, leaderboardReqs[i]); err != nil {
 					s.logger.Warn().Err(err).
 						Str("user_id", leaderboardReqs[i].UserID.String()).
 						Msg("failed to record leaderboard result")
 				}
+				mainBoard := ""
+				recordedBoard = &mainBoard
 			}
 		}
 	}
 
+	// Rate the players of matchmade games
+	var ratingChanges map[uuid.UUID]*ws.RatingChange
+	if s.ratings != nil && matchMode == ModeRandom1v1 && countsForLeaderboard {
+		ratingChanges = s.rateMatch(ctx, matchID, states, winners, leaderboardReqs)
+	}
+
 	// Build match complete payload
 	results := make([]ws.MatchResult, len(states))
 	for i, state := range states {
@@ -708,22 +1244,69 @@ func (s *Service) FinalizeMatch(ctx context.Context, matchID uuid.UUID) (*ws.Mat
 			Accuracy:           accuracy,
 			StreakBonusApplied: streakBonus,
 			Status:             state.Status,
+			LeaveReason:        state.LeaveReason,
+			Won:                winners[state.UserID],
+			Rating:             ratingChanges[state.UserID],
+		}
+		if recordedBoard != nil {
+			results[i].LeaderboardPosition = s.leaderboardPosition(ctx, *recordedBoard, state.UserID)
 		}
 	}
 
-	// Get leaderboard position if eligible (simplified - would need actual lookup)
-	leaderboardPosition := 0
-
 	payload := &ws.MatchCompletePayload{
 		MatchID:             matchID.String(),
 		Results:             results,
 		LeaderboardEligible: leaderboardEligible,
-		LeaderboardPosition: leaderboardPosition,
 	}
 
 	return payload, nil
 }
 
+// leaderboardPosition returns a player's rank on the board their result was
+// recorded to: the room's board when roomCode is set, otherwise the all-time board.
+// Returns 0 when the player is not ranked or the lookup fails.
+func (s *Service) leaderboardPosition(ctx context.Context, roomCode string, userID uuid.UUID) int {
+	var (
+		pos *leaderboard.Position
+		err error
+	)
+	if roomCode != "" {
+		pos, err = s.leaderboard.PrivateRoomRankOf(ctx, roomCode, userID, 0)
+	} else {
+		pos, err = s.leaderboard.RankOf(ctx, userID, leaderboard.WindowAllTime, 0)
+	}
+	if err != nil {
+		if !errors.Is(err, leaderboard.ErrNotRanked) {
+			s.logger.Warn().Err(err).
+				Str("user_id", userID.String()).
+				Str("room_code", roomCode).
+				Msg("failed to look up leaderboard position")
+		}
+		return 0
+	}
+	return pos.Rank
+}
+
+// decideWinners returns the players with the highest final score among those who
+// stayed to the end. Players who left early always lose, so the last player
+// standing wins a forfeited match regardless of score.
+func decideWinners(states []PlayerState) map[uuid.UUID]bool {
+	highest := -1
+	for _, state := range states {
+		if state.LeftAt == nil && state.FinalScore != nil && *state.FinalScore > highest {
+			highest = *state.FinalScore
+		}
+	}
+
+	winners := make(map[uuid.UUID]bool)
+	for _, state := range states {
+		if state.LeftAt == nil && state.FinalScore != nil && *state.FinalScore == highest {
+			winners[state.UserID] = true
+		}
+	}
+	return winners
+}
+
 // CreateRoom creates a private room via RoomManager.
 func (s *Service) CreateRoom(ctx context.Context, req PrivateRoomRequest) (string, *PrivateRoom, error) {
 	return s.roomMgr.CreateRoom(ctx, req)
@@ -731,5 +1314,5 @@ func (s *Service) CreateRoom(ctx context.Context, req PrivateRoomRequest) (strin
 
 // GetRoom retrieves a private room by code.
 func (s *Service) GetRoom(ctx context.Context, roomCode string) (*PrivateRoom, error) {
-	return s.roomMgr.GetRoom(roomCode)
+	return s.roomMgr.GetRoom(ctx, roomCode)
 }
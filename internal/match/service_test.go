@@ -0,0 +1,185 @@
+package match
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
+	"github.com/gokatarajesh/quiz-platform/internal/leaderboard"
+	"github.com/gokatarajesh/quiz-platform/internal/match/queue"
+	"github.com/gokatarajesh/quiz-platform/internal/match/scoring"
+	"github.com/gokatarajesh/quiz-platform/internal/question"
+	"github.com/gokatarajesh/quiz-platform/internal/rating"
+)
+
+func TestBuildProgress(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	first, second := uuid.New(), uuid.New()
+	states := []PlayerState{
+		{UserID: second, JoinedAt: start.Add(time.Second), Status: PlayerStatusActive},
+		{
+			UserID:   first,
+			JoinedAt: start,
+			Status:   PlayerStatusActive,
+			Answers:  []AnswerRecord{{QuestionOrder: 1, IsCorrect: true}, {QuestionOrder: 2}},
+		},
+	}
+
+	progress := buildProgress(uuid.Nil, states, 5)
+	if assert.Len(t, progress.Players, 2) {
+		assert.Equal(t, first.String(), progress.Players[0].UserID)
+		assert.Equal(t, 2, progress.Players[0].Answered)
+		assert.Equal(t, 3, progress.Players[0].Pending)
+		assert.Equal(t, 0, progress.Players[1].Answered)
+		assert.Equal(t, 5, progress.Players[1].Pending)
+	}
+}
+
+func TestDecideWinnersIgnoresLeavers(t *testing.T) {
+	now := time.Now()
+	leaver, stayer := uuid.New(), uuid.New()
+	high, low := 900, 100
+	states := []PlayerState{
+		{UserID: leaver, FinalScore: &high, LeftAt: &now},
+		{UserID: stayer, FinalScore: &low},
+	}
+
+	winners := decideWinners(states)
+	assert.True(t, winners[stayer])
+	assert.False(t, winners[leaver])
+
+	states[1].LeftAt = &now
+	assert.Empty(t, decideWinners(states))
+}
+
+func TestRatingOutcome(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	assert.Equal(t, rating.Win, outcome(map[uuid.UUID]bool{a: true}, a, b))
+	assert.Equal(t, rating.Loss, outcome(map[uuid.UUID]bool{b: true}, a, b))
+	assert.Equal(t, rating.Draw, outcome(map[uuid.UUID]bool{a: true, b: true}, a, b))
+	// Both left early
+	assert.Equal(t, rating.Draw, outcome(map[uuid.UUID]bool{}, a, b))
+}
+
+func TestAnswerLatencyEnforcesWindow(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	per := 15 * time.Second
+	schedule := questionSchedule([]QuestionPackItem{{Order: 1}, {Order: 2}, {Order: 3}}, start, per)
+	assert.Equal(t, start.Add(30*time.Second), schedule[3])
+
+	latency, err := answerLatency(schedule[2], schedule[2].Add(4*time.Second), per)
+	assert.NoError(t, err)
+	assert.Equal(t, 4*time.Second, latency)
+
+	_, err = answerLatency(schedule[3], schedule[2].Add(4*time.Second), per)
+	assert.ErrorIs(t, err, ErrQuestionNotActive)
+
+	// Within the grace period the answer counts but earns no time bonus
+	latency, err = answerLatency(schedule[1], schedule[1].Add(per+answerGrace/2), per)
+	assert.NoError(t, err)
+	assert.Equal(t, per, latency)
+
+	_, err = answerLatency(schedule[1], schedule[1].Add(per+answerGrace+time.Millisecond), per)
+	assert.ErrorIs(t, err, ErrAnswerTooLate)
+}
+
+func TestAnswerScoresMatchFinalScore(t *testing.T) {
+	engine := scoring.NewEngine(scoring.DefaultScoringConfig())
+	per := 15 * time.Second
+	answers := []AnswerRecord{
+		{QuestionOrder: 1, IsCorrect: true, LatencyMs: 2000},
+		{QuestionOrder: 2, IsCorrect: true, LatencyMs: 9000},
+		{QuestionOrder: 3, IsCorrect: false, LatencyMs: 1000},
+		{QuestionOrder: 4, IsCorrect: true, LatencyMs: 14500},
+	}
+
+	// Score each answer as SubmitAnswer does, with only the earlier answers recorded
+	sum := 0
+	for i := range answers {
+		sum += engine.AnswerScore(toScoringAnswers(answers[:i+1]), i, per)
+	}
+
+	total, _, _ := engine.ComputeFinalScore(toScoringAnswers(answers), per)
+	assert.Equal(t, sum, total)
+	assert.Equal(t, 100+43, engine.AnswerScore(toScoringAnswers(answers), 0, per))
+}
+
+func TestRecordedResultsRebuildLeaderboardEntries(t *testing.T) {
+	matchID, winner, leaver, guest := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	state := func(userID uuid.UUID, score int32, correct int) sqlcgen.PlayerMatchState {
+		answers := make([]AnswerRecord, 5)
+		for i := range answers {
+			answers[i] = AnswerRecord{QuestionOrder: i + 1, IsCorrect: i < correct}
+		}
+		data, _ := json.Marshal(answers)
+		return sqlcgen.PlayerMatchState{
+			UserID:     pgtype.UUID{Bytes: userID, Valid: true},
+			FinalScore: pgtype.Int4{Int32: score, Valid: true},
+			Answers:    data,
+		}
+	}
+	rows := []sqlcgen.PlayerMatchState{state(winner, 300, 3), state(leaver, 450, 4), state(guest, 100, 1)}
+	rows[1].LeftAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	rows[2].IsGuest = true
+
+	reqs := recordedResults(matchID, rows, 5)
+	assert.Equal(t, []leaderboard.RecordRequest{
+		{UserID: winner, Score: 300, CorrectCount: 3, QuestionCount: 5, Won: true, MatchID: matchID, Eligible: true},
+		{UserID: leaver, Score: 0, CorrectCount: 4, QuestionCount: 5, MatchID: matchID, Eligible: true},
+	}, reqs)
+}
+
+func TestPairPackUsesAgreedDifficulty(t *testing.T) {
+	pair := &queue.MatchPair{Reason: queue.ReasonSkillMatch, RatingGap: 40, QuestionCount: 10, Difficulty: question.DifficultyHard}
+
+	assert.Equal(t, map[string]int{question.DifficultyHard: 10}, difficultyDistribution(10, pair.Difficulty))
+	assert.JSONEq(t, `{"pairing_reason":"skill_match","rating_gap":40,"question_count":10,"difficulty":"hard"}`, string(pairMetadata(pair, 10)))
+
+	// No agreed difficulty keeps the fixed mix
+	assert.Equal(t, getFixedDifficultyDistribution(5), difficultyDistribution(5, ""))
+	assert.Equal(t, getFixedDifficultyDistribution(5), difficultyDistribution(5, "impossible"))
+
+	// Bot matches carry no pairing metadata
+	assert.Nil(t, pairMetadata(&queue.MatchPair{}, 10))
+}
+
+func TestOpenQuestionIssuesOnlyThatQuestion(t *testing.T) {
+	ctx := context.Background()
+	stateMgr := newTestStateManager(t)
+	svc := &Service{stateMgr: stateMgr, hmacKey: []byte("test-secret"), logger: zerolog.Nop()}
+	match := &Match{ID: uuid.New(), QuestionCount: 2, PerQuestionSeconds: 10, SeedHash: "seed"}
+	questions := []QuestionPackItem{
+		{Order: 1, ID: "q1", Prompt: "First?", Options: []string{"a", "b"}, CorrectAnswer: "a"},
+		{Order: 2, ID: "q2", Prompt: "Second?", Options: []string{"c", "d"}, CorrectAnswer: "d"},
+	}
+	require.NoError(t, stateMgr.StoreMatchQuestions(ctx, match.ID, questions))
+
+	startedAt := time.Now().Add(-10 * time.Second)
+	player, bot, left := uuid.New(), uuid.New(), uuid.New()
+	leftAt := startedAt
+	require.NoError(t, stateMgr.StorePlayerState(ctx, match.ID, player, PlayerState{UserID: player}))
+	require.NoError(t, stateMgr.StorePlayerState(ctx, match.ID, bot, PlayerState{UserID: bot, IsBot: true}))
+	require.NoError(t, stateMgr.StorePlayerState(ctx, match.ID, left, PlayerState{UserID: left, LeftAt: &leftAt}))
+
+	openedAt := time.Now()
+	batches, err := svc.OpenQuestion(ctx, match, startedAt, 2, openedAt)
+	require.NoError(t, err)
+	require.Len(t, batches, 1, "bots and players who left are not sent questions")
+	require.Len(t, batches[player], 1)
+	assert.Equal(t, "q2", batches[player][0].ID)
+	assert.NotEmpty(t, batches[player][0].Token)
+
+	// Answers to it are timed from its delivery
+	state, err := stateMgr.GetPlayerState(ctx, match.ID, player)
+	require.NoError(t, err)
+	assert.WithinDuration(t, openedAt, state.QuestionIssuedAt[2], time.Millisecond)
+	assert.NotContains(t, state.QuestionIssuedAt, 1)
+}
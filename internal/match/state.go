@@ -112,7 +112,8 @@ func (s *StateManager) GetAllPlayerStates(ctx context.Context, matchID uuid.UUID
 	return states, nil
 }
 
-// StoreMatchQuestions caches the question pack for a match (HMAC-signed tokens included).
+// StoreMatchQuestions caches the question pack for a match. Tokens are not stored;
+// they are signed per player when the batch is issued.
 func (s *StateManager) StoreMatchQuestions(ctx context.Context, matchID uuid.UUID, questions []QuestionPackItem) error {
 	key := fmt.Sprintf("match:questions:%s", matchID.String())
 	data, err := json.Marshal(questions)
@@ -142,13 +143,52 @@ func (s *StateManager) GetMatchQuestions(ctx context.Context, matchID uuid.UUID)
 	return questions, nil
 }
 
+// SetActiveMatch indexes the match a user is currently playing, for reconnects.
+func (s *StateManager) SetActiveMatch(ctx context.Context, userID uuid.UUID, matchID uuid.UUID) error {
+	key := fmt.Sprintf("match:user:%s", userID.String())
+	ttl := 2 * time.Hour
+	return s.redis.Set(ctx, key, matchID.String(), ttl).Err()
+}
+
+// GetActiveMatch returns the match a user is currently playing (uuid.Nil if none).
+func (s *StateManager) GetActiveMatch(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	key := fmt.Sprintf("match:user:%s", userID.String())
+	value, err := s.redis.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return uuid.Nil, nil
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("get active match: %w", err)
+	}
+
+	matchID, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("parse active match: %w", err)
+	}
+	return matchID, nil
+}
+
+// ClearActiveMatch removes the user's active match index if it still points at matchID.
+func (s *StateManager) ClearActiveMatch(ctx context.Context, userID uuid.UUID, matchID uuid.UUID) error {
+	key := fmt.Sprintf("match:user:%s", userID.String())
+	// Lua script keeps a newer match's index intact
+	script := `
+		if redis.call("get", KEYS[1]) == ARGV[1] then
+			return redis.call("del", KEYS[1])
+		else
+			return 0
+		end
+	`
+	return s.redis.Eval(ctx, script, []string{key}, matchID.String()).Err()
+}
+
 // QuestionPackItem represents a question with its signed token.
 type QuestionPackItem struct {
 	Order         int      `json:"order"`
 	ID            string   `json:"id"`
 	Prompt        string   `json:"prompt"`
 	Options       []string `json:"options"`
-	Token         string   `json:"token"`          // HMAC-signed
+	Token         string   `json:"token"`          // per-player HMAC token, set when issued
 	CorrectAnswer string   `json:"correct_answer"` // server-side only
 	// Type, Difficulty, Category removed - not needed
 }
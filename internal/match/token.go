@@ -0,0 +1,89 @@
+package match
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidQuestionToken is returned for a token that is malformed or not signed
+// for this match and player.
+var ErrInvalidQuestionToken = errors.New("invalid question token")
+
+// ErrQuestionTokenExpired is returned for a token used after its question's deadline.
+var ErrQuestionTokenExpired = errors.New("question token expired")
+
+// questionToken is what a verified token carries. The match and player it was
+// issued to are part of the signature rather than the token text.
+type questionToken struct {
+	Order     int
+	ExpiresAt time.Time
+}
+
+// signQuestionToken issues the token a player uses to answer one question of a match.
+// Format: "<order>.<expires_unix_ms>.<hex hmac>", where the HMAC also covers the
+// match and user IDs so a token is useless outside the match and player it was issued to.
+func (s *Service) signQuestionToken(matchID, userID uuid.UUID, order int, expiresAt time.Time) string {
+	payload := fmt.Sprintf("%d.%d", order, expiresAt.UnixMilli())
+	return payload + "." + hex.EncodeToString(s.questionTokenMAC(matchID, userID, payload))
+}
+
+// verifyQuestionToken checks the token's signature before anything else is read
+// from it. Expiry is left to the caller, since reports may reference a question
+// after its deadline.
+func (s *Service) verifyQuestionToken(token string, matchID, userID uuid.UUID) (questionToken, error) {
+	idx := strings.LastIndexByte(token, '.')
+	if idx < 0 {
+		return questionToken{}, ErrInvalidQuestionToken
+	}
+	payload, sig := token[:idx], token[idx+1:]
+
+	mac, err := hex.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, s.questionTokenMAC(matchID, userID, payload)) {
+		return questionToken{}, ErrInvalidQuestionToken
+	}
+
+	orderPart, expiryPart, ok := strings.Cut(payload, ".")
+	if !ok {
+		return questionToken{}, ErrInvalidQuestionToken
+	}
+	order, err := strconv.Atoi(orderPart)
+	if err != nil || order <= 0 {
+		return questionToken{}, ErrInvalidQuestionToken
+	}
+	expiry, err := strconv.ParseInt(expiryPart, 10, 64)
+	if err != nil {
+		return questionToken{}, ErrInvalidQuestionToken
+	}
+
+	return questionToken{Order: order, ExpiresAt: time.UnixMilli(expiry)}, nil
+}
+
+func (s *Service) questionTokenMAC(matchID, userID uuid.UUID, payload string) []byte {
+	mac := hmac.New(sha256.New, s.hmacKey)
+	mac.Write([]byte(matchID.String() + ":" + userID.String() + ":" + payload))
+	return mac.Sum(nil)
+}
+
+// QuestionsForPlayer returns copies of questions carrying the player's own tokens
+// and options in the player's shuffled order. Each token expires at its question's
+// deadline (plus answerGrace) on the clock started at startedAt, so reissuing on
+// reconnect yields the same batch.
+func (s *Service) QuestionsForPlayer(match *Match, userID uuid.UUID, questions []QuestionPackItem, startedAt time.Time) []QuestionPackItem {
+	per := time.Duration(match.PerQuestionSeconds) * time.Second
+	out := make([]QuestionPackItem, len(questions))
+	for i, q := range questions {
+		expiresAt := startedAt.Add(time.Duration(q.Order)*per + answerGrace)
+		q.Token = s.signQuestionToken(match.ID, userID, q.Order, expiresAt)
+		q.Options = shuffleOptions(s.hmacKey, q.Options, match.SeedHash, q.Order, userID, s.playerShuffle)
+		out[i] = q
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package match
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuestionTokenBindsMatchAndPlayer(t *testing.T) {
+	svc := &Service{hmacKey: []byte("test-secret")}
+	matchID, userID := uuid.New(), uuid.New()
+	expiresAt := time.Date(2026, 1, 1, 12, 0, 31, 0, time.UTC)
+
+	token := svc.signQuestionToken(matchID, userID, 2, expiresAt)
+	claims, err := svc.verifyQuestionToken(token, matchID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, claims.Order)
+	assert.True(t, expiresAt.Equal(claims.ExpiresAt))
+
+	_, err = svc.verifyQuestionToken(token, uuid.New(), userID)
+	assert.ErrorIs(t, err, ErrInvalidQuestionToken)
+	_, err = svc.verifyQuestionToken(token, matchID, uuid.New())
+	assert.ErrorIs(t, err, ErrInvalidQuestionToken)
+
+	// Changing the order or the expiry breaks the signature
+	_, err = svc.verifyQuestionToken("3"+token[1:], matchID, userID)
+	assert.ErrorIs(t, err, ErrInvalidQuestionToken)
+	_, err = svc.verifyQuestionToken("garbage", matchID, userID)
+	assert.ErrorIs(t, err, ErrInvalidQuestionToken)
+}
+
+func TestQuestionsForPlayerExpireAtDeadline(t *testing.T) {
+	svc := &Service{hmacKey: []byte("test-secret")}
+	match := &Match{ID: uuid.New(), PerQuestionSeconds: 15}
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	questions := []QuestionPackItem{{Order: 1, ID: "a"}, {Order: 2, ID: "b"}}
+	alice, bob := uuid.New(), uuid.New()
+
+	issued := svc.QuestionsForPlayer(match, alice, questions, start)
+	require.Len(t, issued, 2)
+	assert.Empty(t, questions[0].Token)
+	assert.NotEqual(t, issued[0].Token, svc.QuestionsForPlayer(match, bob, questions, start)[0].Token)
+	assert.Equal(t, issued, svc.QuestionsForPlayer(match, alice, questions, start))
+
+	claims, err := svc.verifyQuestionToken(issued[1].Token, match.ID, alice)
+	require.NoError(t, err)
+	assert.Equal(t, 2, claims.Order)
+	assert.True(t, start.Add(30*time.Second+answerGrace).Equal(claims.ExpiresAt))
+}
@@ -31,6 +31,12 @@ const (
 	PlayerStatusTimeout   = "timeout"
 )
 
+// Reasons a player left a match early.
+const (
+	LeaveReasonQuit         = "quit"         // sent leave_match
+	LeaveReasonDisconnected = "disconnected" // did not reconnect within the resume grace window
+)
+
 // Match represents a game session.
 type Match struct {
 	ID                   uuid.UUID
@@ -56,11 +62,15 @@ type PlayerState struct {
 	Username       string
 	JoinedAt       time.Time
 	LeftAt         *time.Time
+	LeaveReason    string
 	FinalScore     *int
 	Status         string
 	Accuracy       *float64
 	StreakBonusPct *float64
+	IsBot          bool
 	Answers        []AnswerRecord
+	// QuestionIssuedAt holds when each question (by order) became active for the player.
+	QuestionIssuedAt map[int]time.Time
 }
 
 // AnswerRecord stores per-question response with timing.
@@ -71,6 +81,17 @@ type AnswerRecord struct {
 	SubmittedAt   time.Time `json:"submitted_at"`
 	IsCorrect     bool      `json:"is_correct"`
 	ScoreEarned   int       `json:"score_earned"`
+	LatencyMs     int       `json:"latency_ms"` // time from the question becoming active to the answer
+}
+
+// ResumeSnapshot is what a reconnecting player needs to continue a match.
+type ResumeSnapshot struct {
+	Match            Match
+	StartedAt        time.Time
+	QuestionOrder    int // active question (0 once every window has elapsed)
+	RemainingSeconds int
+	Questions        []QuestionPackItem // unanswered questions that are still open
+	Answers          []AnswerRecord     // the player's own answers so far
 }
 
 // MatchmakingRequest for random 1v1 queue.
@@ -80,6 +101,7 @@ type MatchmakingRequest struct {
 	IsGuest             bool
 	PreferredCategory   string
 	PreferredDifficulty string
+	QuestionCount       int
 	BotOK               bool
 }
 
@@ -92,7 +114,9 @@ type PrivateRoomRequest struct {
 	MaxPlayers         int
 	QuestionCount      int
 	PerQuestionSeconds int
+	MinReadyPlayers    int
 	Category           string // e.g., "general", "science", "history" (default: "general")
+	ScoringRule        string // one of scoring.RuleNames() (default: "classic")
 }
 
 // CreateRoomRequest for HTTP request payload when creating a private room.
@@ -101,5 +125,7 @@ type CreateRoomRequest struct {
 	MaxPlayers         int    `json:"max_players"`
 	QuestionCount      int    `json:"question_count"`      // 5, 10, or 15
 	PerQuestionSeconds int   `json:"per_question_seconds"` // e.g., 15
+	MinReadyPlayers    int    `json:"min_ready_players,omitempty"` // host may start once this many are ready
 	Category           string `json:"category,omitempty"`   // default: "general"
+	ScoringRule        string `json:"scoring_rule,omitempty"` // default: "classic"
 }
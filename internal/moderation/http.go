@@ -0,0 +1,374 @@
+package moderation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/gokatarajesh/quiz-platform/internal/auth/jwt"
+	httperrors "github.com/gokatarajesh/quiz-platform/pkg/http/errors"
+)
+
+// maxBulkApprove caps the number of questions approved in one request.
+const maxBulkApprove = 200
+
+// HTTPHandlers provides the admin review endpoints. They expect the auth and
+// admin middleware to have run.
+type HTTPHandlers struct {
+	service *Service
+	logger  zerolog.Logger
+}
+
+// NewHTTPHandlers creates HTTP handlers for question moderation.
+func NewHTTPHandlers(service *Service, logger zerolog.Logger) *HTTPHandlers {
+	return &HTTPHandlers{
+		service: service,
+		logger:  logger.With().Str("component", "moderation_http").Logger(),
+	}
+}
+
+// Register mounts the moderation endpoints on mux.
+func (h *HTTPHandlers) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/v1/admin/questions", h.ListQueue)
+	mux.HandleFunc("/v1/admin/questions/approve", h.BulkApprove)
+	mux.HandleFunc("/v1/admin/questions/outliers", h.Outliers)
+	mux.HandleFunc("/v1/admin/questions/{id}", h.Edit)
+	mux.HandleFunc("/v1/admin/questions/{id}/approve", h.Approve)
+	mux.HandleFunc("/v1/admin/questions/{id}/reject", h.Reject)
+	mux.HandleFunc("/v1/admin/questions/{id}/audit", h.History)
+	mux.HandleFunc("/v1/admin/questions/{id}/reports", h.QuestionReports)
+	mux.HandleFunc("/v1/admin/questions/{id}/reports/resolve", h.ResolveReports)
+	mux.HandleFunc("/v1/admin/reports", h.ListReported)
+}
+
+// ListQueue handles GET /v1/admin/questions?status=&source=&category=&difficulty=&limit=&offset=
+func (h *HTTPHandlers) ListQueue(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httperrors.RespondError(w, http.StatusMethodNotAllowed, httperrors.ErrCodeInvalidRequest, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := ReviewFilter{
+		Status:     query.Get("status"),
+		Source:     query.Get("source"),
+		Category:   query.Get("category"),
+		Difficulty: query.Get("difficulty"),
+	}
+	switch filter.Status {
+	case "", StatusPending, StatusApproved, StatusRejected:
+	default:
+		httperrors.RespondValidationError(w, httperrors.ErrCodeValidationFailed, "status must be pending, approved or rejected", "status")
+		return
+	}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			httperrors.RespondValidationError(w, httperrors.ErrCodeValidationFailed, "limit must be a positive integer", "limit")
+			return
+		}
+		filter.Limit = limit
+	}
+	if v := query.Get("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			httperrors.RespondValidationError(w, httperrors.ErrCodeValidationFailed, "offset must be a non-negative integer", "offset")
+			return
+		}
+		filter.Offset = offset
+	}
+
+	questions, err := h.service.ListQueue(r.Context(), filter)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to list review queue")
+		httperrors.RespondError(w, http.StatusInternalServerError, httperrors.ErrCodeModerationFailed, "Failed to list questions")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"questions": questions,
+		"count":     len(questions),
+	})
+}
+
+// Approve handles POST /v1/admin/questions/{id}/approve
+func (h *HTTPHandlers) Approve(w http.ResponseWriter, r *http.Request) {
+	actorID, questionID, ok := h.target(w, r, http.MethodPost)
+	if !ok {
+		return
+	}
+
+	q, err := h.service.Approve(r.Context(), actorID, questionID)
+	if err != nil {
+		h.respondServiceError(w, err, questionID, "approve")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, q)
+}
+
+// Reject handles POST /v1/admin/questions/{id}/reject with an optional {"reason": "..."} body.
+func (h *HTTPHandlers) Reject(w http.ResponseWriter, r *http.Request) {
+	actorID, questionID, ok := h.target(w, r, http.MethodPost)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			httperrors.RespondBadRequest(w, httperrors.ErrCodeInvalidRequest, "Invalid JSON payload")
+			return
+		}
+	}
+
+	q, err := h.service.Reject(r.Context(), actorID, questionID, req.Reason)
+	if err != nil {
+		h.respondServiceError(w, err, questionID, "reject")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, q)
+}
+
+// Edit handles PATCH /v1/admin/questions/{id}
+func (h *HTTPHandlers) Edit(w http.ResponseWriter, r *http.Request) {
+	actorID, questionID, ok := h.target(w, r, http.MethodPatch)
+	if !ok {
+		return
+	}
+
+	var edit Edit
+	if err := json.NewDecoder(r.Body).Decode(&edit); err != nil {
+		httperrors.RespondBadRequest(w, httperrors.ErrCodeInvalidRequest, "Invalid JSON payload")
+		return
+	}
+
+	q, err := h.service.Edit(r.Context(), actorID, questionID, edit)
+	if err != nil {
+		h.respondServiceError(w, err, questionID, "edit")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, q)
+}
+
+// BulkApprove handles POST /v1/admin/questions/approve with {"question_ids": [...]}.
+func (h *HTTPHandlers) BulkApprove(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httperrors.RespondError(w, http.StatusMethodNotAllowed, httperrors.ErrCodeInvalidRequest, "Method not allowed")
+		return
+	}
+	actorID, ok := actor(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		QuestionIDs []uuid.UUID `json:"question_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperrors.RespondBadRequest(w, httperrors.ErrCodeInvalidRequest, "Invalid JSON payload")
+		return
+	}
+	if len(req.QuestionIDs) == 0 {
+		httperrors.RespondValidationError(w, httperrors.ErrCodeMissingField, "question_ids is required", "question_ids")
+		return
+	}
+	if len(req.QuestionIDs) > maxBulkApprove {
+		httperrors.RespondValidationError(w, httperrors.ErrCodeValidationFailed, "too many question_ids", "question_ids")
+		return
+	}
+
+	approved, failed := h.service.BulkApprove(r.Context(), actorID, req.QuestionIDs)
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"approved": approved,
+		"failed":   failed,
+	})
+}
+
+// History handles GET /v1/admin/questions/{id}/audit
+func (h *HTTPHandlers) History(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httperrors.RespondError(w, http.StatusMethodNotAllowed, httperrors.ErrCodeInvalidRequest, "Method not allowed")
+		return
+	}
+	questionID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		httperrors.RespondValidationError(w, httperrors.ErrCodeValidationFailed, "Invalid question ID", "id")
+		return
+	}
+
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, _ = strconv.Atoi(v)
+	}
+	entries, err := h.service.History(r.Context(), questionID, limit)
+	if err != nil {
+		h.respondServiceError(w, err, questionID, "history")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"question_id": questionID,
+		"entries":     entries,
+	})
+}
+
+// ListReported handles GET /v1/admin/reports?status=&limit=&offset=
+func (h *HTTPHandlers) ListReported(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httperrors.RespondError(w, http.StatusMethodNotAllowed, httperrors.ErrCodeInvalidRequest, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	status := query.Get("status")
+	switch status {
+	case "", ReportOpen, ReportUpheld, ReportDismissed:
+	default:
+		httperrors.RespondValidationError(w, httperrors.ErrCodeValidationFailed, "status must be open, upheld or dismissed", "status")
+		return
+	}
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	questions, err := h.service.ListReported(r.Context(), status, limit, offset)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to list reported questions")
+		httperrors.RespondError(w, http.StatusInternalServerError, httperrors.ErrCodeModerationFailed, "Failed to list reported questions")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"questions": questions,
+		"count":     len(questions),
+	})
+}
+
+// Outliers handles GET /v1/admin/questions/outliers?min_exposures=
+func (h *HTTPHandlers) Outliers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httperrors.RespondError(w, http.StatusMethodNotAllowed, httperrors.ErrCodeInvalidRequest, "Method not allowed")
+		return
+	}
+
+	var minExposures int
+	if v := r.URL.Query().Get("min_exposures"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			httperrors.RespondValidationError(w, httperrors.ErrCodeValidationFailed, "min_exposures must be a positive integer", "min_exposures")
+			return
+		}
+		minExposures = n
+	}
+
+	outliers, err := h.service.Outliers(r.Context(), minExposures)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to list question outliers")
+		httperrors.RespondError(w, http.StatusInternalServerError, httperrors.ErrCodeModerationFailed, "Failed to list question outliers")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"questions": outliers,
+		"count":     len(outliers),
+	})
+}
+
+// QuestionReports handles GET /v1/admin/questions/{id}/reports
+func (h *HTTPHandlers) QuestionReports(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httperrors.RespondError(w, http.StatusMethodNotAllowed, httperrors.ErrCodeInvalidRequest, "Method not allowed")
+		return
+	}
+	questionID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		httperrors.RespondValidationError(w, httperrors.ErrCodeValidationFailed, "Invalid question ID", "id")
+		return
+	}
+
+	reports, err := h.service.Reports(r.Context(), questionID)
+	if err != nil {
+		h.respondServiceError(w, err, questionID, "reports")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"question_id": questionID,
+		"reports":     reports,
+	})
+}
+
+// ResolveReports handles POST /v1/admin/questions/{id}/reports/resolve
+func (h *HTTPHandlers) ResolveReports(w http.ResponseWriter, r *http.Request) {
+	actorID, questionID, ok := h.target(w, r, http.MethodPost)
+	if !ok {
+		return
+	}
+
+	var res Resolution
+	if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+		httperrors.RespondBadRequest(w, httperrors.ErrCodeInvalidRequest, "Invalid JSON payload")
+		return
+	}
+
+	result, err := h.service.ResolveReports(r.Context(), actorID, questionID, res)
+	if err != nil {
+		h.respondServiceError(w, err, questionID, "resolve_reports")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, result)
+}
+
+// target checks the method and resolves the acting admin and the {id} path value.
+func (h *HTTPHandlers) target(w http.ResponseWriter, r *http.Request, method string) (uuid.UUID, uuid.UUID, bool) {
+	if r.Method != method {
+		httperrors.RespondError(w, http.StatusMethodNotAllowed, httperrors.ErrCodeInvalidRequest, "Method not allowed")
+		return uuid.Nil, uuid.Nil, false
+	}
+	actorID, ok := actor(w, r)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	questionID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		httperrors.RespondValidationError(w, httperrors.ErrCodeValidationFailed, "Invalid question ID", "id")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return actorID, questionID, true
+}
+
+func (h *HTTPHandlers) respondServiceError(w http.ResponseWriter, err error, questionID uuid.UUID, op string) {
+	switch {
+	case errors.Is(err, ErrQuestionNotFound):
+		httperrors.RespondNotFound(w, httperrors.ErrCodeQuestionNotFound, "Question not found")
+	case errors.Is(err, ErrInvalidQuestion):
+		httperrors.RespondBadRequest(w, httperrors.ErrCodeInvalidQuestion, err.Error())
+	case errors.Is(err, ErrInvalidReport):
+		httperrors.RespondBadRequest(w, httperrors.ErrCodeInvalidReport, err.Error())
+	case errors.Is(err, ErrNoOpenReports):
+		httperrors.RespondError(w, http.StatusConflict, httperrors.ErrCodeNoOpenReports, "Question has no open reports")
+	default:
+		h.logger.Error().Err(err).Str("question_id", questionID.String()).Str("op", op).Msg("moderation request failed")
+		httperrors.RespondError(w, http.StatusInternalServerError, httperrors.ErrCodeModerationFailed, "Moderation request failed")
+	}
+}
+
+func (h *HTTPHandlers) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error().Err(err).Msg("failed to encode JSON response")
+	}
+}
+
+func actor(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	claims, ok := r.Context().Value("claims").(*jwt.Claims)
+	if !ok || claims == nil {
+		httperrors.RespondUnauthorized(w, httperrors.ErrCodeAuthenticationRequired, "Authentication required")
+		return uuid.Nil, false
+	}
+	return claims.UserID, true
+}
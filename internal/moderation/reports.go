@@ -0,0 +1,443 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
+	"github.com/gokatarajesh/quiz-platform/internal/match/scoring"
+)
+
+// Reasons a player can give when reporting a question.
+const (
+	ReasonWrongAnswer = "wrong_answer"
+	ReasonAmbiguous   = "ambiguous"
+	ReasonTypo        = "typo"
+	ReasonOffensive   = "offensive"
+	ReasonOther       = "other"
+)
+
+// Report states (question_reports.status).
+const (
+	ReportOpen      = "open"
+	ReportUpheld    = "upheld"
+	ReportDismissed = "dismissed"
+)
+
+// maxReportDetails caps the free text stored with a report.
+const maxReportDetails = 1000
+
+var (
+	// ErrAlreadyReported is returned when the player already reported the question.
+	ErrAlreadyReported = errors.New("question already reported")
+	// ErrInvalidReport wraps a report or resolution that fails validation.
+	ErrInvalidReport = errors.New("invalid report")
+	// ErrNoOpenReports is returned when resolving a question without open reports.
+	ErrNoOpenReports = errors.New("no open reports for question")
+)
+
+var validReasons = map[string]bool{
+	ReasonWrongAnswer: true,
+	ReasonAmbiguous:   true,
+	ReasonTypo:        true,
+	ReasonOffensive:   true,
+	ReasonOther:       true,
+}
+
+// Report is one player report against a question.
+type Report struct {
+	ID         uuid.UUID  `json:"report_id"`
+	QuestionID uuid.UUID  `json:"question_id"`
+	MatchID    *uuid.UUID `json:"match_id,omitempty"`
+	ReporterID uuid.UUID  `json:"reporter_id"`
+	Reason     string     `json:"reason"`
+	Details    string     `json:"details,omitempty"`
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ReportedQuestion summarizes the reports against one question for the moderation view.
+type ReportedQuestion struct {
+	QuestionID     uuid.UUID `json:"question_id"`
+	Prompt         string    `json:"prompt"`
+	Options        []string  `json:"options"`
+	CorrectAnswer  string    `json:"correct_answer"`
+	Source         string    `json:"source"`
+	Verified       bool      `json:"verified"`
+	ReviewStatus   string    `json:"review_status"`
+	ReportCount    int64     `json:"report_count"`
+	LastReportedAt time.Time `json:"last_reported_at"`
+}
+
+// Resolution is an admin decision on the open reports against a question.
+type Resolution struct {
+	Outcome       string  `json:"outcome"`                  // upheld or dismissed
+	CorrectAnswer *string `json:"correct_answer,omitempty"` // upheld: the fixed answer key; omit to reject the question
+	Rescore       bool    `json:"rescore,omitempty"`        // upheld with correct_answer: re-mark finished matches
+	Note          string  `json:"note,omitempty"`
+}
+
+// ResolutionResult reports what a resolution changed.
+type ResolutionResult struct {
+	QuestionID      uuid.UUID `json:"question_id"`
+	Outcome         string    `json:"outcome"`
+	ReportsResolved int64     `json:"reports_resolved"`
+	Question        *Question `json:"question,omitempty"`
+	RescoredMatches int       `json:"rescored_matches"`
+}
+
+// ReportQuestion stores a player's report. Once a verified question collects
+// reportThreshold open reports it is unverified and sent back to the review queue.
+func (s *Service) ReportQuestion(ctx context.Context, reporterID, matchID, questionID uuid.UUID, reason, details string) (*Report, error) {
+	if !validReasons[reason] {
+		return nil, fmt.Errorf("%w: unknown reason %q", ErrInvalidReport, reason)
+	}
+	details = strings.TrimSpace(details)
+	if len(details) > maxReportDetails {
+		details = details[:maxReportDetails]
+	}
+
+	pgQuestionID := pgtype.UUID{Bytes: questionID, Valid: true}
+	row, err := s.store.InsertQuestionReport(ctx, sqlcgen.InsertQuestionReportParams{
+		QuestionID: pgQuestionID,
+		MatchID:    pgtype.UUID{Bytes: matchID, Valid: matchID != uuid.Nil},
+		ReporterID: pgtype.UUID{Bytes: reporterID, Valid: true},
+		Reason:     reason,
+		Details:    details,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAlreadyReported
+	}
+	if err != nil {
+		return nil, fmt.Errorf("insert report: %w", err)
+	}
+
+	s.logger.Info().
+		Str("question_id", questionID.String()).
+		Str("reporter_id", reporterID.String()).
+		Str("reason", reason).
+		Msg("question reported")
+
+	if err := s.quarantineIfReported(ctx, questionID); err != nil {
+		s.logger.Warn().Err(err).Str("question_id", questionID.String()).Msg("failed to check report threshold")
+	}
+
+	report := toReport(row)
+	return &report, nil
+}
+
+// quarantineIfReported pulls a verified question out of the pool once it reaches the report threshold.
+func (s *Service) quarantineIfReported(ctx context.Context, questionID uuid.UUID) error {
+	pgQuestionID := pgtype.UUID{Bytes: questionID, Valid: true}
+	count, err := s.store.CountOpenReportsForQuestion(ctx, pgQuestionID)
+	if err != nil {
+		return fmt.Errorf("count reports: %w", err)
+	}
+	if count < int64(s.reportThreshold) {
+		return nil
+	}
+
+	current, err := s.load(ctx, questionID)
+	if err != nil {
+		return err
+	}
+	if !current.Verified {
+		return nil
+	}
+
+	if _, err := s.store.UpsertQuestionVerification(ctx, sqlcgen.UpsertQuestionVerificationParams{
+		Verified:     false,
+		ReviewStatus: StatusPending,
+		QuestionID:   pgQuestionID,
+	}); err != nil {
+		return fmt.Errorf("quarantine question: %w", err)
+	}
+
+	s.audit(ctx, uuid.Nil, questionID, ActionQuarantine, map[string]interface{}{
+		"previous_status": current.ReviewStatus,
+		"open_reports":    count,
+		"threshold":       s.reportThreshold,
+	})
+	s.logger.Warn().
+		Str("question_id", questionID.String()).
+		Int64("open_reports", count).
+		Msg("question quarantined after player reports")
+	return nil
+}
+
+// ListReported returns questions with reports in the given status (default open), most reported first.
+func (s *Service) ListReported(ctx context.Context, status string, limit, offset int) ([]ReportedQuestion, error) {
+	if status == "" {
+		status = ReportOpen
+	}
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	rows, err := s.store.ListReportedQuestions(ctx, sqlcgen.ListReportedQuestionsParams{
+		Status:   status,
+		MaxCount: int32(limit),
+		Skip:     int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list reported questions: %w", err)
+	}
+
+	questions := make([]ReportedQuestion, 0, len(rows))
+	for _, row := range rows {
+		questions = append(questions, ReportedQuestion{
+			QuestionID:     uuid.UUID(row.QuestionID.Bytes),
+			Prompt:         row.Prompt,
+			Options:        row.Options,
+			CorrectAnswer:  row.CorrectAnswer,
+			Source:         row.Source,
+			Verified:       row.Verified,
+			ReviewStatus:   row.ReviewStatus,
+			ReportCount:    row.ReportCount,
+			LastReportedAt: row.LastReportedAt.Time,
+		})
+	}
+	return questions, nil
+}
+
+// Reports returns every report against a question, newest first.
+func (s *Service) Reports(ctx context.Context, questionID uuid.UUID) ([]Report, error) {
+	rows, err := s.store.ListReportsForQuestion(ctx, pgtype.UUID{Bytes: questionID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("list reports: %w", err)
+	}
+	reports := make([]Report, 0, len(rows))
+	for _, row := range rows {
+		reports = append(reports, toReport(row))
+	}
+	return reports, nil
+}
+
+// ResolveReports closes the open reports against a question. Upholding them with a
+// corrected answer key edits the question (and optionally re-scores finished matches);
+// upholding without one rejects the question.
+func (s *Service) ResolveReports(ctx context.Context, actorID, questionID uuid.UUID, res Resolution) (*ResolutionResult, error) {
+	switch res.Outcome {
+	case ReportUpheld, ReportDismissed:
+	default:
+		return nil, fmt.Errorf("%w: outcome must be %s or %s", ErrInvalidReport, ReportUpheld, ReportDismissed)
+	}
+	if res.Rescore && (res.Outcome != ReportUpheld || res.CorrectAnswer == nil) {
+		return nil, fmt.Errorf("%w: rescore requires an upheld outcome with correct_answer", ErrInvalidReport)
+	}
+
+	pgQuestionID := pgtype.UUID{Bytes: questionID, Valid: true}
+	open, err := s.store.CountOpenReportsForQuestion(ctx, pgQuestionID)
+	if err != nil {
+		return nil, fmt.Errorf("count reports: %w", err)
+	}
+	if open == 0 {
+		return nil, ErrNoOpenReports
+	}
+
+	result := &ResolutionResult{QuestionID: questionID, Outcome: res.Outcome}
+	if res.Outcome == ReportUpheld {
+		if res.CorrectAnswer != nil {
+			result.Question, err = s.Edit(ctx, actorID, questionID, Edit{CorrectAnswer: res.CorrectAnswer})
+		} else {
+			result.Question, err = s.Reject(ctx, actorID, questionID, strings.TrimSpace("upheld player reports. "+res.Note))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result.ReportsResolved, err = s.store.ResolveQuestionReports(ctx, sqlcgen.ResolveQuestionReportsParams{
+		Status:     res.Outcome,
+		ResolvedBy: pgtype.UUID{Bytes: actorID, Valid: true},
+		QuestionID: pgQuestionID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("resolve reports: %w", err)
+	}
+
+	if res.Rescore {
+		result.RescoredMatches, err = s.rescoreQuestion(ctx, questionID, result.Question.CorrectAnswer)
+		if err != nil {
+			s.logger.Error().Err(err).Str("question_id", questionID.String()).Msg("failed to re-score matches")
+		}
+	}
+
+	s.audit(ctx, actorID, questionID, ActionResolveReports, map[string]interface{}{
+		"outcome":          res.Outcome,
+		"reports_resolved": result.ReportsResolved,
+		"rescored_matches": result.RescoredMatches,
+		"note":             res.Note,
+	})
+	return result, nil
+}
+
+func toReport(row sqlcgen.QuestionReport) Report {
+	report := Report{
+		ID:         uuid.UUID(row.ReportID.Bytes),
+		QuestionID: uuid.UUID(row.QuestionID.Bytes),
+		ReporterID: uuid.UUID(row.ReporterID.Bytes),
+		Reason:     row.Reason,
+		Details:    row.Details,
+		Status:     row.Status,
+		CreatedAt:  row.CreatedAt.Time,
+	}
+	if row.MatchID.Valid {
+		matchID := uuid.UUID(row.MatchID.Bytes)
+		report.MatchID = &matchID
+	}
+	return report
+}
+
+// rescoreQuestion re-marks every recorded answer to questionID in finished matches
+// against the corrected answer key, and moves the stored final score and accuracy
+// by the difference. The time bonus of a re-marked answer is unknown after the
+// fact, so it is scored without one; later answers keep their streak bonus.
+// Leaderboard totals are not rewritten. Returns the number of matches changed.
+func (s *Service) rescoreQuestion(ctx context.Context, questionID uuid.UUID, correctAnswer string) (int, error) {
+	issued, err := s.store.ListMatchQuestionsByQuestion(ctx, pgtype.UUID{Bytes: questionID, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("list matches: %w", err)
+	}
+
+	rescored := 0
+	for _, mq := range issued {
+		match, err := s.store.GetMatchForSummary(ctx, mq.MatchID)
+		if err != nil {
+			return rescored, fmt.Errorf("load match: %w", err)
+		}
+		// Matches still running are marked against the new key when they finalize
+		if match.Status != "completed" && match.Status != "timeout" {
+			continue
+		}
+		perQuestionTimeout := time.Duration(match.PerQuestionSeconds) * time.Second
+		// Re-mark under the rule the match was played with
+		ruleName := scoring.RuleFromMetadata(match.Metadata)
+		rule, err := s.scoringEngine.Rule(ruleName)
+		if err != nil {
+			s.logger.Warn().Str("match_id", uuid.UUID(mq.MatchID.Bytes).String()).Str("scoring_rule", ruleName).Msg("unknown scoring rule, using classic")
+			rule = s.scoringEngine
+		}
+
+		states, err := s.store.GetPlayerStatesByMatch(ctx, mq.MatchID)
+		if err != nil {
+			return rescored, fmt.Errorf("load player states: %w", err)
+		}
+
+		changed := false
+		for _, state := range states {
+			params, ok, err := s.rescoreState(state, rule, int(mq.QuestionOrder), correctAnswer, perQuestionTimeout)
+			if err != nil {
+				s.logger.Warn().Err(err).
+					Str("match_id", uuid.UUID(mq.MatchID.Bytes).String()).
+					Str("user_id", uuid.UUID(state.UserID.Bytes).String()).
+					Msg("skipping player during re-score")
+				continue
+			}
+			if !ok {
+				continue
+			}
+			if err := s.store.UpdatePlayerMatchResult(ctx, params); err != nil {
+				return rescored, fmt.Errorf("update player result: %w", err)
+			}
+			changed = true
+		}
+		if changed {
+			rescored++
+		}
+	}
+	return rescored, nil
+}
+
+// rescoreState re-marks one player's answer at questionOrder. ok is false when nothing changed.
+func (s *Service) rescoreState(state sqlcgen.PlayerMatchState, rule scoring.ScoringRule, questionOrder int, correctAnswer string, perQuestionTimeout time.Duration) (sqlcgen.UpdatePlayerMatchResultParams, bool, error) {
+	// Answers are patched field by field so anything else stored with them survives
+	var answers []map[string]json.RawMessage
+	if err := json.Unmarshal(state.Answers, &answers); err != nil {
+		return sqlcgen.UpdatePlayerMatchResultParams{}, false, fmt.Errorf("decode answers: %w", err)
+	}
+
+	fields := make([]scoring.AnswerRecord, len(answers))
+	for i, raw := range answers {
+		data, _ := json.Marshal(raw)
+		if err := json.Unmarshal(data, &fields[i]); err != nil {
+			return sqlcgen.UpdatePlayerMatchResultParams{}, false, fmt.Errorf("decode answer: %w", err)
+		}
+		// Answers recorded before latencies were kept earn no time bonus
+		if _, ok := raw["latency_ms"]; !ok {
+			fields[i].LatencyMs = int(perQuestionTimeout / time.Millisecond)
+		}
+	}
+
+	delta := 0
+	changed := false
+	for i := range fields {
+		ans := &fields[i]
+		if ans.QuestionOrder != questionOrder {
+			continue
+		}
+		isCorrect := ans.Answer != "" && ans.Answer == correctAnswer
+		if isCorrect == ans.IsCorrect {
+			continue
+		}
+
+		ans.IsCorrect = isCorrect
+		score := rule.AnswerScore(fields, i, perQuestionTimeout)
+
+		delta += score - ans.ScoreEarned
+		ans.ScoreEarned = score
+		answers[i]["is_correct"], _ = json.Marshal(isCorrect)
+		answers[i]["score_earned"], _ = json.Marshal(score)
+		changed = true
+	}
+	if !changed {
+		return sqlcgen.UpdatePlayerMatchResultParams{}, false, nil
+	}
+
+	correct := 0
+	for _, ans := range fields {
+		if ans.IsCorrect {
+			correct++
+		}
+	}
+	accuracy := 0.0
+	if len(fields) > 0 {
+		accuracy = float64(correct) / float64(len(fields))
+	}
+
+	finalScore := int(state.FinalScore.Int32) + delta
+	if finalScore < 0 {
+		finalScore = 0
+	}
+	answersJSON, err := json.Marshal(answers)
+	if err != nil {
+		return sqlcgen.UpdatePlayerMatchResultParams{}, false, fmt.Errorf("encode answers: %w", err)
+	}
+
+	pgAccuracy := pgtype.Numeric{}
+	if err := pgAccuracy.Scan(strconv.FormatFloat(accuracy, 'f', 2, 64)); err != nil {
+		return sqlcgen.UpdatePlayerMatchResultParams{}, false, fmt.Errorf("encode accuracy: %w", err)
+	}
+
+	return sqlcgen.UpdatePlayerMatchResultParams{
+		MatchID:        state.MatchID,
+		UserID:         state.UserID,
+		FinalScore:     pgtype.Int4{Int32: int32(finalScore), Valid: true},
+		Status:         state.Status,
+		Accuracy:       pgAccuracy,
+		StreakBonusPct: state.StreakBonusPct,
+		Answers:        answersJSON,
+	}, true, nil
+}
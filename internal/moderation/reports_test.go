@@ -0,0 +1,95 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
+)
+
+func TestReportQuarantinesAtThreshold(t *testing.T) {
+	id, matchID := uuid.New(), uuid.New()
+	row := pendingQuestion(id)
+	row.Verified = true
+	row.ReviewStatus = StatusApproved
+	store := newFakeStore(row)
+	svc := NewService(store, Options{ReportThreshold: 2}, zerolog.New(io.Discard))
+	ctx := context.Background()
+
+	reporter := uuid.New()
+	_, err := svc.ReportQuestion(ctx, reporter, matchID, id, ReasonWrongAnswer, "it's Saturn")
+	require.NoError(t, err)
+	assert.True(t, store.questions[id].Verified)
+
+	_, err = svc.ReportQuestion(ctx, reporter, matchID, id, ReasonAmbiguous, "")
+	assert.ErrorIs(t, err, ErrAlreadyReported)
+
+	_, err = svc.ReportQuestion(ctx, uuid.New(), matchID, id, "boring", "")
+	assert.ErrorIs(t, err, ErrInvalidReport)
+
+	_, err = svc.ReportQuestion(ctx, uuid.New(), matchID, id, ReasonWrongAnswer, "")
+	require.NoError(t, err)
+	assert.False(t, store.questions[id].Verified)
+	assert.Equal(t, StatusPending, store.questions[id].ReviewStatus)
+
+	require.Len(t, store.audit, 1)
+	assert.Equal(t, ActionQuarantine, store.audit[0].Action)
+	assert.False(t, store.audit[0].ActorID.Valid)
+}
+
+func TestResolveUpheldRescoresFinishedMatches(t *testing.T) {
+	id, matchID := uuid.New(), uuid.New()
+	row := pendingQuestion(id)
+	row.CorrectAnswer = "Mars"
+	store := newFakeStore(row)
+	pgMatchID := pgtype.UUID{Bytes: matchID, Valid: true}
+	store.matches[matchID] = sqlcgen.Match{MatchID: pgMatchID, Status: "completed", PerQuestionSeconds: 15}
+	store.matchQuestions = []sqlcgen.MatchQuestion{{MatchID: pgMatchID, QuestionOrder: 2, QuestionID: row.QuestionID}}
+
+	answers := `[
+		{"question_order":1,"answer":"A","is_correct":true,"score_earned":120,"submitted_at":"2026-01-01T00:00:00Z"},
+		{"question_order":2,"answer":"Jupiter","is_correct":false,"score_earned":0,"submitted_at":"2026-01-01T00:00:05Z","latency_ms":5000}
+	]`
+	right := sqlcgen.PlayerMatchState{MatchID: pgMatchID, UserID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Status: "completed",
+		FinalScore: pgtype.Int4{Int32: 120, Valid: true}, Answers: []byte(answers)}
+	unaffected := sqlcgen.PlayerMatchState{MatchID: pgMatchID, UserID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Status: "completed",
+		FinalScore: pgtype.Int4{Int32: 0, Valid: true}, Answers: []byte(`[{"question_order":2,"answer":"","is_correct":false,"score_earned":0}]`)}
+	store.states[matchID] = []sqlcgen.PlayerMatchState{right, unaffected}
+
+	ctx := context.Background()
+	_, err := newTestService(store).ReportQuestion(ctx, uuid.New(), matchID, id, ReasonWrongAnswer, "")
+	require.NoError(t, err)
+
+	answer := "Jupiter"
+	result, err := newTestService(store).ResolveReports(ctx, uuid.New(), id, Resolution{Outcome: ReportUpheld, CorrectAnswer: &answer, Rescore: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.ReportsResolved)
+	assert.Equal(t, 1, result.RescoredMatches)
+	assert.Equal(t, "Jupiter", store.questions[id].CorrectAnswer)
+
+	require.Len(t, store.results, 1)
+	updated := store.results[0]
+	assert.Equal(t, right.UserID, updated.UserID)
+	// base 100 + time bonus for 10s of 15s left (33) + one correct answer of streak (5%)
+	assert.Equal(t, int32(120+138), updated.FinalScore.Int32)
+
+	var patched []map[string]interface{}
+	require.NoError(t, json.Unmarshal(updated.Answers, &patched))
+	assert.Equal(t, true, patched[1]["is_correct"])
+	assert.Equal(t, "2026-01-01T00:00:05Z", patched[1]["submitted_at"])
+
+	_, err = newTestService(store).ResolveReports(ctx, uuid.New(), id, Resolution{Outcome: ReportDismissed})
+	assert.ErrorIs(t, err, ErrNoOpenReports)
+}
+
+func newTestService(store *fakeStore) *Service {
+	return NewService(store, Options{}, zerolog.New(io.Discard))
+}
@@ -0,0 +1,402 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+
+	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
+	"github.com/gokatarajesh/quiz-platform/internal/match/scoring"
+	"github.com/gokatarajesh/quiz-platform/internal/question"
+)
+
+// Review states of a question (questions.review_status).
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+// Audit log actions recorded for review decisions.
+const (
+	entityQuestion       = "question"
+	ActionApprove        = "question.approve"
+	ActionReject         = "question.reject"
+	ActionEdit           = "question.edit"
+	ActionQuarantine     = "question.quarantine"
+	ActionResolveReports = "question.resolve_reports"
+)
+
+var (
+	// ErrQuestionNotFound is returned when the question does not exist.
+	ErrQuestionNotFound = errors.New("question not found")
+	// ErrInvalidQuestion wraps content that fails validation.
+	ErrInvalidQuestion = errors.New("invalid question")
+)
+
+// Store is the subset of sqlc queries the moderation service needs.
+type Store interface {
+	GetQuestionByID(ctx context.Context, questionID pgtype.UUID) (sqlcgen.Question, error)
+	ListQuestionsForReview(ctx context.Context, arg sqlcgen.ListQuestionsForReviewParams) ([]sqlcgen.Question, error)
+	UpsertQuestionVerification(ctx context.Context, arg sqlcgen.UpsertQuestionVerificationParams) (sqlcgen.Question, error)
+	UpdateQuestionContent(ctx context.Context, arg sqlcgen.UpdateQuestionContentParams) (sqlcgen.Question, error)
+	InsertAuditLog(ctx context.Context, arg sqlcgen.InsertAuditLogParams) error
+	ListAuditLogsForEntity(ctx context.Context, arg sqlcgen.ListAuditLogsForEntityParams) ([]sqlcgen.AuditLog, error)
+
+	InsertQuestionReport(ctx context.Context, arg sqlcgen.InsertQuestionReportParams) (sqlcgen.QuestionReport, error)
+	CountOpenReportsForQuestion(ctx context.Context, questionID pgtype.UUID) (int64, error)
+	ListReportedQuestions(ctx context.Context, arg sqlcgen.ListReportedQuestionsParams) ([]sqlcgen.ListReportedQuestionsRow, error)
+	ListReportsForQuestion(ctx context.Context, questionID pgtype.UUID) ([]sqlcgen.QuestionReport, error)
+	ResolveQuestionReports(ctx context.Context, arg sqlcgen.ResolveQuestionReportsParams) (int64, error)
+
+	ListMatchQuestionsByQuestion(ctx context.Context, questionID pgtype.UUID) ([]sqlcgen.MatchQuestion, error)
+	GetMatchForSummary(ctx context.Context, matchID pgtype.UUID) (sqlcgen.Match, error)
+	GetPlayerStatesByMatch(ctx context.Context, matchID pgtype.UUID) ([]sqlcgen.PlayerMatchState, error)
+	UpdatePlayerMatchResult(ctx context.Context, arg sqlcgen.UpdatePlayerMatchResultParams) error
+
+	ListQuestionStats(ctx context.Context, minExposures int32) ([]sqlcgen.ListQuestionStatsRow, error)
+}
+
+// Options configures the moderation service.
+type Options struct {
+	ReportThreshold int                   // open reports that pull a verified question from the pool (default 3)
+	ScoringConfig   scoring.ScoringConfig // must match the match service when re-scoring
+	StatsMinSamples int                   // exposures before a question's stats are judged (default 20)
+}
+
+// Question is a question as shown to reviewers, including its answer key.
+type Question struct {
+	ID            uuid.UUID `json:"question_id"`
+	Source        string    `json:"source"`
+	Prompt        string    `json:"prompt"`
+	Options       []string  `json:"options"`
+	CorrectAnswer string    `json:"correct_answer"`
+	Category      string    `json:"category"`
+	Difficulty    string    `json:"difficulty"`
+	Tags          []string  `json:"tags"`
+	Verified      bool      `json:"verified"`
+	ReviewStatus  string    `json:"review_status"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ReviewFilter selects questions for the review queue. Empty fields match everything.
+type ReviewFilter struct {
+	Status     string
+	Source     string
+	Category   string
+	Difficulty string
+	Limit      int
+	Offset     int
+}
+
+// Edit holds the fields an admin changes; nil fields keep their current value.
+type Edit struct {
+	Prompt        *string  `json:"prompt,omitempty"`
+	Options       []string `json:"options,omitempty"`
+	CorrectAnswer *string  `json:"correct_answer,omitempty"`
+	Category      *string  `json:"category,omitempty"`
+	Difficulty    *string  `json:"difficulty,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+}
+
+// AuditEntry is one recorded review decision.
+type AuditEntry struct {
+	ActorID   uuid.UUID       `json:"actor_id"`
+	Action    string          `json:"action"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// Service implements the question review workflow. Every decision is written to
+// audit_logs with the reviewer's user ID.
+type Service struct {
+	store           Store
+	reportThreshold int
+	statsMinSamples int
+	scoringEngine   *scoring.Engine
+	logger          zerolog.Logger
+}
+
+// NewService creates a moderation service.
+func NewService(store Store, opts Options, logger zerolog.Logger) *Service {
+	threshold := opts.ReportThreshold
+	if threshold <= 0 {
+		threshold = 3
+	}
+	minSamples := opts.StatsMinSamples
+	if minSamples <= 0 {
+		minSamples = 20
+	}
+	scoringCfg := opts.ScoringConfig
+	if scoringCfg.BaseScore == 0 {
+		scoringCfg = scoring.DefaultScoringConfig()
+	}
+
+	return &Service{
+		store:           store,
+		reportThreshold: threshold,
+		statsMinSamples: minSamples,
+		scoringEngine:   scoring.NewEngine(scoringCfg),
+		logger:          logger.With().Str("component", "moderation").Logger(),
+	}
+}
+
+// ListQueue returns questions in the requested review state (default pending), oldest first.
+func (s *Service) ListQueue(ctx context.Context, filter ReviewFilter) ([]Question, error) {
+	if filter.Status == "" {
+		filter.Status = StatusPending
+	}
+	if filter.Limit <= 0 || filter.Limit > 200 {
+		filter.Limit = 50
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	rows, err := s.store.ListQuestionsForReview(ctx, sqlcgen.ListQuestionsForReviewParams{
+		ReviewStatus: filter.Status,
+		Source:       filter.Source,
+		Category:     filter.Category,
+		Difficulty:   filter.Difficulty,
+		MaxCount:     int32(filter.Limit),
+		Skip:         int32(filter.Offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list review queue: %w", err)
+	}
+
+	questions := make([]Question, 0, len(rows))
+	for _, row := range rows {
+		questions = append(questions, toQuestion(row))
+	}
+	return questions, nil
+}
+
+// Approve verifies a question so it is served by the curated pool.
+func (s *Service) Approve(ctx context.Context, actorID, questionID uuid.UUID) (*Question, error) {
+	return s.setReview(ctx, actorID, questionID, StatusApproved, ActionApprove, "")
+}
+
+// Reject pulls a question out of the pool and the review queue.
+func (s *Service) Reject(ctx context.Context, actorID, questionID uuid.UUID, reason string) (*Question, error) {
+	return s.setReview(ctx, actorID, questionID, StatusRejected, ActionReject, reason)
+}
+
+// BulkApprove approves every question it can and reports the rest with the reason they failed.
+func (s *Service) BulkApprove(ctx context.Context, actorID uuid.UUID, questionIDs []uuid.UUID) ([]uuid.UUID, map[uuid.UUID]string) {
+	approved := make([]uuid.UUID, 0, len(questionIDs))
+	failed := make(map[uuid.UUID]string)
+	for _, id := range questionIDs {
+		if _, err := s.Approve(ctx, actorID, id); err != nil {
+			failed[id] = err.Error()
+			continue
+		}
+		approved = append(approved, id)
+	}
+	return approved, failed
+}
+
+// Edit changes a question's content. The answer key must stay one of the options.
+func (s *Service) Edit(ctx context.Context, actorID, questionID uuid.UUID, edit Edit) (*Question, error) {
+	current, err := s.load(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := sqlcgen.UpdateQuestionContentParams{
+		Prompt:        current.Prompt,
+		Options:       current.Options,
+		CorrectAnswer: current.CorrectAnswer,
+		Category:      current.Category,
+		Difficulty:    current.Difficulty,
+		Tags:          current.Tags,
+		QuestionID:    current.QuestionID,
+	}
+	if edit.Prompt != nil {
+		params.Prompt = strings.TrimSpace(*edit.Prompt)
+	}
+	if edit.Options != nil {
+		params.Options = edit.Options
+	}
+	if edit.CorrectAnswer != nil {
+		params.CorrectAnswer = strings.TrimSpace(*edit.CorrectAnswer)
+	}
+	if edit.Category != nil {
+		params.Category = strings.TrimSpace(*edit.Category)
+	}
+	if edit.Difficulty != nil {
+		params.Difficulty = strings.ToLower(strings.TrimSpace(*edit.Difficulty))
+	}
+	if edit.Tags != nil {
+		params.Tags = edit.Tags
+	}
+	if err := validateContent(&params); err != nil {
+		return nil, err
+	}
+
+	row, err := s.store.UpdateQuestionContent(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("update question: %w", err)
+	}
+
+	before, after := toQuestion(current), toQuestion(row)
+	s.audit(ctx, actorID, questionID, ActionEdit, map[string]interface{}{
+		"before": before,
+		"after":  after,
+	})
+	return &after, nil
+}
+
+// History returns the recorded review decisions for a question, newest first.
+func (s *Service) History(ctx context.Context, questionID uuid.UUID, limit int) ([]AuditEntry, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	rows, err := s.store.ListAuditLogsForEntity(ctx, sqlcgen.ListAuditLogsForEntityParams{
+		EntityType: entityQuestion,
+		EntityID:   pgtype.UUID{Bytes: questionID, Valid: true},
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list audit logs: %w", err)
+	}
+
+	entries := make([]AuditEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, AuditEntry{
+			ActorID:   uuid.UUID(row.ActorID.Bytes),
+			Action:    row.Action,
+			Payload:   row.Payload,
+			CreatedAt: row.CreatedAt.Time,
+		})
+	}
+	return entries, nil
+}
+
+func (s *Service) setReview(ctx context.Context, actorID, questionID uuid.UUID, status, action, reason string) (*Question, error) {
+	current, err := s.load(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.store.UpsertQuestionVerification(ctx, sqlcgen.UpsertQuestionVerificationParams{
+		Verified:     status == StatusApproved,
+		ReviewStatus: status,
+		QuestionID:   current.QuestionID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update review status: %w", err)
+	}
+
+	payload := map[string]interface{}{"previous_status": current.ReviewStatus}
+	if reason != "" {
+		payload["reason"] = reason
+	}
+	s.audit(ctx, actorID, questionID, action, payload)
+
+	q := toQuestion(row)
+	return &q, nil
+}
+
+func (s *Service) load(ctx context.Context, questionID uuid.UUID) (sqlcgen.Question, error) {
+	row, err := s.store.GetQuestionByID(ctx, pgtype.UUID{Bytes: questionID, Valid: true})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return sqlcgen.Question{}, ErrQuestionNotFound
+	}
+	if err != nil {
+		return sqlcgen.Question{}, fmt.Errorf("load question: %w", err)
+	}
+	return row, nil
+}
+
+// audit records a decision. The decision itself has already been applied, so a
+// failed write is logged rather than returned. A nil actorID records a system action.
+func (s *Service) audit(ctx context.Context, actorID, questionID uuid.UUID, action string, payload map[string]interface{}) {
+	data, _ := json.Marshal(payload)
+	err := s.store.InsertAuditLog(ctx, sqlcgen.InsertAuditLogParams{
+		ActorID:    pgtype.UUID{Bytes: actorID, Valid: actorID != uuid.Nil},
+		EntityType: entityQuestion,
+		EntityID:   pgtype.UUID{Bytes: questionID, Valid: true},
+		Action:     action,
+		Payload:    data,
+	})
+	if err != nil {
+		s.logger.Error().Err(err).
+			Str("actor_id", actorID.String()).
+			Str("question_id", questionID.String()).
+			Str("action", action).
+			Msg("failed to write audit log")
+	}
+}
+
+// validateContent checks edited content the same way imports are checked.
+func validateContent(params *sqlcgen.UpdateQuestionContentParams) error {
+	if params.Prompt == "" {
+		return fmt.Errorf("%w: prompt is empty", ErrInvalidQuestion)
+	}
+
+	options := make([]string, 0, len(params.Options))
+	seen := make(map[string]bool, len(params.Options))
+	for _, opt := range params.Options {
+		opt = strings.TrimSpace(opt)
+		if opt == "" {
+			continue
+		}
+		if seen[strings.ToLower(opt)] {
+			return fmt.Errorf("%w: duplicate option %q", ErrInvalidQuestion, opt)
+		}
+		seen[strings.ToLower(opt)] = true
+		options = append(options, opt)
+	}
+	if len(options) < 2 {
+		return fmt.Errorf("%w: at least two options are required", ErrInvalidQuestion)
+	}
+	params.Options = options
+
+	found := false
+	for _, opt := range options {
+		if opt == params.CorrectAnswer {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("%w: correct_answer %q is not one of the options", ErrInvalidQuestion, params.CorrectAnswer)
+	}
+
+	switch params.Difficulty {
+	case question.DifficultyEasy, question.DifficultyMedium, question.DifficultyHard:
+	default:
+		return fmt.Errorf("%w: unknown difficulty %q", ErrInvalidQuestion, params.Difficulty)
+	}
+	if params.Category == "" {
+		return fmt.Errorf("%w: category is empty", ErrInvalidQuestion)
+	}
+	return nil
+}
+
+func toQuestion(row sqlcgen.Question) Question {
+	return Question{
+		ID:            uuid.UUID(row.QuestionID.Bytes),
+		Source:        row.Source,
+		Prompt:        row.Prompt,
+		Options:       row.Options,
+		CorrectAnswer: row.CorrectAnswer,
+		Category:      row.Category,
+		Difficulty:    row.Difficulty,
+		Tags:          row.Tags,
+		Verified:      row.Verified,
+		ReviewStatus:  row.ReviewStatus,
+		CreatedAt:     row.CreatedAt.Time,
+		UpdatedAt:     row.UpdatedAt.Time,
+	}
+}
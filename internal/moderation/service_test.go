@@ -0,0 +1,248 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
+)
+
+// fakeStore keeps questions, reports, finished matches and audit rows in memory.
+type fakeStore struct {
+	questions      map[uuid.UUID]sqlcgen.Question
+	audit          []sqlcgen.InsertAuditLogParams
+	reports        []sqlcgen.QuestionReport
+	matches        map[uuid.UUID]sqlcgen.Match
+	matchQuestions []sqlcgen.MatchQuestion
+	states         map[uuid.UUID][]sqlcgen.PlayerMatchState
+	results        []sqlcgen.UpdatePlayerMatchResultParams
+	stats          []sqlcgen.ListQuestionStatsRow
+}
+
+func newFakeStore(rows ...sqlcgen.Question) *fakeStore {
+	s := &fakeStore{
+		questions: map[uuid.UUID]sqlcgen.Question{},
+		matches:   map[uuid.UUID]sqlcgen.Match{},
+		states:    map[uuid.UUID][]sqlcgen.PlayerMatchState{},
+	}
+	for _, row := range rows {
+		s.questions[uuid.UUID(row.QuestionID.Bytes)] = row
+	}
+	return s
+}
+
+func (s *fakeStore) GetQuestionByID(ctx context.Context, questionID pgtype.UUID) (sqlcgen.Question, error) {
+	row, ok := s.questions[uuid.UUID(questionID.Bytes)]
+	if !ok {
+		return sqlcgen.Question{}, pgx.ErrNoRows
+	}
+	return row, nil
+}
+
+func (s *fakeStore) ListQuestionsForReview(ctx context.Context, arg sqlcgen.ListQuestionsForReviewParams) ([]sqlcgen.Question, error) {
+	var rows []sqlcgen.Question
+	for _, row := range s.questions {
+		if row.ReviewStatus == arg.ReviewStatus {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (s *fakeStore) UpsertQuestionVerification(ctx context.Context, arg sqlcgen.UpsertQuestionVerificationParams) (sqlcgen.Question, error) {
+	row := s.questions[uuid.UUID(arg.QuestionID.Bytes)]
+	row.Verified = arg.Verified
+	row.ReviewStatus = arg.ReviewStatus
+	s.questions[uuid.UUID(arg.QuestionID.Bytes)] = row
+	return row, nil
+}
+
+func (s *fakeStore) UpdateQuestionContent(ctx context.Context, arg sqlcgen.UpdateQuestionContentParams) (sqlcgen.Question, error) {
+	row := s.questions[uuid.UUID(arg.QuestionID.Bytes)]
+	row.Prompt = arg.Prompt
+	row.Options = arg.Options
+	row.CorrectAnswer = arg.CorrectAnswer
+	row.Category = arg.Category
+	row.Difficulty = arg.Difficulty
+	row.Tags = arg.Tags
+	s.questions[uuid.UUID(arg.QuestionID.Bytes)] = row
+	return row, nil
+}
+
+func (s *fakeStore) InsertAuditLog(ctx context.Context, arg sqlcgen.InsertAuditLogParams) error {
+	s.audit = append(s.audit, arg)
+	return nil
+}
+
+func (s *fakeStore) ListAuditLogsForEntity(ctx context.Context, arg sqlcgen.ListAuditLogsForEntityParams) ([]sqlcgen.AuditLog, error) {
+	return nil, nil
+}
+
+func (s *fakeStore) InsertQuestionReport(ctx context.Context, arg sqlcgen.InsertQuestionReportParams) (sqlcgen.QuestionReport, error) {
+	for _, r := range s.reports {
+		if r.QuestionID == arg.QuestionID && r.ReporterID == arg.ReporterID {
+			return sqlcgen.QuestionReport{}, pgx.ErrNoRows
+		}
+	}
+	row := sqlcgen.QuestionReport{
+		ReportID:   pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		QuestionID: arg.QuestionID,
+		MatchID:    arg.MatchID,
+		ReporterID: arg.ReporterID,
+		Reason:     arg.Reason,
+		Details:    arg.Details,
+		Status:     ReportOpen,
+	}
+	s.reports = append(s.reports, row)
+	return row, nil
+}
+
+func (s *fakeStore) CountOpenReportsForQuestion(ctx context.Context, questionID pgtype.UUID) (int64, error) {
+	var n int64
+	for _, r := range s.reports {
+		if r.QuestionID == questionID && r.Status == ReportOpen {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *fakeStore) ListReportedQuestions(ctx context.Context, arg sqlcgen.ListReportedQuestionsParams) ([]sqlcgen.ListReportedQuestionsRow, error) {
+	return nil, nil
+}
+
+func (s *fakeStore) ListReportsForQuestion(ctx context.Context, questionID pgtype.UUID) ([]sqlcgen.QuestionReport, error) {
+	return nil, nil
+}
+
+func (s *fakeStore) ResolveQuestionReports(ctx context.Context, arg sqlcgen.ResolveQuestionReportsParams) (int64, error) {
+	var n int64
+	for i := range s.reports {
+		if s.reports[i].QuestionID == arg.QuestionID && s.reports[i].Status == ReportOpen {
+			s.reports[i].Status = arg.Status
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *fakeStore) ListMatchQuestionsByQuestion(ctx context.Context, questionID pgtype.UUID) ([]sqlcgen.MatchQuestion, error) {
+	var rows []sqlcgen.MatchQuestion
+	for _, mq := range s.matchQuestions {
+		if mq.QuestionID == questionID {
+			rows = append(rows, mq)
+		}
+	}
+	return rows, nil
+}
+
+func (s *fakeStore) GetMatchForSummary(ctx context.Context, matchID pgtype.UUID) (sqlcgen.Match, error) {
+	return s.matches[uuid.UUID(matchID.Bytes)], nil
+}
+
+func (s *fakeStore) GetPlayerStatesByMatch(ctx context.Context, matchID pgtype.UUID) ([]sqlcgen.PlayerMatchState, error) {
+	return s.states[uuid.UUID(matchID.Bytes)], nil
+}
+
+func (s *fakeStore) UpdatePlayerMatchResult(ctx context.Context, arg sqlcgen.UpdatePlayerMatchResultParams) error {
+	s.results = append(s.results, arg)
+	return nil
+}
+
+func (s *fakeStore) ListQuestionStats(ctx context.Context, minExposures int32) ([]sqlcgen.ListQuestionStatsRow, error) {
+	var rows []sqlcgen.ListQuestionStatsRow
+	for _, row := range s.stats {
+		if row.Exposures >= minExposures {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func pendingQuestion(id uuid.UUID) sqlcgen.Question {
+	return sqlcgen.Question{
+		QuestionID:    pgtype.UUID{Bytes: id, Valid: true},
+		Source:        "ai",
+		Prompt:        "Largest planet?",
+		Options:       []string{"Jupiter", "Mars", "Venus", "Earth"},
+		CorrectAnswer: "Jupiter",
+		Category:      "science",
+		Difficulty:    "easy",
+		ReviewStatus:  StatusPending,
+	}
+}
+
+func TestApproveRecordsAudit(t *testing.T) {
+	id, admin := uuid.New(), uuid.New()
+	store := newFakeStore(pendingQuestion(id))
+	svc := NewService(store, Options{}, zerolog.New(io.Discard))
+
+	q, err := svc.Approve(context.Background(), admin, id)
+	require.NoError(t, err)
+	assert.True(t, q.Verified)
+	assert.Equal(t, StatusApproved, q.ReviewStatus)
+
+	require.Len(t, store.audit, 1)
+	entry := store.audit[0]
+	assert.Equal(t, ActionApprove, entry.Action)
+	assert.Equal(t, admin, uuid.UUID(entry.ActorID.Bytes))
+	assert.Equal(t, id, uuid.UUID(entry.EntityID.Bytes))
+
+	var payload map[string]string
+	require.NoError(t, json.Unmarshal(entry.Payload, &payload))
+	assert.Equal(t, StatusPending, payload["previous_status"])
+}
+
+func TestRejectUnverifies(t *testing.T) {
+	id := uuid.New()
+	row := pendingQuestion(id)
+	row.Verified = true
+	store := newFakeStore(row)
+	svc := NewService(store, Options{}, zerolog.New(io.Discard))
+
+	q, err := svc.Reject(context.Background(), uuid.New(), id, "ambiguous wording")
+	require.NoError(t, err)
+	assert.False(t, q.Verified)
+	assert.Equal(t, StatusRejected, q.ReviewStatus)
+	require.Len(t, store.audit, 1)
+	assert.Contains(t, string(store.audit[0].Payload), "ambiguous wording")
+}
+
+func TestEditValidatesAnswer(t *testing.T) {
+	id := uuid.New()
+	store := newFakeStore(pendingQuestion(id))
+	svc := NewService(store, Options{}, zerolog.New(io.Discard))
+
+	answer := "Pluto"
+	_, err := svc.Edit(context.Background(), uuid.New(), id, Edit{CorrectAnswer: &answer})
+	assert.ErrorIs(t, err, ErrInvalidQuestion)
+	assert.Empty(t, store.audit)
+
+	prompt := "Which planet is the largest?"
+	q, err := svc.Edit(context.Background(), uuid.New(), id, Edit{Prompt: &prompt, Options: []string{"Jupiter", "Saturn"}})
+	require.NoError(t, err)
+	assert.Equal(t, prompt, q.Prompt)
+	assert.Equal(t, []string{"Jupiter", "Saturn"}, q.Options)
+	require.Len(t, store.audit, 1)
+	assert.Equal(t, ActionEdit, store.audit[0].Action)
+}
+
+func TestBulkApprovePartialFailure(t *testing.T) {
+	known, missing := uuid.New(), uuid.New()
+	store := newFakeStore(pendingQuestion(known))
+	svc := NewService(store, Options{}, zerolog.New(io.Discard))
+
+	approved, failed := svc.BulkApprove(context.Background(), uuid.New(), []uuid.UUID{known, missing})
+	assert.Equal(t, []uuid.UUID{known}, approved)
+	require.Contains(t, failed, missing)
+	assert.Equal(t, ErrQuestionNotFound.Error(), failed[missing])
+}
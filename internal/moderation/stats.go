@@ -0,0 +1,136 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
+)
+
+// Reasons a question is flagged as a statistical outlier.
+const (
+	OutlierNobodyCorrect   = "nobody_correct"
+	OutlierEveryoneCorrect = "everyone_correct"
+	OutlierDeadDistractor  = "dead_distractor"
+	OutlierLabelMismatch   = "label_mismatch"
+)
+
+// Correct-rate bounds outside which a question is flagged.
+const (
+	nobodyCorrectRate   = 0.05
+	everyoneCorrectRate = 0.98
+)
+
+// QuestionStats is the rolled-up answer history of one question.
+type QuestionStats struct {
+	QuestionID          uuid.UUID      `json:"question_id"`
+	Prompt              string         `json:"prompt"`
+	Options             []string       `json:"options"`
+	CorrectAnswer       string         `json:"correct_answer"`
+	Difficulty          string         `json:"difficulty"`
+	EmpiricalDifficulty string         `json:"empirical_difficulty,omitempty"`
+	Verified            bool           `json:"verified"`
+	Exposures           int            `json:"exposures"`
+	Answered            int            `json:"answered"`
+	Correct             int            `json:"correct"`
+	CorrectRate         float64        `json:"correct_rate"`
+	AvgResponseMs       int            `json:"avg_response_ms"`
+	OptionPicks         map[string]int `json:"option_picks"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+}
+
+// Outlier is a question whose stats suggest it needs review.
+type Outlier struct {
+	QuestionStats
+	Reasons         []string `json:"reasons"`
+	DeadDistractors []string `json:"dead_distractors,omitempty"`
+}
+
+// Outliers returns questions with at least minExposures answers (default the
+// configured sample size) whose stats look wrong, most exposed first.
+func (s *Service) Outliers(ctx context.Context, minExposures int) ([]Outlier, error) {
+	if minExposures <= 0 {
+		minExposures = s.statsMinSamples
+	}
+
+	rows, err := s.store.ListQuestionStats(ctx, int32(minExposures))
+	if err != nil {
+		return nil, fmt.Errorf("list question stats: %w", err)
+	}
+
+	outliers := make([]Outlier, 0)
+	for _, row := range rows {
+		stats := toQuestionStats(row)
+		if o, ok := detectOutlier(stats, minExposures); ok {
+			outliers = append(outliers, o)
+		}
+	}
+	return outliers, nil
+}
+
+// detectOutlier flags a question whose correct rate is at either extreme,
+// whose wrong options are never picked, or whose measured difficulty
+// disagrees with its label. Distractors are only judged once minAnswers
+// players have answered.
+func detectOutlier(stats QuestionStats, minAnswers int) (Outlier, bool) {
+	o := Outlier{QuestionStats: stats}
+
+	switch {
+	case stats.Exposures == 0:
+		return o, false
+	case stats.CorrectRate < nobodyCorrectRate:
+		o.Reasons = append(o.Reasons, OutlierNobodyCorrect)
+	case stats.CorrectRate >= everyoneCorrectRate:
+		o.Reasons = append(o.Reasons, OutlierEveryoneCorrect)
+	}
+
+	if stats.Answered >= minAnswers {
+		for _, opt := range stats.Options {
+			if opt != stats.CorrectAnswer && stats.OptionPicks[opt] == 0 {
+				o.DeadDistractors = append(o.DeadDistractors, opt)
+			}
+		}
+		if len(o.DeadDistractors) > 0 {
+			o.Reasons = append(o.Reasons, OutlierDeadDistractor)
+		}
+	}
+
+	if stats.EmpiricalDifficulty != "" && stats.EmpiricalDifficulty != stats.Difficulty {
+		o.Reasons = append(o.Reasons, OutlierLabelMismatch)
+	}
+
+	return o, len(o.Reasons) > 0
+}
+
+func toQuestionStats(row sqlcgen.ListQuestionStatsRow) QuestionStats {
+	stats := QuestionStats{
+		QuestionID:    uuid.UUID(row.QuestionID.Bytes),
+		Prompt:        row.Prompt,
+		Options:       row.Options,
+		CorrectAnswer: row.CorrectAnswer,
+		Difficulty:    row.Difficulty,
+		Verified:      row.Verified,
+		Exposures:     int(row.Exposures),
+		Answered:      int(row.Answered),
+		Correct:       int(row.Correct),
+		AvgResponseMs: int(row.AvgResponseMs),
+		OptionPicks:   map[string]int{},
+	}
+	if row.EmpiricalDifficulty.Valid {
+		stats.EmpiricalDifficulty = row.EmpiricalDifficulty.String
+	}
+	if row.Exposures > 0 {
+		stats.CorrectRate = float64(row.Correct) / float64(row.Exposures)
+	}
+	if len(row.OptionPicks) > 0 {
+		_ = json.Unmarshal(row.OptionPicks, &stats.OptionPicks)
+	}
+	if row.UpdatedAt.Valid {
+		stats.UpdatedAt = row.UpdatedAt.Time
+	}
+	return stats
+}
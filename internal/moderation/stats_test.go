@@ -0,0 +1,61 @@
+package moderation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
+)
+
+func statsRow(id uuid.UUID, exposures, correct int32, picks string, empirical string) sqlcgen.ListQuestionStatsRow {
+	return sqlcgen.ListQuestionStatsRow{
+		QuestionID:          pgtype.UUID{Bytes: id, Valid: true},
+		Prompt:              "Largest planet?",
+		Options:             []string{"Jupiter", "Mars", "Venus", "Earth"},
+		CorrectAnswer:       "Jupiter",
+		Difficulty:          "medium",
+		Verified:            true,
+		Exposures:           exposures,
+		Answered:            exposures,
+		Correct:             correct,
+		OptionPicks:         []byte(picks),
+		EmpiricalDifficulty: pgtype.Text{String: empirical, Valid: empirical != ""},
+	}
+}
+
+func TestOutliersFlagsExtremesAndDeadDistractors(t *testing.T) {
+	healthy, impossible, trivial, skewed := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	store := newFakeStore()
+	store.stats = []sqlcgen.ListQuestionStatsRow{
+		statsRow(healthy, 40, 22, `{"Jupiter":22,"Mars":8,"Venus":6,"Earth":4}`, "medium"),
+		statsRow(impossible, 40, 1, `{"Jupiter":1,"Mars":20,"Venus":10,"Earth":9}`, "hard"),
+		statsRow(trivial, 40, 40, `{"Jupiter":40}`, "easy"),
+		statsRow(skewed, 40, 24, `{"Jupiter":24,"Mars":16}`, "medium"),
+		statsRow(uuid.New(), 5, 0, `{"Mars":5}`, ""),
+	}
+	svc := newTestService(store)
+
+	outliers, err := svc.Outliers(context.Background(), 0)
+	require.NoError(t, err)
+
+	byID := map[uuid.UUID]Outlier{}
+	for _, o := range outliers {
+		byID[o.QuestionID] = o
+	}
+	require.Len(t, byID, 3)
+	assert.NotContains(t, byID, healthy)
+
+	assert.ElementsMatch(t, []string{OutlierNobodyCorrect, OutlierLabelMismatch}, byID[impossible].Reasons)
+
+	assert.ElementsMatch(t, []string{OutlierEveryoneCorrect, OutlierDeadDistractor, OutlierLabelMismatch}, byID[trivial].Reasons)
+	assert.Equal(t, []string{"Mars", "Venus", "Earth"}, byID[trivial].DeadDistractors)
+	assert.InDelta(t, 1.0, byID[trivial].CorrectRate, 0.001)
+
+	assert.Equal(t, []string{OutlierDeadDistractor}, byID[skewed].Reasons)
+	assert.Equal(t, []string{"Venus", "Earth"}, byID[skewed].DeadDistractors)
+}
@@ -93,7 +93,9 @@ func (g *Generator) GeneratePack(ctx context.Context, req question.AIGenerateReq
 
 	questions := make([]question.Question, 0, len(genResp.Questions))
 	for _, q := range genResp.Questions {
-		questions = append(questions, normalizeAIQuestion(q))
+		normalized := normalizeAIQuestion(q)
+		normalized.Category = req.Category
+		questions = append(questions, normalized)
 	}
 
 	if len(questions) == 0 {
@@ -165,13 +167,14 @@ func normalizeAIQuestion(q aiQuestion) question.Question {
 		id = uuid.NewString()
 	}
 
+	// The ID is provisional; question.Service replaces it with the stored row's ID.
 	return question.Question{
-		ID:      id,
-		Prompt:  q.Prompt,
-		Options: options,
-		Answer:  q.Answer,
-		Source:  "ai",
-		// Type, Difficulty, Category removed - not needed
+		ID:         id,
+		Prompt:     q.Prompt,
+		Options:    options,
+		Answer:     q.Answer,
+		Source:     "ai",
+		Difficulty: strings.ToLower(q.Difficulty),
 	}
 }
 
@@ -188,7 +191,9 @@ type aiQuestion struct {
 	Prompt  string   `json:"prompt"`
 	Options []string `json:"options"`
 	Answer  string   `json:"answer"`
-	// Type, Difficulty, Category removed - not needed from Gemini
+	// Optional; the generator may tag each question with its difficulty
+	Difficulty string `json:"difficulty,omitempty"`
+	// Type, Category removed - not needed from Gemini
 	// Server will infer Type from options count and set defaults
 }
 
@@ -6,11 +6,13 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
 
 	"github.com/gokatarajesh/quiz-platform/internal/db/repository"
 	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
@@ -42,6 +44,7 @@ type Service struct {
 	ai      AIGenerator
 	redis   *redis.Client
 	hmacKey []byte
+	logger  zerolog.Logger
 }
 
 type ServiceOptions struct {
@@ -49,13 +52,14 @@ type ServiceOptions struct {
 	Redis      *redis.Client
 }
 
-func NewService(repo *repository.QuestionRepository, cache PackCache, ai AIGenerator, opts ServiceOptions) *Service {
+func NewService(repo *repository.QuestionRepository, cache PackCache, ai AIGenerator, opts ServiceOptions, logger zerolog.Logger) *Service {
 	return &Service{
 		repo:    repo,
 		cache:   cache,
 		ai:      ai,
 		redis:   opts.Redis,
 		hmacKey: opts.HMACSecret,
+		logger:  logger.With().Str("component", "question_service").Logger(),
 	}
 }
 
@@ -391,8 +395,10 @@ func (s *Service) FetchPack(ctx context.Context, req PackRequest) (PackResponse,
 }
 
 func (s *Service) fetchCurated(ctx context.Context, category, difficulty string, limit int) ([]Question, error) {
-	// Note: category and difficulty parameters are ignored - columns removed from DB
-	rows, err := s.repo.FetchPool(ctx, int32(limit))
+	if limit <= 0 {
+		return nil, nil
+	}
+	rows, err := s.repo.FetchPool(ctx, category, difficulty, int32(limit))
 	if err != nil {
 		return nil, err
 	}
@@ -413,24 +419,93 @@ func (s *Service) fetchAIMixed(ctx context.Context, category string, needs map[s
 		total += c
 	}
 
-	return s.ai.GeneratePack(ctx, AIGenerateRequest{
+	qs, err := s.ai.GeneratePack(ctx, AIGenerateRequest{
 		Category:         category,
 		Count:            total,
 		Seed:             seed,
 		DifficultyCounts: needs,
 	})
+	if err != nil {
+		return nil, err
+	}
+	return s.persistAI(ctx, category, needs, qs), nil
+}
+
+// reviewRejected is the review status moderation gives a question it rejects.
+const reviewRejected = "rejected"
+
+// persistAI stores generated questions as unverified rows keyed by content hash and
+// swaps their provisional IDs for the stored ones, so a re-generated duplicate gets
+// the same ID (and shows up in per-user question history). A duplicate is served
+// with its stored content, so moderation edits stick, and is dropped if moderation
+// rejected it. Questions that fail to persist are left out too: their provisional
+// ID has no questions row for match records and reports to point at.
+func (s *Service) persistAI(ctx context.Context, category string, needs map[string]int, qs []Question) []Question {
+	fallbackDifficulty := DifficultyMedium
+	if len(needs) == 1 {
+		for diff := range needs {
+			fallbackDifficulty = diff
+		}
+	}
+
+	kept := qs[:0]
+	for i := range qs {
+		q := &qs[i]
+		if q.Category == "" {
+			q.Category = category
+		}
+		if q.Difficulty != DifficultyEasy && q.Difficulty != DifficultyMedium && q.Difficulty != DifficultyHard {
+			q.Difficulty = fallbackDifficulty
+		}
+
+		stored, err := s.repo.UpsertAI(ctx, sqlcgen.UpsertAIQuestionParams{
+			Prompt:        q.Prompt,
+			Options:       q.Options,
+			CorrectAnswer: q.Answer,
+			Category:      q.Category,
+			Difficulty:    q.Difficulty,
+			ContentHash:   pgtype.Text{String: ContentHash(q.Prompt, q.Answer), Valid: true},
+		})
+		if err != nil {
+			s.logger.Warn().Err(err).Str("category", q.Category).Msg("failed to persist ai question, leaving it out of the pack")
+			continue
+		}
+		if stored.ReviewStatus == reviewRejected {
+			continue
+		}
+		q.ID = uuidFrom(stored.QuestionID)
+		q.Token = s.signToken(q.ID)
+		q.Prompt = stored.Prompt
+		q.Options = stored.Options
+		q.Answer = stored.CorrectAnswer
+		kept = append(kept, *q)
+	}
+	return kept
+}
+
+// ContentHash identifies a question by its normalized prompt and answer
+// (case and whitespace insensitive). Imported and AI-generated questions are both
+// hashed with it, so copies of a question dedupe against each other.
+func ContentHash(prompt, answer string) string {
+	normalize := func(text string) string {
+		return strings.ToLower(strings.Join(strings.Fields(text), " "))
+	}
+	sum := sha256.Sum256([]byte(normalize(prompt) + "\x00" + normalize(answer)))
+	return hex.EncodeToString(sum[:])
 }
 
 func (s *Service) toDomain(row sqlcgen.Question) Question {
 	id := uuidFrom(row.QuestionID)
 	return Question{
-		ID:      id,
-		Prompt:  row.Prompt,
-		Options: row.Options,
-		Answer:  row.CorrectAnswer,
-		Source:  row.Source,
-		Token:   s.signToken(id),
-		// Type, Difficulty, Category from DB are ignored - not needed
+		ID:         id,
+		Prompt:     row.Prompt,
+		Options:    row.Options,
+		Answer:     row.CorrectAnswer,
+		Source:     row.Source,
+		Token:      s.signToken(id),
+		Category:   row.Category,
+		Difficulty: row.Difficulty,
+		Tags:       row.Tags,
 	}
 }
 
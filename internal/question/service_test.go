@@ -21,17 +21,25 @@ import (
 )
 
 type stubQuestionStore struct {
-	fetch func(ctx context.Context, limit int32) ([]sqlcgen.Question, error)
+	fetch  func(ctx context.Context, arg sqlcgen.GetQuestionPoolParams) ([]sqlcgen.Question, error)
+	upsert func(ctx context.Context, arg sqlcgen.UpsertAIQuestionParams) (sqlcgen.UpsertAIQuestionRow, error)
 }
 
-func (s *stubQuestionStore) GetQuestionPool(ctx context.Context, limit int32) ([]sqlcgen.Question, error) {
-	return s.fetch(ctx, limit)
+func (s *stubQuestionStore) GetQuestionPool(ctx context.Context, arg sqlcgen.GetQuestionPoolParams) ([]sqlcgen.Question, error) {
+	return s.fetch(ctx, arg)
 }
 
 func (s *stubQuestionStore) InsertQuestion(ctx context.Context, params sqlcgen.InsertQuestionParams) (sqlcgen.Question, error) {
 	return sqlcgen.Question{}, errors.New("not implemented")
 }
 
+func (s *stubQuestionStore) UpsertAIQuestion(ctx context.Context, params sqlcgen.UpsertAIQuestionParams) (sqlcgen.UpsertAIQuestionRow, error) {
+	if s.upsert == nil {
+		return sqlcgen.UpsertAIQuestionRow{}, errors.New("not implemented")
+	}
+	return s.upsert(ctx, params)
+}
+
 type memoryCache struct {
 	store map[string]PackResponse
 }
@@ -92,13 +100,13 @@ func (s *stubAI) EnqueueCount() int {
 }
 func TestFetchPackUsesCache(t *testing.T) {
 	repo := repository.NewQuestionRepository(&stubQuestionStore{
-		fetch: func(ctx context.Context, limit int32) ([]sqlcgen.Question, error) {
+		fetch: func(ctx context.Context, arg sqlcgen.GetQuestionPoolParams) ([]sqlcgen.Question, error) {
 			return []sqlcgen.Question{sqlQuestion("curated-1", DifficultyEasy)}, nil
 		},
 	})
 	cache := newMemoryCache()
 	ai := &stubAI{}
-	service := NewService(repo, cache, ai, ServiceOptions{HMACSecret: []byte("secret")})
+	service := NewService(repo, cache, ai, ServiceOptions{HMACSecret: []byte("secret")}, zerolog.Nop())
 
 	req := PackRequest{
 		Category: "general",
@@ -120,9 +128,12 @@ func TestFetchPackUsesCache(t *testing.T) {
 
 func TestFetchPackFallsBackToAI(t *testing.T) {
 	repo := repository.NewQuestionRepository(&stubQuestionStore{
-		fetch: func(ctx context.Context, limit int32) ([]sqlcgen.Question, error) {
+		fetch: func(ctx context.Context, arg sqlcgen.GetQuestionPoolParams) ([]sqlcgen.Question, error) {
 			return nil, nil
 		},
+		upsert: func(ctx context.Context, arg sqlcgen.UpsertAIQuestionParams) (sqlcgen.UpsertAIQuestionRow, error) {
+			return sqlcgen.UpsertAIQuestionRow{QuestionID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Prompt: arg.Prompt, Options: arg.Options, CorrectAnswer: arg.CorrectAnswer, ReviewStatus: "pending"}, nil
+		},
 	})
 	cache := newMemoryCache()
 
@@ -133,7 +144,7 @@ func TestFetchPackFallsBackToAI(t *testing.T) {
 		},
 	}
 
-	service := NewService(repo, cache, ai, ServiceOptions{HMACSecret: []byte("secret")})
+	service := NewService(repo, cache, ai, ServiceOptions{HMACSecret: []byte("secret")}, zerolog.Nop())
 	req := PackRequest{
 		Category: "general",
 		DifficultyCounts: map[string]int{
@@ -147,13 +158,158 @@ func TestFetchPackFallsBackToAI(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, resp.Questions, 2)
 	assert.Equal(t, "ai", resp.Questions[0].Source)
-	assert.Equal(t, "ai-1", resp.Questions[0].ID)
-	assert.Equal(t, "ai-2", resp.Questions[1].ID)
+	assert.Equal(t, "AI Question", resp.Questions[0].Prompt)
+	assert.Equal(t, "AI Question 2", resp.Questions[1].Prompt)
+	assert.NotEqual(t, resp.Questions[0].ID, resp.Questions[1].ID)
+}
+
+func TestFetchPackFiltersCuratedByCategoryAndDifficulty(t *testing.T) {
+	var mu sync.Mutex
+	requested := map[string]sqlcgen.GetQuestionPoolParams{}
+	repo := repository.NewQuestionRepository(&stubQuestionStore{
+		fetch: func(ctx context.Context, arg sqlcgen.GetQuestionPoolParams) ([]sqlcgen.Question, error) {
+			mu.Lock()
+			requested[arg.Difficulty] = arg
+			mu.Unlock()
+
+			rows := make([]sqlcgen.Question, 0, arg.MaxCount)
+			for i := int32(0); i < arg.MaxCount; i++ {
+				rows = append(rows, sqlQuestion(fmt.Sprintf("%s-%d", arg.Difficulty, i), arg.Difficulty))
+			}
+			return rows, nil
+		},
+	})
+	service := NewService(repo, newMemoryCache(), &stubAI{}, ServiceOptions{HMACSecret: []byte("secret")}, zerolog.Nop())
+
+	req := PackRequest{
+		Category: "science",
+		DifficultyCounts: map[string]int{
+			DifficultyEasy:   2,
+			DifficultyMedium: 2,
+			DifficultyHard:   1,
+		},
+		TotalQuestions: 5,
+		Seed:           "seed",
+	}
+
+	resp, err := service.FetchPack(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Questions, 5)
+
+	counts := map[string]int{}
+	for _, q := range resp.Questions {
+		counts[q.Difficulty]++
+	}
+	assert.Equal(t, req.DifficultyCounts, counts)
+	for diff, count := range req.DifficultyCounts {
+		assert.Equal(t, "science", requested[diff].Category)
+		assert.Equal(t, int32(count), requested[diff].MaxCount)
+	}
+}
+
+func TestFetchPackPersistsAIQuestionsByContentHash(t *testing.T) {
+	var mu sync.Mutex
+	stored := map[string]sqlcgen.UpsertAIQuestionRow{}
+	var params []sqlcgen.UpsertAIQuestionParams
+	repo := repository.NewQuestionRepository(&stubQuestionStore{
+		fetch: func(ctx context.Context, arg sqlcgen.GetQuestionPoolParams) ([]sqlcgen.Question, error) {
+			return nil, nil
+		},
+		upsert: func(ctx context.Context, arg sqlcgen.UpsertAIQuestionParams) (sqlcgen.UpsertAIQuestionRow, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			params = append(params, arg)
+			row, ok := stored[arg.ContentHash.String]
+			if !ok {
+				row = sqlcgen.UpsertAIQuestionRow{
+					QuestionID:    pgtype.UUID{Bytes: uuid.New(), Valid: true},
+					Prompt:        arg.Prompt,
+					Options:       arg.Options,
+					CorrectAnswer: arg.CorrectAnswer,
+					ReviewStatus:  "pending",
+				}
+				stored[arg.ContentHash.String] = row
+			}
+			return row, nil
+		},
+	})
+	ai := &stubAI{
+		generated: []Question{
+			{ID: "provisional-1", Prompt: "Capital of  France?", Options: []string{"Paris", "Rome"}, Answer: "Paris", Source: "ai"},
+			{ID: "provisional-2", Prompt: "capital of france?", Options: []string{"Paris", "Rome"}, Answer: "paris", Source: "ai"},
+		},
+	}
+	service := NewService(repo, newMemoryCache(), ai, ServiceOptions{HMACSecret: []byte("secret")}, zerolog.Nop())
+
+	qs, err := service.fetchAIMixed(context.Background(), "geography", map[string]int{DifficultyHard: 2}, "seed")
+	assert.NoError(t, err)
+	if assert.Len(t, qs, 2) {
+		assert.Equal(t, qs[0].ID, qs[1].ID, "re-generated duplicate should resolve to the stored ID")
+		assert.NotEqual(t, "provisional-1", qs[0].ID)
+		assert.Equal(t, service.signToken(qs[0].ID), qs[0].Token)
+	}
+	if assert.Len(t, params, 2) {
+		assert.Equal(t, "geography", params[0].Category)
+		assert.Equal(t, DifficultyHard, params[0].Difficulty)
+	}
+	assert.Equal(t, ContentHash("Capital of France?", "Paris"), ContentHash(" capital of\tfrance? ", "PARIS"))
+	assert.NotEqual(t, ContentHash("Capital of France?", "Paris"), ContentHash("Capital of France?", "Rome"))
+}
+
+func TestPersistAIServesModeratedContent(t *testing.T) {
+	edited := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	repo := repository.NewQuestionRepository(&stubQuestionStore{
+		upsert: func(ctx context.Context, arg sqlcgen.UpsertAIQuestionParams) (sqlcgen.UpsertAIQuestionRow, error) {
+			if strings.Contains(arg.Prompt, "Pluto") {
+				return sqlcgen.UpsertAIQuestionRow{QuestionID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Prompt: arg.Prompt, ReviewStatus: "rejected"}, nil
+			}
+			return sqlcgen.UpsertAIQuestionRow{
+				QuestionID:    edited,
+				Prompt:        "Which planet is largest?",
+				Options:       []string{"Jupiter", "Saturn", "Mars"},
+				CorrectAnswer: "Jupiter",
+				ReviewStatus:  "approved",
+			}, nil
+		},
+	})
+	service := NewService(repo, newMemoryCache(), nil, ServiceOptions{HMACSecret: []byte("secret")}, zerolog.Nop())
+
+	qs := service.persistAI(context.Background(), "science", map[string]int{DifficultyEasy: 2}, []Question{
+		{ID: "provisional-1", Prompt: "Pluto is a planet?", Options: []string{"Yes", "No"}, Answer: "Yes"},
+		{ID: "provisional-2", Prompt: "Largest planet?", Options: []string{"Saturn", "Mars"}, Answer: "Saturn"},
+	})
+	if assert.Len(t, qs, 1, "rejected duplicate should be dropped") {
+		assert.Equal(t, uuidFrom(edited), qs[0].ID)
+		assert.Equal(t, "Which planet is largest?", qs[0].Prompt)
+		assert.Equal(t, []string{"Jupiter", "Saturn", "Mars"}, qs[0].Options)
+		assert.Equal(t, "Jupiter", qs[0].Answer)
+	}
+}
+
+func TestPersistAILeavesOutUnstoredQuestions(t *testing.T) {
+	repo := repository.NewQuestionRepository(&stubQuestionStore{
+		upsert: func(ctx context.Context, arg sqlcgen.UpsertAIQuestionParams) (sqlcgen.UpsertAIQuestionRow, error) {
+			if strings.HasPrefix(arg.Prompt, "Broken") {
+				return sqlcgen.UpsertAIQuestionRow{}, errors.New("db down")
+			}
+			return sqlcgen.UpsertAIQuestionRow{QuestionID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Prompt: arg.Prompt, Options: arg.Options, CorrectAnswer: arg.CorrectAnswer, ReviewStatus: "pending"}, nil
+		},
+	})
+	service := NewService(repo, newMemoryCache(), nil, ServiceOptions{HMACSecret: []byte("secret")}, zerolog.Nop())
+
+	qs := service.persistAI(context.Background(), "science", map[string]int{DifficultyEasy: 2}, []Question{
+		{ID: "provisional-1", Prompt: "Broken question?", Options: []string{"A", "B"}, Answer: "A"},
+		{ID: "provisional-2", Prompt: "Stored question?", Options: []string{"A", "B"}, Answer: "B"},
+	})
+	if assert.Len(t, qs, 1) {
+		assert.Equal(t, "Stored question?", qs[0].Prompt)
+		assert.NotEqual(t, "provisional-2", qs[0].ID)
+	}
 }
 
 func TestFetcherWorkerEnqueueAIOnFailure(t *testing.T) {
 	repo := repository.NewQuestionRepository(&stubQuestionStore{
-		fetch: func(ctx context.Context, limit int32) ([]sqlcgen.Question, error) {
+		fetch: func(ctx context.Context, arg sqlcgen.GetQuestionPoolParams) ([]sqlcgen.Question, error) {
 			return nil, errors.New("db down")
 		},
 	})
@@ -161,7 +317,7 @@ func TestFetcherWorkerEnqueueAIOnFailure(t *testing.T) {
 	ai := &stubAI{
 		generated: []Question{{ID: "ai"}},
 	}
-	service := NewService(repo, cache, ai, ServiceOptions{HMACSecret: []byte("secret")})
+	service := NewService(repo, cache, ai, ServiceOptions{HMACSecret: []byte("secret")}, zerolog.Nop())
 
 	queue := make(chan PackRequest, 1)
 	queue <- PackRequest{
@@ -198,6 +354,8 @@ func sqlQuestion(id string, difficulty string) sqlcgen.Question {
 		CorrectAnswer: "A",
 		Source:        "curated",
 		Verified:      true,
+		Category:      "general",
+		Difficulty:    difficulty,
 	}
 }
 
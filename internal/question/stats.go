@@ -0,0 +1,81 @@
+package question
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
+)
+
+// Correct-rate cut-offs for empirical difficulty. A question at or above
+// CalibrationEasyRate is easy, below CalibrationHardRate is hard, medium otherwise.
+const (
+	CalibrationEasyRate = 0.75
+	CalibrationHardRate = 0.40
+)
+
+// StatsWorker periodically rolls player answers up into question_stats and
+// recalibrates each question's empirical difficulty.
+type StatsWorker struct {
+	queries    *sqlcgen.Queries
+	logger     zerolog.Logger
+	interval   time.Duration
+	minSamples int
+}
+
+func NewStatsWorker(queries *sqlcgen.Queries, interval time.Duration, minSamples int, logger zerolog.Logger) *StatsWorker {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	if minSamples <= 0 {
+		minSamples = 20
+	}
+	return &StatsWorker{
+		queries:    queries,
+		logger:     logger.With().Str("component", "question_stats_worker").Logger(),
+		interval:   interval,
+		minSamples: minSamples,
+	}
+}
+
+// Run blocks until context cancellation.
+func (w *StatsWorker) Run(ctx context.Context) error {
+	if w.queries == nil {
+		return nil
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	// run immediately
+	w.tick(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			w.tick(ctx)
+		}
+	}
+}
+
+func (w *StatsWorker) tick(ctx context.Context) {
+	started := time.Now()
+	n, err := w.queries.RefreshQuestionStats(ctx, sqlcgen.RefreshQuestionStatsParams{
+		MinSamples: int32(w.minSamples),
+		EasyRate:   CalibrationEasyRate,
+		HardRate:   CalibrationHardRate,
+	})
+	if err != nil {
+		w.logger.Warn().Err(err).Msg("question stats refresh failed")
+		return
+	}
+
+	w.logger.Info().
+		Int64("questions", n).
+		Dur("took", time.Since(started)).
+		Msg("question stats refreshed")
+}
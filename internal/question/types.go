@@ -24,7 +24,9 @@ type Question struct {
 	Answer     string   `json:"answer,omitempty"` // server-side only
 	Source     string   `json:"source"`
 	Token      string   `json:"token"`
-	// Type, Difficulty, Category removed - not needed, only stored in DB
+	Category   string   `json:"category,omitempty"`
+	Difficulty string   `json:"difficulty,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
 }
 
 // PackRequest guides selection for matchmaking.
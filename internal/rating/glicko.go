@@ -0,0 +1,124 @@
+package rating
+
+import "math"
+
+// Starting values for a player who has never been rated (Glicko-2 defaults).
+const (
+	DefaultRating     = 1500.0
+	DefaultDeviation  = 350.0
+	DefaultVolatility = 0.06
+)
+
+const (
+	// glickoScale converts between the Glicko and Glicko-2 scales.
+	glickoScale = 173.7178
+	// convergence is the tolerance of the volatility iteration.
+	convergence = 0.000001
+)
+
+// Outcomes of a game from one player's side.
+const (
+	Loss = 0.0
+	Draw = 0.5
+	Win  = 1.0
+)
+
+// Rating is a player's Glicko-2 rating on the familiar Glicko scale.
+type Rating struct {
+	Rating     float64 `json:"rating"`
+	Deviation  float64 `json:"deviation"`
+	Volatility float64 `json:"volatility"`
+}
+
+// Default returns the rating of a new player.
+func Default() Rating {
+	return Rating{Rating: DefaultRating, Deviation: DefaultDeviation, Volatility: DefaultVolatility}
+}
+
+// Result is one game against an opponent within a rating period.
+type Result struct {
+	Opponent Rating
+	Score    float64 // Win, Draw or Loss
+}
+
+// Update applies a rating period's results to player, following Glickman's
+// "Example of the Glicko-2 system". tau constrains how fast volatility changes.
+// A period without results only widens the deviation.
+func Update(player Rating, results []Result, tau float64) Rating {
+	mu := (player.Rating - DefaultRating) / glickoScale
+	phi := player.Deviation / glickoScale
+	sigma := player.Volatility
+
+	if len(results) == 0 {
+		player.Deviation = math.Sqrt(phi*phi+sigma*sigma) * glickoScale
+		return player
+	}
+
+	// Estimated variance and improvement from the period's games
+	var vInv, improvement float64
+	for _, r := range results {
+		muJ := (r.Opponent.Rating - DefaultRating) / glickoScale
+		gJ := g(r.Opponent.Deviation / glickoScale)
+		e := expected(mu, muJ, gJ)
+		vInv += gJ * gJ * e * (1 - e)
+		improvement += gJ * (r.Score - e)
+	}
+	v := 1 / vInv
+	delta := v * improvement
+
+	sigma = newVolatility(phi, sigma, v, delta, tau)
+
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	phi = 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	mu += phi * phi * improvement
+
+	return Rating{
+		Rating:     mu*glickoScale + DefaultRating,
+		Deviation:  phi * glickoScale,
+		Volatility: sigma,
+	}
+}
+
+func g(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+func expected(mu, muJ, gJ float64) float64 {
+	return 1 / (1 + math.Exp(-gJ*(mu-muJ)))
+}
+
+// newVolatility solves for the new volatility with the Illinois algorithm
+// (step 5 of the Glicko-2 paper).
+func newVolatility(phi, sigma, v, delta, tau float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-a)/(tau*tau)
+	}
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+		B = a - k*tau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > convergence {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+	return math.Exp(A / 2)
+}
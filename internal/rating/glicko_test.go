@@ -0,0 +1,47 @@
+package rating
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The worked example from Glickman's "Example of the Glicko-2 system".
+func TestUpdateMatchesGlickmanExample(t *testing.T) {
+	player := Rating{Rating: 1500, Deviation: 200, Volatility: 0.06}
+	results := []Result{
+		{Opponent: Rating{Rating: 1400, Deviation: 30, Volatility: 0.06}, Score: Win},
+		{Opponent: Rating{Rating: 1550, Deviation: 100, Volatility: 0.06}, Score: Loss},
+		{Opponent: Rating{Rating: 1700, Deviation: 300, Volatility: 0.06}, Score: Loss},
+	}
+
+	got := Update(player, results, 0.5)
+	assert.InDelta(t, 1464.06, got.Rating, 0.01)
+	assert.InDelta(t, 151.52, got.Deviation, 0.01)
+	assert.InDelta(t, 0.05999, got.Volatility, 0.00001)
+}
+
+func TestUpdateSingleGame(t *testing.T) {
+	a, b := Default(), Default()
+	winner := Update(a, []Result{{Opponent: b, Score: Win}}, 0.5)
+	loser := Update(b, []Result{{Opponent: a, Score: Loss}}, 0.5)
+
+	assert.Greater(t, winner.Rating, DefaultRating)
+	assert.InDelta(t, DefaultRating-loser.Rating, winner.Rating-DefaultRating, 1e-9)
+	assert.Less(t, winner.Deviation, DefaultDeviation)
+
+	// Beating a much weaker, well-established player is worth little
+	veteran := Rating{Rating: 2100, Deviation: 50, Volatility: 0.06}
+	novice := Rating{Rating: 1300, Deviation: 50, Volatility: 0.06}
+	assert.Less(t, Update(veteran, []Result{{Opponent: novice, Score: Win}}, 0.5).Rating-veteran.Rating, 1.0)
+
+	draw := Update(a, []Result{{Opponent: b, Score: Draw}}, 0.5)
+	assert.InDelta(t, DefaultRating, draw.Rating, 1e-9)
+}
+
+func TestUpdateWithoutGamesWidensDeviation(t *testing.T) {
+	player := Rating{Rating: 1620, Deviation: 80, Volatility: 0.06}
+	got := Update(player, nil, 0.5)
+	assert.Equal(t, player.Rating, got.Rating)
+	assert.Greater(t, got.Deviation, player.Deviation)
+}
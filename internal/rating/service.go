@@ -0,0 +1,239 @@
+package rating
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+
+	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
+)
+
+// Queries is the subset of sqlc queries the rating service needs.
+type Queries interface {
+	GetPlayerRating(ctx context.Context, userID pgtype.UUID) (sqlcgen.PlayerRating, error)
+	EnsurePlayerRating(ctx context.Context, arg sqlcgen.EnsurePlayerRatingParams) error
+	LockPlayerRating(ctx context.Context, userID pgtype.UUID) (sqlcgen.PlayerRating, error)
+	UpsertPlayerRating(ctx context.Context, arg sqlcgen.UpsertPlayerRatingParams) error
+	InsertRatingHistory(ctx context.Context, arg sqlcgen.InsertRatingHistoryParams) (int64, error)
+	VoidMatchRatings(ctx context.Context, matchID pgtype.UUID) ([]sqlcgen.VoidMatchRatingsRow, error)
+}
+
+// Store runs the rating queries, on their own or together in one transaction.
+type Store interface {
+	Queries
+	InTx(ctx context.Context, fn func(q Queries) error) error
+}
+
+// pgStore is the Store on a Postgres connection pool.
+type pgStore struct {
+	*sqlcgen.Queries
+	pool *pgxpool.Pool
+}
+
+// NewStore returns a Store backed by the pool.
+func NewStore(pool *pgxpool.Pool) Store {
+	return &pgStore{Queries: sqlcgen.New(pool), pool: pool}
+}
+
+func (s *pgStore) InTx(ctx context.Context, fn func(q Queries) error) error {
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		return fn(s.Queries.WithTx(tx))
+	})
+}
+
+// Options configures the rating service.
+type Options struct {
+	Tau float64 // how fast volatility may change; 0.3 to 1.2 is sensible (default 0.5)
+}
+
+// Change is one player's rating before and after a match.
+type Change struct {
+	UserID uuid.UUID
+	Before Rating
+	After  Rating
+}
+
+// Delta is the change in displayed (rounded) rating.
+func (c Change) Delta() int {
+	return Display(c.After) - Display(c.Before)
+}
+
+// Display rounds a rating to the whole number shown to players.
+func Display(r Rating) int {
+	return int(math.Round(r.Rating))
+}
+
+// Service keeps Glicko-2 ratings for registered players. Each rated match is a
+// rating period of one game, and every change is recorded in rating_history.
+type Service struct {
+	store  Store
+	tau    float64
+	logger zerolog.Logger
+}
+
+// NewService creates a rating service.
+func NewService(store Store, opts Options, logger zerolog.Logger) *Service {
+	tau := opts.Tau
+	if tau <= 0 {
+		tau = 0.5
+	}
+
+	return &Service{
+		store:  store,
+		tau:    tau,
+		logger: logger.With().Str("component", "rating").Logger(),
+	}
+}
+
+// Get returns a player's rating, or the starting rating for an unrated player.
+func (s *Service) Get(ctx context.Context, userID uuid.UUID) (Rating, error) {
+	row, err := s.store.GetPlayerRating(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Default(), nil
+	}
+	if err != nil {
+		return Rating{}, err
+	}
+	return Rating{Rating: row.Rating, Deviation: row.Deviation, Volatility: row.Volatility}, nil
+}
+
+// RecordMatch rates a finished one-on-one match. scoreA is playerA's outcome
+// (Win, Draw or Loss). A match is rated once; recording it again returns no
+// changes. Both players' rating rows are locked while the match is rated, and
+// the history and new ratings are written together or not at all.
+func (s *Service) RecordMatch(ctx context.Context, matchID, playerA, playerB uuid.UUID, scoreA float64) ([]Change, error) {
+	var changes []Change
+	err := s.store.InTx(ctx, func(q Queries) error {
+		var err error
+		changes, err = s.recordMatch(ctx, q, matchID, playerA, playerB, scoreA)
+		return err
+	})
+	if err != nil || changes == nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("match_id", matchID.String()).
+		Int("delta_a", changes[0].Delta()).
+		Int("delta_b", changes[1].Delta()).
+		Msg("match rated")
+	return changes, nil
+}
+
+func (s *Service) recordMatch(ctx context.Context, q Queries, matchID, playerA, playerB uuid.UUID, scoreA float64) ([]Change, error) {
+	ratings, err := lockRatings(ctx, q, playerA, playerB)
+	if err != nil {
+		return nil, err
+	}
+	a, b := ratings[playerA], ratings[playerB]
+
+	changes := []Change{
+		{UserID: playerA, Before: a, After: Update(a, []Result{{Opponent: b, Score: scoreA}}, s.tau)},
+		{UserID: playerB, Before: b, After: Update(b, []Result{{Opponent: a, Score: 1 - scoreA}}, s.tau)},
+	}
+
+	// History rows go first: the unique (match, user) key is what stops a match being rated twice
+	for i, c := range changes {
+		opponent := changes[1-i].UserID
+		n, err := q.InsertRatingHistory(ctx, sqlcgen.InsertRatingHistoryParams{
+			UserID:          pgtype.UUID{Bytes: c.UserID, Valid: true},
+			MatchID:         pgtype.UUID{Bytes: matchID, Valid: true},
+			OpponentID:      pgtype.UUID{Bytes: opponent, Valid: true},
+			Outcome:         outcomeFor(scoreA, i),
+			RatingBefore:    c.Before.Rating,
+			RatingAfter:     c.After.Rating,
+			DeviationBefore: c.Before.Deviation,
+			DeviationAfter:  c.After.Deviation,
+			VolatilityAfter: c.After.Volatility,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("record rating history: %w", err)
+		}
+		if n == 0 && i == 0 {
+			return nil, nil
+		}
+	}
+
+	for _, c := range changes {
+		err := q.UpsertPlayerRating(ctx, sqlcgen.UpsertPlayerRatingParams{
+			UserID:     pgtype.UUID{Bytes: c.UserID, Valid: true},
+			Rating:     c.After.Rating,
+			Deviation:  c.After.Deviation,
+			Volatility: c.After.Volatility,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("store rating for %s: %w", c.UserID, err)
+		}
+	}
+	return changes, nil
+}
+
+// lockRatings locks the players' rating rows, creating them at the starting
+// rating for unrated players, and returns their current ratings. Rows are locked
+// in user ID order so two matches sharing both players cannot deadlock.
+func lockRatings(ctx context.Context, q Queries, players ...uuid.UUID) (map[uuid.UUID]Rating, error) {
+	ordered := append([]uuid.UUID(nil), players...)
+	sort.Slice(ordered, func(i, j int) bool {
+		return bytes.Compare(ordered[i][:], ordered[j][:]) < 0
+	})
+
+	start := Default()
+	ratings := make(map[uuid.UUID]Rating, len(ordered))
+	for _, userID := range ordered {
+		pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+		err := q.EnsurePlayerRating(ctx, sqlcgen.EnsurePlayerRatingParams{
+			UserID:     pgUserID,
+			Rating:     start.Rating,
+			Deviation:  start.Deviation,
+			Volatility: start.Volatility,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create rating for %s: %w", userID, err)
+		}
+		row, err := q.LockPlayerRating(ctx, pgUserID)
+		if err != nil {
+			return nil, fmt.Errorf("load rating for %s: %w", userID, err)
+		}
+		ratings[userID] = Rating{Rating: row.Rating, Deviation: row.Deviation, Volatility: row.Volatility}
+	}
+	return ratings, nil
+}
+
+// VoidMatch takes a rated match's changes back off both players, for a match
+// invalidated after the fact. Games played since are kept. A match from before the
+// last season rollover is voided without touching the reset ratings. Returns the
+// players' ratings afterwards; a match that was not rated, was already voided or
+// is from an earlier season returns none.
+func (s *Service) VoidMatch(ctx context.Context, matchID uuid.UUID) (map[uuid.UUID]Rating, error) {
+	rows, err := s.store.VoidMatchRatings(ctx, pgtype.UUID{Bytes: matchID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("void match ratings: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	restored := make(map[uuid.UUID]Rating, len(rows))
+	for _, row := range rows {
+		restored[uuid.UUID(row.UserID.Bytes)] = Rating{Rating: row.Rating, Deviation: row.Deviation, Volatility: row.Volatility}
+	}
+	s.logger.Info().Str("match_id", matchID.String()).Int("players", len(rows)).Msg("match ratings voided")
+	return restored, nil
+}
+
+// outcomeFor returns the outcome of the i-th player given playerA's.
+func outcomeFor(scoreA float64, i int) float64 {
+	if i == 0 {
+		return scoreA
+	}
+	return 1 - scoreA
+}
@@ -0,0 +1,230 @@
+package rating
+
+import (
+	"context"
+	"errors"
+	"io"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sqlcgen "github.com/gokatarajesh/quiz-platform/internal/db/sqlc"
+)
+
+// fakeStore keeps ratings and history in memory. A failed transaction restores
+// both.
+type fakeStore struct {
+	ratings    map[uuid.UUID]sqlcgen.PlayerRating
+	history    []sqlcgen.InsertRatingHistoryParams
+	voided     map[int]bool
+	rolledOver int // history before this index was rated in an earlier season
+	inTx       bool
+	failUpsert uuid.UUID // UpsertPlayerRating fails for this player
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{ratings: map[uuid.UUID]sqlcgen.PlayerRating{}, voided: map[int]bool{}}
+}
+
+func (s *fakeStore) GetPlayerRating(ctx context.Context, userID pgtype.UUID) (sqlcgen.PlayerRating, error) {
+	row, ok := s.ratings[uuid.UUID(userID.Bytes)]
+	if !ok {
+		return sqlcgen.PlayerRating{}, pgx.ErrNoRows
+	}
+	return row, nil
+}
+
+func (s *fakeStore) InTx(ctx context.Context, fn func(q Queries) error) error {
+	ratings := maps.Clone(s.ratings)
+	history := slices.Clone(s.history)
+	s.inTx = true
+	defer func() { s.inTx = false }()
+	if err := fn(s); err != nil {
+		s.ratings, s.history = ratings, history
+		return err
+	}
+	return nil
+}
+
+func (s *fakeStore) EnsurePlayerRating(ctx context.Context, arg sqlcgen.EnsurePlayerRatingParams) error {
+	userID := uuid.UUID(arg.UserID.Bytes)
+	if _, ok := s.ratings[userID]; !ok {
+		s.ratings[userID] = sqlcgen.PlayerRating{UserID: arg.UserID, Rating: arg.Rating, Deviation: arg.Deviation, Volatility: arg.Volatility}
+	}
+	return nil
+}
+
+func (s *fakeStore) LockPlayerRating(ctx context.Context, userID pgtype.UUID) (sqlcgen.PlayerRating, error) {
+	if !s.inTx {
+		return sqlcgen.PlayerRating{}, errors.New("row lock outside a transaction")
+	}
+	return s.GetPlayerRating(ctx, userID)
+}
+
+func (s *fakeStore) UpsertPlayerRating(ctx context.Context, arg sqlcgen.UpsertPlayerRatingParams) error {
+	if uuid.UUID(arg.UserID.Bytes) == s.failUpsert {
+		return errors.New("connection reset")
+	}
+	row := s.ratings[uuid.UUID(arg.UserID.Bytes)]
+	s.ratings[uuid.UUID(arg.UserID.Bytes)] = sqlcgen.PlayerRating{
+		UserID:     arg.UserID,
+		Rating:     arg.Rating,
+		Deviation:  arg.Deviation,
+		Volatility: arg.Volatility,
+		Games:      row.Games + 1,
+	}
+	return nil
+}
+
+func (s *fakeStore) InsertRatingHistory(ctx context.Context, arg sqlcgen.InsertRatingHistoryParams) (int64, error) {
+	for _, h := range s.history {
+		if h.MatchID == arg.MatchID && h.UserID == arg.UserID {
+			return 0, nil
+		}
+	}
+	s.history = append(s.history, arg)
+	return 1, nil
+}
+
+func (s *fakeStore) VoidMatchRatings(ctx context.Context, matchID pgtype.UUID) ([]sqlcgen.VoidMatchRatingsRow, error) {
+	var rows []sqlcgen.VoidMatchRatingsRow
+	for i, h := range s.history {
+		if h.MatchID != matchID || s.voided[i] {
+			continue
+		}
+		s.voided[i] = true
+		if i < s.rolledOver {
+			continue
+		}
+		userID := uuid.UUID(h.UserID.Bytes)
+		row := s.ratings[userID]
+		row.Rating -= h.RatingAfter - h.RatingBefore
+		row.Deviation = max(row.Deviation, h.DeviationBefore)
+		row.Games = max(row.Games-1, 0)
+		s.ratings[userID] = row
+		rows = append(rows, sqlcgen.VoidMatchRatingsRow{UserID: h.UserID, Rating: row.Rating, Deviation: row.Deviation, Volatility: row.Volatility})
+	}
+	return rows, nil
+}
+
+func TestRecordMatchRatesOnce(t *testing.T) {
+	store := newFakeStore()
+	winner, loser := uuid.New(), uuid.New()
+	store.ratings[loser] = sqlcgen.PlayerRating{Rating: 1650, Deviation: 90, Volatility: 0.06, Games: 40}
+	svc := NewService(store, Options{}, zerolog.New(io.Discard))
+
+	matchID := uuid.New()
+	changes, err := svc.RecordMatch(context.Background(), matchID, winner, loser, Win)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+
+	assert.Equal(t, Default(), changes[0].Before)
+	assert.Greater(t, changes[0].Delta(), 0)
+	assert.Less(t, changes[1].Delta(), 0)
+	// The provisional player moves much further than the established one
+	assert.Greater(t, changes[0].Delta(), -changes[1].Delta())
+
+	assert.Equal(t, int32(1), store.ratings[winner].Games)
+	assert.Equal(t, int32(41), store.ratings[loser].Games)
+	require.Len(t, store.history, 2)
+	assert.Equal(t, Win, store.history[0].Outcome)
+	assert.Equal(t, Loss, store.history[1].Outcome)
+	assert.Equal(t, loser, uuid.UUID(store.history[0].OpponentID.Bytes))
+
+	// Finalizing the same match again changes nothing
+	changes, err = svc.RecordMatch(context.Background(), matchID, winner, loser, Win)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, int32(1), store.ratings[winner].Games)
+
+	got, err := svc.Get(context.Background(), winner)
+	require.NoError(t, err)
+	assert.Greater(t, got.Rating, DefaultRating)
+}
+
+func TestVoidMatchTakesBackItsChangesOnce(t *testing.T) {
+	store := newFakeStore()
+	trader, partner := uuid.New(), uuid.New()
+	store.ratings[partner] = sqlcgen.PlayerRating{Rating: 1500, Deviation: 120, Volatility: 0.06, Games: 20}
+	svc := NewService(store, Options{}, zerolog.New(io.Discard))
+	ctx := context.Background()
+
+	traded := uuid.New()
+	_, err := svc.RecordMatch(ctx, traded, trader, partner, Win)
+	require.NoError(t, err)
+	// A legitimate game played after the traded one
+	rival := uuid.New()
+	later, err := svc.RecordMatch(ctx, uuid.New(), trader, rival, Loss)
+	require.NoError(t, err)
+	laterDelta := later[0].After.Rating - later[0].Before.Rating
+
+	restored, err := svc.VoidMatch(ctx, traded)
+	require.NoError(t, err)
+	require.Len(t, restored, 2)
+	assert.InDelta(t, DefaultRating+laterDelta, restored[trader].Rating, 1e-9, "only the traded win is taken back")
+	assert.InDelta(t, 1500, restored[partner].Rating, 1e-9)
+	assert.Equal(t, 120.0, restored[partner].Deviation)
+	assert.Equal(t, int32(1), store.ratings[trader].Games)
+	assert.Equal(t, int32(20), store.ratings[partner].Games)
+
+	// Voiding again changes nothing
+	restored, err = svc.VoidMatch(ctx, traded)
+	require.NoError(t, err)
+	assert.Empty(t, restored)
+	assert.InDelta(t, 1500, store.ratings[partner].Rating, 1e-9)
+}
+
+func TestVoidMatchFromBeforeTheSeasonResetKeepsResetRatings(t *testing.T) {
+	store := newFakeStore()
+	trader, partner := uuid.New(), uuid.New()
+	svc := NewService(store, Options{}, zerolog.New(io.Discard))
+	ctx := context.Background()
+
+	traded := uuid.New()
+	_, err := svc.RecordMatch(ctx, traded, trader, partner, Win)
+	require.NoError(t, err)
+
+	// The season rolls over and pulls both ratings halfway back to the start
+	for userID, row := range store.ratings {
+		row.Rating = DefaultRating + (row.Rating-DefaultRating)*0.5
+		store.ratings[userID] = row
+	}
+	store.rolledOver = len(store.history)
+	reset := maps.Clone(store.ratings)
+
+	restored, err := svc.VoidMatch(ctx, traded)
+	require.NoError(t, err)
+	assert.Empty(t, restored)
+	assert.Equal(t, reset, store.ratings, "the reset already took back all but what it carried over")
+	assert.True(t, store.voided[0])
+	assert.True(t, store.voided[1])
+}
+
+func TestRecordMatchWritesNothingWhenAnUpdateFails(t *testing.T) {
+	store := newFakeStore()
+	winner, loser := uuid.New(), uuid.New()
+	store.failUpsert = loser
+	svc := NewService(store, Options{}, zerolog.New(io.Discard))
+	matchID := uuid.New()
+
+	_, err := svc.RecordMatch(context.Background(), matchID, winner, loser, Win)
+	require.Error(t, err)
+	assert.Empty(t, store.history, "no history may be left to block the retry")
+	assert.Empty(t, store.ratings)
+
+	// The finalize retry rates the match
+	store.failUpsert = uuid.Nil
+	changes, err := svc.RecordMatch(context.Background(), matchID, winner, loser, Win)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Len(t, store.history, 2)
+	assert.Equal(t, int32(1), store.ratings[winner].Games)
+	assert.Equal(t, int32(1), store.ratings[loser].Games)
+}
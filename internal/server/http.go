@@ -102,7 +102,8 @@ func createWSOriginChecker(cfg config.CORS) func(*http.Request) bool {
 // NewHTTPServer wires base routes (health, metrics) for the API service.
 // authHandlers can be nil if auth is not yet initialized.
 // authSvc is needed for applying auth middleware to protected endpoints.
-func NewHTTPServer(cfg *config.App, logger zerolog.Logger, pool *pgxpool.Pool, redis *redis.Client, authHandlers *auth.HTTPHandlers, authSvc *auth.Service, matchGetRoomHandler http.HandlerFunc, matchRoomHandler http.Handler, matchWSHandler http.HandlerFunc, leaderboardHandler http.HandlerFunc) *http.Server {
+// adminHandler serves /v1/admin/ and must already enforce the admin role.
+func NewHTTPServer(cfg *config.App, logger zerolog.Logger, pool *pgxpool.Pool, redis *redis.Client, authHandlers *auth.HTTPHandlers, authSvc *auth.Service, matchGetRoomHandler http.HandlerFunc, matchRoomHandler http.Handler, matchWSHandler http.HandlerFunc, leaderboardHandler http.HandlerFunc, matchReportHandler http.Handler, adminHandler http.Handler) *http.Server {
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
@@ -180,6 +181,16 @@ func NewHTTPServer(cfg *config.App, logger zerolog.Logger, pool *pgxpool.Pool, r
 		mux.HandleFunc("/v1/rooms/", matchGetRoomHandler)
 	}
 
+	// POST /v1/matches/{match_id}/reports - Report a question after the match (requires auth)
+	if matchReportHandler != nil {
+		mux.Handle("/v1/matches/{match_id}/reports", matchReportHandler)
+	}
+
+	// Admin endpoints (question moderation)
+	if adminHandler != nil {
+		mux.Handle("/v1/admin/", adminHandler)
+	}
+
 	// Apply CORS middleware to all routes
 	handler := corsMiddleware(cfg.CORS, logger)(mux)
 
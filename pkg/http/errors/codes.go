@@ -37,14 +37,22 @@ const (
 	ErrCodeInvalidRoomCode    = "invalid_room_code"
 	ErrCodeJoinFailed         = "join_failed"
 	ErrCodeRoomStartFailed    = "room_start_failed"
+	ErrCodeReadyFailed        = "ready_failed"
 	ErrCodeMatchCreationFailed = "match_creation_failed"
 	ErrCodeInvalidMatchID     = "invalid_match_id"
 	ErrCodeSubmitFailed       = "submit_failed"
+	ErrCodeAnswerTooLate      = "answer_too_late"
+	ErrCodeInvalidQuestionToken = "invalid_question_token"
+	ErrCodeInvalidOption      = "invalid_option"
+	ErrCodeQuestionNotActive  = "question_not_active"
+	ErrCodeProgressFailed     = "progress_failed"
+	ErrCodeLeaveFailed        = "leave_failed"
 
 	// Queue errors
 	ErrCodeEnqueueFailed      = "enqueue_failed"
 	ErrCodeInvalidQueueToken  = "invalid_queue_token"
 	ErrCodeQueueTokenNotFound = "queue_token_not_found"
+	ErrCodeBotNotOffered      = "bot_not_offered"
 
 	// WebSocket errors
 	ErrCodeInvalidPayload     = "invalid_payload"
@@ -68,8 +76,29 @@ const (
 	ErrCodeOAuthInvalidState    = "invalid_state"
 	ErrCodeUserCreationFailed   = "user_creation_failed"
 
+	// Moderation errors
+	ErrCodeQuestionNotFound  = "question_not_found"
+	ErrCodeInvalidQuestion   = "invalid_question"
+	ErrCodeModerationFailed  = "moderation_failed"
+	ErrCodeInvalidReport     = "invalid_report"
+	ErrCodeAlreadyReported   = "already_reported"
+	ErrCodeNoOpenReports     = "no_open_reports"
+	ErrCodeReportFailed      = "report_failed"
+
+	// Anti-cheat errors
+	ErrCodeCheatFlagNotFound   = "cheat_flag_not_found"
+	ErrCodeFlagAlreadyReviewed = "flag_already_reviewed"
+	ErrCodeInvalidReview       = "invalid_review"
+	ErrCodeAntiCheatFailed     = "anticheat_failed"
+
 	// Leaderboard errors
 	ErrCodeLeaderboardFetchFailed = "leaderboard_fetch_failed"
 	ErrCodeUnknownWindow          = "unknown_leaderboard_window"
+	ErrCodeNotRanked              = "not_ranked"
+	ErrCodeSeasonNotFound         = "season_not_found"
+	ErrCodeSeasonExists           = "season_exists"
+	ErrCodeSeasonOverlap          = "season_overlap"
+	ErrCodeInvalidSeason          = "invalid_season"
+	ErrCodeSeasonFailed           = "season_failed"
 )
 
@@ -0,0 +1,142 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// Envelope kinds relayed between hub instances.
+const (
+	EnvelopeUser       = "user"       // deliver Message to UserID
+	EnvelopeMatch      = "match"      // deliver Message to local members of MatchID
+	EnvelopeJoin       = "join"       // add UserID to the MatchID roster
+	EnvelopeLeave      = "leave"      // remove UserID from the MatchID roster
+	EnvelopeDisconnect = "disconnect" // UserID connected elsewhere; drop the local connection
+)
+
+// Envelope is a hub event routed through the backplane.
+type Envelope struct {
+	Origin  string    `json:"origin"`
+	Kind    string    `json:"kind"`
+	UserID  uuid.UUID `json:"user_id"`
+	MatchID uuid.UUID `json:"match_id"`
+	Message Message   `json:"message"`
+}
+
+// Backplane relays hub events between instances and tracks which users are
+// connected anywhere in the cluster.
+type Backplane interface {
+	// Publish sends an envelope to every other instance.
+	Publish(ctx context.Context, env Envelope) error
+	// Run delivers envelopes from other instances until the context is cancelled.
+	Run(ctx context.Context, deliver func(Envelope)) error
+	// MarkOnline records that this instance holds the user's connection.
+	MarkOnline(ctx context.Context, userID uuid.UUID) error
+	// MarkOffline clears the user's presence if this instance still owns it.
+	MarkOffline(ctx context.Context, userID uuid.UUID) error
+	// IsOnline reports whether any instance holds a connection for the user.
+	IsOnline(ctx context.Context, userID uuid.UUID) (bool, error)
+}
+
+// presenceTTL bounds stale presence after an instance dies; the hub refreshes it
+// every presenceRefreshInterval.
+const presenceTTL = 90 * time.Second
+
+// markOfflineScript deletes the presence key only if this instance owns it.
+var markOfflineScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+else
+	return 0
+end
+`)
+
+// RedisBackplane implements Backplane over Redis Pub/Sub, with presence kept
+// in ws:presence:{user_id} keys holding the owning instance ID.
+type RedisBackplane struct {
+	redis      *redis.Client
+	channel    string
+	instanceID string
+	logger     zerolog.Logger
+}
+
+// NewRedisBackplane creates a Redis Pub/Sub backplane. Channel defaults to "ws:fanout".
+func NewRedisBackplane(redis *redis.Client, channel string, logger zerolog.Logger) *RedisBackplane {
+	if channel == "" {
+		channel = "ws:fanout"
+	}
+	instanceID := uuid.NewString()
+	return &RedisBackplane{
+		redis:      redis,
+		channel:    channel,
+		instanceID: instanceID,
+		logger:     logger.With().Str("component", "ws_backplane").Str("instance_id", instanceID).Logger(),
+	}
+}
+
+// Publish sends an envelope to every other instance.
+func (b *RedisBackplane) Publish(ctx context.Context, env Envelope) error {
+	env.Origin = b.instanceID
+	data, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("marshal envelope: %w", err)
+	}
+	return b.redis.Publish(ctx, b.channel, data).Err()
+}
+
+// Run subscribes to the fan-out channel and blocks until the context is cancelled.
+func (b *RedisBackplane) Run(ctx context.Context, deliver func(Envelope)) error {
+	sub := b.redis.Subscribe(ctx, b.channel)
+	defer sub.Close()
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			var env Envelope
+			if err := json.Unmarshal([]byte(msg.Payload), &env); err != nil {
+				b.logger.Warn().Err(err).Msg("failed to decode backplane envelope")
+				continue
+			}
+			if env.Origin == b.instanceID {
+				continue
+			}
+			deliver(env)
+		}
+	}
+}
+
+// MarkOnline records that this instance holds the user's connection.
+func (b *RedisBackplane) MarkOnline(ctx context.Context, userID uuid.UUID) error {
+	return b.redis.Set(ctx, presenceKey(userID), b.instanceID, presenceTTL).Err()
+}
+
+// MarkOffline clears the user's presence if this instance still owns it.
+func (b *RedisBackplane) MarkOffline(ctx context.Context, userID uuid.UUID) error {
+	return markOfflineScript.Run(ctx, b.redis, []string{presenceKey(userID)}, b.instanceID).Err()
+}
+
+// IsOnline reports whether any instance holds a connection for the user.
+func (b *RedisBackplane) IsOnline(ctx context.Context, userID uuid.UUID) (bool, error) {
+	n, err := b.redis.Exists(ctx, presenceKey(userID)).Result()
+	if err != nil {
+		return false, fmt.Errorf("presence lookup: %w", err)
+	}
+	return n > 0, nil
+}
+
+func presenceKey(userID uuid.UUID) string {
+	return fmt.Sprintf("ws:presence:%s", userID.String())
+}
@@ -1,6 +1,7 @@
 package ws
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -9,11 +10,17 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// presenceRefreshInterval is how often local users' shared presence is renewed.
+const presenceRefreshInterval = 30 * time.Second
+
 // Hub manages WebSocket connections and broadcasts messages to match participants.
+// With a Backplane attached, user- and match-addressed messages also reach
+// connections held by other instances, and match rosters and presence are shared.
 type Hub struct {
 	mu          sync.RWMutex
 	connections map[uuid.UUID]*Connection // user_id -> connection
 	matches     map[uuid.UUID][]uuid.UUID // match_id -> []user_id
+	backplane   Backplane
 	logger      zerolog.Logger
 }
 
@@ -26,87 +33,88 @@ func NewHub(logger zerolog.Logger) *Hub {
 	}
 }
 
+// UseBackplane attaches a cross-instance backplane. Call before serving connections.
+func (h *Hub) UseBackplane(backplane Backplane) {
+	h.backplane = backplane
+}
+
+// Run relays envelopes from other instances and keeps presence fresh.
+// Blocks until the context is cancelled; returns immediately without a backplane.
+func (h *Hub) Run(ctx context.Context) error {
+	if h.backplane == nil {
+		return nil
+	}
+
+	go h.refreshPresence(ctx)
+	return h.backplane.Run(ctx, h.deliver)
+}
+
 // RegisterConnection adds a connection for a user.
 func (h *Hub) RegisterConnection(userID uuid.UUID, conn *Connection) {
 	h.mu.Lock()
-	defer h.mu.Unlock()
-
 	// Close existing connection if any
 	if old, exists := h.connections[userID]; exists {
 		old.Close()
 	}
 
 	h.connections[userID] = conn
+	h.mu.Unlock()
 	h.logger.Info().Str("user_id", userID.String()).Msg("connection registered")
+
+	if h.backplane != nil {
+		ctx := context.Background()
+		if err := h.backplane.MarkOnline(ctx, userID); err != nil {
+			h.logger.Warn().Err(err).Str("user_id", userID.String()).Msg("failed to mark user online")
+		}
+		// A user holds a single connection cluster-wide; drop any older one elsewhere.
+		h.publish(Envelope{Kind: EnvelopeDisconnect, UserID: userID})
+	}
 }
 
-// UnregisterConnection removes a connection.
-func (h *Hub) UnregisterConnection(userID uuid.UUID) {
+// UnregisterConnection removes a user's connection if conn is still the current one.
+// Returns false when the connection was already superseded by a newer one (a
+// reconnect here or on another instance), in which case the user's state is left untouched.
+func (h *Hub) UnregisterConnection(userID uuid.UUID, conn *Connection) bool {
 	h.mu.Lock()
-	defer h.mu.Unlock()
-
-	if conn, exists := h.connections[userID]; exists {
+	current, exists := h.connections[userID]
+	if !exists || current != conn {
+		h.mu.Unlock()
 		conn.Close()
-		delete(h.connections, userID)
-		h.logger.Info().Str("user_id", userID.String()).Msg("connection unregistered")
+		return false
 	}
+	current.Close()
+	delete(h.connections, userID)
+	h.logger.Info().Str("user_id", userID.String()).Msg("connection unregistered")
 
 	// Remove from all matches
-	for matchID, users := range h.matches {
-		for i, uid := range users {
-			if uid == userID {
-				h.matches[matchID] = append(users[:i], users[i+1:]...)
-				break
-			}
+	h.removeFromMatchesLocked(userID)
+	h.mu.Unlock()
+
+	if h.backplane != nil {
+		if err := h.backplane.MarkOffline(context.Background(), userID); err != nil {
+			h.logger.Warn().Err(err).Str("user_id", userID.String()).Msg("failed to mark user offline")
 		}
 	}
+	return true
 }
 
 // JoinMatch associates a user with a match for targeted broadcasts.
 func (h *Hub) JoinMatch(matchID, userID uuid.UUID) {
-	h.mu.Lock()
-	defer h.mu.Unlock()
-
-	users := h.matches[matchID]
-	for _, uid := range users {
-		if uid == userID {
-			return // already joined
-		}
-	}
-	h.matches[matchID] = append(users, userID)
+	h.joinMatch(matchID, userID)
+	h.publish(Envelope{Kind: EnvelopeJoin, MatchID: matchID, UserID: userID})
 }
 
 // LeaveMatch removes a user from a match.
 func (h *Hub) LeaveMatch(matchID, userID uuid.UUID) {
-	h.mu.Lock()
-	defer h.mu.Unlock()
-
-	users := h.matches[matchID]
-	for i, uid := range users {
-		if uid == userID {
-			h.matches[matchID] = append(users[:i], users[i+1:]...)
-			break
-		}
-	}
+	h.leaveMatch(matchID, userID)
+	h.publish(Envelope{Kind: EnvelopeLeave, MatchID: matchID, UserID: userID})
 }
 
 // BroadcastToMatch sends a message to all players in a match.
 func (h *Hub) BroadcastToMatch(matchID uuid.UUID, msg Message) error {
-	h.mu.RLock()
-	users := h.matches[matchID]
-	h.mu.RUnlock()
-
-	var errors []error
-	for _, userID := range users {
-		if err := h.SendToUser(userID, msg); err != nil {
-			errors = append(errors, err)
-		}
-	}
-
-	if len(errors) > 0 {
-		return errors[0] // return first error
-	}
-	return nil
+	err := h.broadcastLocal(matchID, msg)
+	h.publish(Envelope{Kind: EnvelopeMatch, MatchID: matchID, Message: msg})
+	return err
 }
 
 // BroadcastAll sends a message to every connected user.
@@ -124,17 +132,42 @@ func (h *Hub) BroadcastAll(msg Message) error {
 	return firstErr
 }
 
-// SendToUser delivers a message to a specific user.
+// SendToUser delivers a message to a specific user, on whichever instance holds the connection.
 func (h *Hub) SendToUser(userID uuid.UUID, msg Message) error {
 	h.mu.RLock()
 	conn, exists := h.connections[userID]
 	h.mu.RUnlock()
 
-	if !exists {
+	if exists {
+		return conn.Send(msg)
+	}
+
+	if h.backplane == nil {
+		return ErrConnectionNotFound
+	}
+
+	online, err := h.backplane.IsOnline(context.Background(), userID)
+	if err != nil || !online {
 		return ErrConnectionNotFound
 	}
+	return h.backplane.Publish(context.Background(), Envelope{Kind: EnvelopeUser, UserID: userID, Message: msg})
+}
+
+// IsOnline reports whether the user is connected to this or (with a backplane) any instance.
+func (h *Hub) IsOnline(ctx context.Context, userID uuid.UUID) bool {
+	if _, exists := h.GetConnection(userID); exists {
+		return true
+	}
+	if h.backplane == nil {
+		return false
+	}
 
-	return conn.Send(msg)
+	online, err := h.backplane.IsOnline(ctx, userID)
+	if err != nil {
+		h.logger.Warn().Err(err).Str("user_id", userID.String()).Msg("presence lookup failed")
+		return false
+	}
+	return online
 }
 
 // GetConnection retrieves a connection for a user.
@@ -146,6 +179,131 @@ func (h *Hub) GetConnection(userID uuid.UUID) (*Connection, bool) {
 	return conn, exists
 }
 
+// deliver applies an envelope published by another instance to local state.
+func (h *Hub) deliver(env Envelope) {
+	switch env.Kind {
+	case EnvelopeUser:
+		h.mu.RLock()
+		conn, exists := h.connections[env.UserID]
+		h.mu.RUnlock()
+		if exists {
+			if err := conn.Send(env.Message); err != nil {
+				h.logger.Warn().Err(err).Str("user_id", env.UserID.String()).Msg("relayed send failed")
+			}
+		}
+	case EnvelopeMatch:
+		_ = h.broadcastLocal(env.MatchID, env.Message)
+	case EnvelopeJoin:
+		h.joinMatch(env.MatchID, env.UserID)
+	case EnvelopeLeave:
+		h.leaveMatch(env.MatchID, env.UserID)
+	case EnvelopeDisconnect:
+		h.mu.Lock()
+		if conn, exists := h.connections[env.UserID]; exists {
+			conn.Close()
+			delete(h.connections, env.UserID)
+			h.logger.Info().Str("user_id", env.UserID.String()).Msg("connection superseded on another instance")
+		}
+		h.mu.Unlock()
+	default:
+		h.logger.Warn().Str("kind", env.Kind).Msg("unknown backplane envelope")
+	}
+}
+
+// broadcastLocal sends a message to the match members connected to this instance.
+func (h *Hub) broadcastLocal(matchID uuid.UUID, msg Message) error {
+	h.mu.RLock()
+	users := h.matches[matchID]
+	conns := make([]*Connection, 0, len(users))
+	for _, userID := range users {
+		if conn, exists := h.connections[userID]; exists {
+			conns = append(conns, conn)
+		}
+	}
+	h.mu.RUnlock()
+
+	var firstErr error
+	for _, conn := range conns {
+		if err := conn.Send(msg); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (h *Hub) joinMatch(matchID, userID uuid.UUID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	users := h.matches[matchID]
+	for _, uid := range users {
+		if uid == userID {
+			return // already joined
+		}
+	}
+	h.matches[matchID] = append(users, userID)
+}
+
+func (h *Hub) leaveMatch(matchID, userID uuid.UUID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	users := h.matches[matchID]
+	for i, uid := range users {
+		if uid == userID {
+			h.matches[matchID] = append(users[:i], users[i+1:]...)
+			break
+		}
+	}
+}
+
+func (h *Hub) removeFromMatchesLocked(userID uuid.UUID) {
+	for matchID, users := range h.matches {
+		for i, uid := range users {
+			if uid == userID {
+				h.matches[matchID] = append(users[:i], users[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// publish forwards an envelope to other instances when a backplane is attached.
+func (h *Hub) publish(env Envelope) {
+	if h.backplane == nil {
+		return
+	}
+	if err := h.backplane.Publish(context.Background(), env); err != nil {
+		h.logger.Warn().Err(err).Str("kind", env.Kind).Msg("backplane publish failed")
+	}
+}
+
+// refreshPresence renews shared presence for every local connection until ctx is done.
+func (h *Hub) refreshPresence(ctx context.Context) {
+	ticker := time.NewTicker(presenceRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.mu.RLock()
+			users := make([]uuid.UUID, 0, len(h.connections))
+			for userID := range h.connections {
+				users = append(users, userID)
+			}
+			h.mu.RUnlock()
+
+			for _, userID := range users {
+				if err := h.backplane.MarkOnline(ctx, userID); err != nil {
+					h.logger.Warn().Err(err).Str("user_id", userID.String()).Msg("failed to refresh presence")
+				}
+			}
+		}
+	}
+}
+
 // Connection represents a WebSocket connection with send queue.
 type Connection struct {
 	conn   *websocket.Conn
@@ -0,0 +1,140 @@
+package ws
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryBus links in-process hubs the way Redis Pub/Sub links instances.
+type memoryBus struct {
+	mu       sync.Mutex
+	hubs     map[string]*Hub
+	presence map[uuid.UUID]string
+}
+
+type memoryBackplane struct {
+	bus *memoryBus
+	id  string
+}
+
+func (b *memoryBackplane) Publish(ctx context.Context, env Envelope) error {
+	b.bus.mu.Lock()
+	hubs := make(map[string]*Hub, len(b.bus.hubs))
+	for id, hub := range b.bus.hubs {
+		hubs[id] = hub
+	}
+	b.bus.mu.Unlock()
+
+	for id, hub := range hubs {
+		if id != b.id {
+			hub.deliver(env)
+		}
+	}
+	return nil
+}
+
+func (b *memoryBackplane) Run(ctx context.Context, deliver func(Envelope)) error { return nil }
+
+func (b *memoryBackplane) MarkOnline(ctx context.Context, userID uuid.UUID) error {
+	b.bus.mu.Lock()
+	defer b.bus.mu.Unlock()
+	b.bus.presence[userID] = b.id
+	return nil
+}
+
+func (b *memoryBackplane) MarkOffline(ctx context.Context, userID uuid.UUID) error {
+	b.bus.mu.Lock()
+	defer b.bus.mu.Unlock()
+	if b.bus.presence[userID] == b.id {
+		delete(b.bus.presence, userID)
+	}
+	return nil
+}
+
+func (b *memoryBackplane) IsOnline(ctx context.Context, userID uuid.UUID) (bool, error) {
+	b.bus.mu.Lock()
+	defer b.bus.mu.Unlock()
+	_, ok := b.bus.presence[userID]
+	return ok, nil
+}
+
+func newLinkedHubs(n int) []*Hub {
+	bus := &memoryBus{hubs: make(map[string]*Hub), presence: make(map[uuid.UUID]string)}
+	hubs := make([]*Hub, n)
+	for i := range hubs {
+		id := uuid.NewString()
+		hubs[i] = NewHub(zerolog.New(io.Discard))
+		hubs[i].UseBackplane(&memoryBackplane{bus: bus, id: id})
+		bus.hubs[id] = hubs[i]
+	}
+	return hubs
+}
+
+// testConnection is a Connection without a socket; messages stay in sendCh.
+func testConnection() *Connection {
+	return &Connection{sendCh: make(chan Message, 8), logger: zerolog.New(io.Discard)}
+}
+
+func TestHubRoutesAcrossInstances(t *testing.T) {
+	hubs := newLinkedHubs(2)
+	alice, bob := uuid.New(), uuid.New()
+	aliceConn, bobConn := testConnection(), testConnection()
+
+	hubs[0].RegisterConnection(alice, aliceConn)
+	hubs[1].RegisterConnection(bob, bobConn)
+
+	// The match is created on instance 0, but bob is connected to instance 1.
+	matchID := uuid.New()
+	hubs[0].JoinMatch(matchID, alice)
+	hubs[0].JoinMatch(matchID, bob)
+
+	require.NoError(t, hubs[0].BroadcastToMatch(matchID, Message{Type: TypeMatchFound}))
+	assert.Equal(t, TypeMatchFound, (<-aliceConn.sendCh).Type)
+	assert.Equal(t, TypeMatchFound, (<-bobConn.sendCh).Type)
+
+	// Rosters are shared, so instance 1 can broadcast to the same match.
+	require.NoError(t, hubs[1].BroadcastToMatch(matchID, Message{Type: TypeQuestionTick}))
+	assert.Equal(t, TypeQuestionTick, (<-aliceConn.sendCh).Type)
+	assert.Equal(t, TypeQuestionTick, (<-bobConn.sendCh).Type)
+
+	require.NoError(t, hubs[0].SendToUser(bob, Message{Type: TypeAnswerAck}))
+	assert.Equal(t, TypeAnswerAck, (<-bobConn.sendCh).Type)
+	assert.Empty(t, aliceConn.sendCh)
+}
+
+func TestHubSharedPresence(t *testing.T) {
+	hubs := newLinkedHubs(2)
+	user := uuid.New()
+	ctx := context.Background()
+
+	assert.False(t, hubs[1].IsOnline(ctx, user))
+	assert.ErrorIs(t, hubs[1].SendToUser(user, Message{Type: TypePing}), ErrConnectionNotFound)
+
+	hubs[0].RegisterConnection(user, testConnection())
+	assert.True(t, hubs[1].IsOnline(ctx, user))
+}
+
+func TestHubUnregisterIgnoresSupersededConnection(t *testing.T) {
+	hub := NewHub(zerolog.New(io.Discard))
+	user := uuid.New()
+	matchID := uuid.New()
+
+	// Test connections have no socket; mark the stale one closed so Close is a no-op.
+	first, second := testConnection(), testConnection()
+	first.closed = true
+	hub.connections[user] = first
+	hub.JoinMatch(matchID, user)
+	hub.connections[user] = second
+
+	assert.False(t, hub.UnregisterConnection(user, first))
+	_, exists := hub.GetConnection(user)
+	assert.True(t, exists)
+	assert.Contains(t, hub.matches[matchID], user)
+}
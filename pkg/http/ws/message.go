@@ -10,12 +10,16 @@ const (
 	TypeAcceptBotFill   = "accept_bot_fill"
 	TypeJoinPrivate     = "join_private"
 	TypeReadyState      = "ready_state"
+	TypeStartPrivate    = "start_private"
 	TypeSubmitAnswer    = "submit_answer"
 	TypeLeaveMatch      = "leave_match"
 	TypeRequestProgress = "request_progress"
+	TypeResumeMatch     = "resume_match"
+	TypeReportQuestion  = "report_question"
 
 	// Server -> Client
 	TypeQueueUpdate       = "queue_update"
+	TypeQueueExpired      = "queue_expired"
 	TypeBotOffer          = "bot_offer"
 	TypeMatchFound        = "match_found"
 	TypePrivateRoomUpdate = "private_room_update"
@@ -27,6 +31,9 @@ const (
 	TypeMatchComplete     = "match_complete"
 	TypeLeaderboardUpdate = "leaderboard_update"
 	TypeMatchTimeout      = "match_timeout"
+	TypeMatchResume       = "match_resume"
+	TypePlayerLeft        = "player_left"
+	TypeReportReceived    = "report_received"
 	TypeError             = "error"
 	TypePing              = "ping"
 	TypePong              = "pong"
@@ -45,6 +52,7 @@ type JoinQueuePayload struct {
 	QueueToken    string `json:"queue_token"`
 	QuestionCount int    `json:"question_count,omitempty"` // 5, 10, or 15 (default: 10)
 	Category      string `json:"category,omitempty"`       // e.g., "general", "science", "history" (default: "general")
+	Difficulty    string `json:"difficulty,omitempty"`     // "easy", "medium" or "hard"; also selects the bot level
 }
 
 type CancelQueuePayload struct {
@@ -61,14 +69,22 @@ type JoinPrivatePayload struct {
 }
 
 type ReadyStatePayload struct {
-	MatchID string `json:"match_id"`
-	Ready   bool   `json:"ready"`
+	MatchID  string `json:"match_id"`
+	RoomCode string `json:"room_code"`
+	Ready    bool   `json:"ready"`
+}
+
+type StartPrivatePayload struct {
+	RoomCode string `json:"room_code"`
 }
 
+// SubmitAnswerPayload answers a question. OptionIndex refers to the options in the
+// order they were sent; older clients send the option text in Answer instead.
 type SubmitAnswerPayload struct {
 	MatchID         string `json:"match_id"`
 	QuestionToken   string `json:"question_token"`
-	Answer          string `json:"answer"`
+	OptionIndex     *int   `json:"option_index,omitempty"`
+	Answer          string `json:"answer,omitempty"`
 	ClientLatencyMs int    `json:"client_latency_ms"`
 }
 
@@ -81,6 +97,13 @@ type RequestProgressPayload struct {
 	MatchID string `json:"match_id"`
 }
 
+type ReportQuestionPayload struct {
+	MatchID       string `json:"match_id"`
+	QuestionToken string `json:"question_token"`
+	Reason        string `json:"reason"` // wrong_answer, ambiguous, typo, offensive or other
+	Text          string `json:"text,omitempty"`
+}
+
 // Server Messages (outgoing)
 
 type QueueUpdatePayload struct {
@@ -90,6 +113,13 @@ type QueueUpdatePayload struct {
 	WaitSeconds int    `json:"wait_seconds"`
 }
 
+// QueueExpiredPayload tells a player their queue entry was dropped after waiting
+// too long; they have to join the queue again.
+type QueueExpiredPayload struct {
+	QueueToken  string `json:"queue_token"`
+	WaitSeconds int    `json:"wait_seconds"`
+}
+
 type BotOfferPayload struct {
 	QueueToken      string `json:"queue_token"`
 	DeadlineSeconds int    `json:"deadline_seconds"`
@@ -107,13 +137,19 @@ type MatchFoundPayload struct {
 type Player struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
+	IsHost   bool   `json:"is_host,omitempty"`
+	Ready    bool   `json:"ready,omitempty"`
 }
 
 type PrivateRoomUpdatePayload struct {
-	MatchID        string   `json:"match_id"`
-	RoomCode       string   `json:"room_code"`
-	Players        []Player `json:"players"`
-	SlotsRemaining int      `json:"slots_remaining"`
+	MatchID         string   `json:"match_id"`
+	RoomCode        string   `json:"room_code"`
+	Status          string   `json:"status"`
+	Players         []Player `json:"players"`
+	SlotsRemaining  int      `json:"slots_remaining"`
+	ReadyCount      int      `json:"ready_count"`
+	MinReadyPlayers int      `json:"min_ready_players,omitempty"`
+	CanStart        bool     `json:"can_start"`
 }
 
 type CountdownPayload struct {
@@ -150,6 +186,12 @@ type AnswerAckPayload struct {
 	ServerReceivedAt string `json:"server_received_at"`
 }
 
+type ReportReceivedPayload struct {
+	MatchID       string `json:"match_id"`
+	QuestionToken string `json:"question_token"`
+	ReportID      string `json:"report_id"`
+}
+
 type ProgressUpdatePayload struct {
 	MatchID string           `json:"match_id"`
 	Players []PlayerProgress `json:"players"`
@@ -166,16 +208,27 @@ type MatchCompletePayload struct {
 	MatchID             string        `json:"match_id"`
 	Results             []MatchResult `json:"results"`
 	LeaderboardEligible bool          `json:"leaderboard_eligible"`
-	LeaderboardPosition int           `json:"leaderboard_position,omitempty"`
 }
 
 type MatchResult struct {
-	UserID             string  `json:"user_id"`
-	Username           string  `json:"username"`
-	FinalScore         int     `json:"final_score"`
-	Accuracy           float64 `json:"accuracy"`
-	StreakBonusApplied float64 `json:"streak_bonus_applied"`
-	Status             string  `json:"status"`
+	UserID             string        `json:"user_id"`
+	Username           string        `json:"username"`
+	FinalScore         int           `json:"final_score"`
+	Accuracy           float64       `json:"accuracy"`
+	StreakBonusApplied float64       `json:"streak_bonus_applied"`
+	Status             string        `json:"status"`
+	LeaveReason        string        `json:"leave_reason,omitempty"`
+	Won                bool          `json:"won"`
+	Rating             *RatingChange `json:"rating,omitempty"` // rated matches only
+	// Rank on the board the result was recorded to; eligible matches only
+	LeaderboardPosition int `json:"leaderboard_position,omitempty"`
+}
+
+// RatingChange is a player's skill rating before and after a rated match.
+type RatingChange struct {
+	Before int `json:"before"`
+	After  int `json:"after"`
+	Delta  int `json:"delta"`
 }
 
 type LeaderboardUpdatePayload struct {
@@ -194,6 +247,32 @@ type LeaderboardEntry struct {
 	Accuracy float64 `json:"accuracy"`
 }
 
+type MatchResumePayload struct {
+	MatchID              string            `json:"match_id"`
+	Mode                 string            `json:"mode"`
+	QuestionCount        int               `json:"question_count"`
+	PerQuestionSeconds   int               `json:"per_question_seconds"`
+	GlobalTimeoutSeconds int               `json:"global_timeout_seconds"`
+	StartedAt            string            `json:"started_at"`
+	QuestionOrder        int               `json:"question_order"`
+	RemainingSeconds     int               `json:"remaining_seconds"`
+	Batch                []QuestionPayload `json:"batch"`
+	Answers              []ResumedAnswer   `json:"answers"`
+}
+
+type ResumedAnswer struct {
+	QuestionOrder int    `json:"question_order"`
+	Answer        string `json:"answer"`
+	SubmittedAt   string `json:"submitted_at"`
+}
+
+type PlayerLeftPayload struct {
+	MatchID  string `json:"match_id"`
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Reason   string `json:"reason"`
+}
+
 type MatchTimeoutPayload struct {
 	MatchID string `json:"match_id"`
 	Reason  string `json:"reason"`
@@ -90,7 +90,7 @@ func TestValidationErrors(t *testing.T) {
 			name: "invalid max_players",
 			payload: map[string]interface{}{
 				"match_name":           "Test",
-				"max_players":           3, // must be 2
+				"max_players":           9, // must be between 2 and 8
 				"question_count":       5,
 				"per_question_seconds": 15,
 			},
@@ -9,6 +9,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
+
 	wsmsg "github.com/gokatarajesh/quiz-platform/pkg/http/ws"
 )
 
@@ -37,8 +39,11 @@ func TestPrivateRoomMatchFlow(t *testing.T) {
 		t.Fatalf("failed to send join_private: %v", err)
 	}
 
-	// Wait for questions to be sent (match should start automatically when second player joins)
-	deadline := time.Now().Add(10 * time.Second)
+	readyAndStartRoom(t, hostConn, playerConn, roomCode)
+
+	// Wait for questions to be sent (after the start countdown)
+	deadline := time.Now().Add(15 * time.Second)
+	var countdownReceived bool
 	var questionsReceived bool
 	var matchID string
 
@@ -49,6 +54,10 @@ func TestPrivateRoomMatchFlow(t *testing.T) {
 			continue
 		}
 
+		if msg.Type == wsmsg.TypeCountdown {
+			countdownReceived = true
+		}
+
 		if msg.Type == wsmsg.TypeQuestionBatch {
 			var payload wsmsg.QuestionBatchPayload
 			if err := json.Unmarshal(msg.Payload, &payload); err != nil {
@@ -66,6 +75,10 @@ func TestPrivateRoomMatchFlow(t *testing.T) {
 		t.Fatal("timeout waiting for question batch")
 	}
 
+	if !countdownReceived {
+		t.Fatal("question batch arrived without a countdown")
+	}
+
 	if matchID == "" {
 		t.Fatal("match ID is empty")
 	}
@@ -80,7 +93,10 @@ func TestAnswerSubmission(t *testing.T) {
 
 	roomCode := createRoom(t, baseURL, host.AccessToken)
 
-	// Connect player to WebSocket
+	// Connect both players to WebSocket
+	hostConn := dialMatchWS(t, baseWS, host.AccessToken)
+	defer hostConn.Close()
+
 	playerConn := dialMatchWS(t, baseWS, player.AccessToken)
 	defer playerConn.Close()
 
@@ -93,10 +109,12 @@ func TestAnswerSubmission(t *testing.T) {
 		t.Fatalf("failed to send join_private: %v", err)
 	}
 
+	readyAndStartRoom(t, hostConn, playerConn, roomCode)
+
 	// Wait for questions
 	var questionToken string
 	var matchID string
-	deadline := time.Now().Add(10 * time.Second)
+	deadline := time.Now().Add(15 * time.Second)
 
 	for time.Now().Before(deadline) {
 		playerConn.SetReadDeadline(time.Now().Add(2 * time.Second))
@@ -173,3 +191,47 @@ func TestMatchCompletion(t *testing.T) {
 	t.Skip("Match completion test requires full match flow implementation")
 }
 
+// readyAndStartRoom marks the joined player ready and has the host start the room
+// once the server reports that it can start.
+func readyAndStartRoom(t *testing.T, hostConn, playerConn *websocket.Conn, roomCode string) {
+	t.Helper()
+
+	readyMsg := wsmsg.Message{
+		Type:    wsmsg.TypeReadyState,
+		Payload: json.RawMessage(fmt.Sprintf(`{"room_code": "%s", "ready": true}`, roomCode)),
+	}
+	if err := playerConn.WriteJSON(readyMsg); err != nil {
+		t.Fatalf("failed to send ready_state: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		hostConn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		var msg wsmsg.Message
+		if err := hostConn.ReadJSON(&msg); err != nil {
+			continue
+		}
+		if msg.Type != wsmsg.TypePrivateRoomUpdate {
+			continue
+		}
+
+		var update wsmsg.PrivateRoomUpdatePayload
+		if err := json.Unmarshal(msg.Payload, &update); err != nil {
+			t.Fatalf("decode private room update failed: %v", err)
+		}
+		if !update.CanStart {
+			continue
+		}
+
+		startMsg := wsmsg.Message{
+			Type:    wsmsg.TypeStartPrivate,
+			Payload: json.RawMessage(fmt.Sprintf(`{"room_code": "%s"}`, roomCode)),
+		}
+		if err := hostConn.WriteJSON(startMsg); err != nil {
+			t.Fatalf("failed to send start_private: %v", err)
+		}
+		return
+	}
+
+	t.Fatal("timeout waiting for room to become startable")
+}